@@ -68,6 +68,19 @@ func ProjectCacheDir() string {
 	return cacheDir
 }
 
+// ScriptCommandsDir returns the directory scanned for script commands, WATOOLS_COMMANDS_DIR overrides <cache>/commands
+func ScriptCommandsDir() string {
+	if dir := os.Getenv("WATOOLS_COMMANDS_DIR"); dir != "" {
+		return filepath.Clean(dir)
+	}
+	return filepath.Join(ProjectCacheDir(), "commands")
+}
+
+// ClipboardImageDir returns the directory holding the images of the clipboard history, named by content hash
+func ClipboardImageDir() string {
+	return filepath.Join(ProjectCacheDir(), "clipboard")
+}
+
 func InitWithWailsContext(ctx context.Context) {
 	initOnce.Do(func() {
 		wailsCtx = ctx
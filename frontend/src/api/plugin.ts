@@ -2,7 +2,6 @@ import {Plugin} from "@/schemas/plugin"
 import {
     GetPluginJsEntryUrlApi,
     GetPluginsApi,
-    UpdatePluginUsageApi,
     InstallPluginApi,
     UninstallPluginApi,
     TogglePluginApi
@@ -63,15 +62,6 @@ export const getPlugins = async (): Promise<Plugin[]> => {
     return plugins
 }
 
-export const updatePluginUsage = async (updates: Array<{ packageId: string; lastUsedAt: Date; usedCount: number }>) => {
-    const formattedUpdates = updates.map(update => ({
-        packageId: update.packageId,
-        lastUsedAt: update.lastUsedAt.toISOString(),
-        usedCount: update.usedCount
-    }));
-    return UpdatePluginUsageApi(formattedUpdates);
-}
-
 export const installPlugin = async (wtFilePath: string) => {
     return InstallPluginApi(wtFilePath)
 }
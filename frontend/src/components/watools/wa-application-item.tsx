@@ -1,37 +1,26 @@
 import {useMemo} from "react";
-import {CommandType} from "@/schemas/command";
+import {CommandSearchResultType, CommandType} from "@/schemas/command";
 import {WaIcon} from "@/components/watools/wa-icon";
-import {useApplicationCommandStore} from "@/stores/applicationCommandStore";
+import {applicationsOf} from "@/api/command";
 import {BaseItemProps} from "@/components/watools/wa-base-item";
 import {compareRankableItems, RankingInputContext, RankingSelectionRecord} from "@/lib/command-ranking";
 
 type UseApplicationItemsParams = {
-    searchKey: string;
+    searchResults: CommandSearchResultType[];
     rankingContext: RankingInputContext;
     rankingHistory: RankingSelectionRecord[];
     onTriggerCommand: (command: CommandType) => void;
 }
 
 export const useApplicationItems = ({
-    searchKey,
+    searchResults,
     rankingContext,
     rankingHistory,
     onTriggerCommand
 }: UseApplicationItemsParams) => {
-    const {
-        commandGroup,
-        isLoading,
-        searchCommands,
-        updateCommandUsage
-    } = useApplicationCommandStore();
-
-
     return useMemo((): BaseItemProps[] => {
-        if (!searchKey || !commandGroup || isLoading) {
-            return [];
-        }
-
-        const commands = searchCommands(searchKey, 10)
+        const commands = applicationsOf(searchResults)
+            .slice(0, 10)
             .map((command, index) => ({
                 command,
                 rankingMeta: {
@@ -64,10 +53,7 @@ export const useApplicationItems = ({
             usedCount: command.usedCount,
             rankingMeta,
             subtitle: command.path,
-            onSelect: async () => {
-                await updateCommandUsage(command.id);
-                onTriggerCommand(command);
-            }
+            onSelect: () => onTriggerCommand(command)
         }));
-    }, [searchKey, commandGroup, searchCommands, isLoading, updateCommandUsage, onTriggerCommand, rankingContext, rankingHistory]);
+    }, [searchResults, onTriggerCommand, rankingContext, rankingHistory]);
 };
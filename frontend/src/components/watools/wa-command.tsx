@@ -4,6 +4,7 @@ import React, {useCallback, useEffect, useMemo, useRef} from "react";
 import {cn} from "@/lib/utils";
 import {CommandType} from "@/schemas/command";
 import {useWindowFocus} from "@/hooks/useWindowFocus";
+import {useCommandSearch} from "@/hooks/useCommandSearch";
 import {PluginCommandEntry, usePluginItems} from "@/components/watools/wa-plugin-item";
 import {HideAppApi, HideOrShowAppApi, TriggerCommandApi,} from "../../../wailsjs/go/coordinator/WaAppCoordinator";
 import {useAppStore, usePluginStore} from "@/stores";
@@ -19,7 +20,6 @@ import {getClipboardContent} from "@/api/app";
 import {PluginContext} from "@/schemas/plugin";
 import {useShallow} from "zustand/react/shallow";
 import {createWaToolsApi} from "@/api/api";
-import {useApplicationCommandStore} from "@/stores/applicationCommandStore";
 import {compareRankableItems, createRankingInputContext} from "@/lib/command-ranking";
 import {useCommandRankingStore} from "@/stores";
 import {persistPluginLaunchContext} from "@/lib/plugin-context";
@@ -30,8 +30,6 @@ export const WaCommand = () => {
     const commandListRef = useRef<HTMLDivElement>(null)
     const [isPasted, setIsPasted] = React.useState<boolean>(false)
     const {updatePluginUsage} = usePluginStore()
-    const flushPluginUsage = usePluginStore(state => state.flushBufferUpdates)
-    const flushApplicationUsage = useApplicationCommandStore(state => state.flushBufferUpdates)
     const rankingHistory = useCommandRankingStore(state => state.history)
     const recordSelection = useCommandRankingStore(state => state.recordSelection)
     const [_, navigate] = useLocation()
@@ -64,12 +62,10 @@ export const WaCommand = () => {
 
     const onTriggerCommand = useCallback((command: CommandType) => {
         clearValue()
-        TriggerCommandApi(command.triggerId, command.category).then(() => {
-            void Promise.allSettled([flushApplicationUsage(), flushPluginUsage()]).finally(() => {
-                void HideAppApi()
-            })
+        TriggerCommandApi(command.triggerId, command.category, {}).then(() => {
+            void HideAppApi()
         })
-    }, [clearValue, flushApplicationUsage, flushPluginUsage])
+    }, [clearValue])
 
     const onTriggerPluginCommand = useCallback(async (entry: PluginCommandEntry, context: PluginContext) => {
         // Update plugin usage statistics
@@ -100,7 +96,6 @@ export const WaCommand = () => {
             try {
                 entry.execute && await entry.execute(context)
                 clearValue()
-                await Promise.allSettled([flushApplicationUsage(), flushPluginUsage()])
                 void HideAppApi()
             } catch (error) {
                 Logger.error(`Failed to execute plugin command: ${error}`)
@@ -114,18 +109,21 @@ export const WaCommand = () => {
                 }
             }
         }
-    }, [updatePluginUsage, navigate, clearValue, flushApplicationUsage, flushPluginUsage])
+    }, [updatePluginUsage, navigate, clearValue])
+
+    // Applications and operations are matched by the backend search index, plugins and app features in JS
+    const searchResults = useCommandSearch(value)
 
     // Get items from hooks directly
     const applicationItems = useApplicationItems({
-        searchKey: value,
+        searchResults,
         rankingContext,
         rankingHistory,
         onTriggerCommand
     });
 
     const operationItems = useOperationItems({
-        searchKey: value,
+        searchResults,
         rankingContext,
         rankingHistory,
         onTriggerCommand
@@ -209,9 +207,7 @@ export const WaCommand = () => {
             if (isDevMode()) {
                 return
             }
-            void Promise.allSettled([flushApplicationUsage(), flushPluginUsage()]).finally(() => {
-                void HideAppApi()
-            })
+            void HideAppApi()
         }
     })
 
@@ -223,9 +219,7 @@ export const WaCommand = () => {
                 if (getIsPanelOpen()) {
                     clearValue()
                 } else {
-                    void Promise.allSettled([flushApplicationUsage(), flushPluginUsage()]).finally(() => {
-                        void HideOrShowAppApi()
-                    })
+                    void HideOrShowAppApi()
                 }
             } else if (e.key === "Tab") {
                 e.preventDefault()
@@ -241,7 +235,7 @@ export const WaCommand = () => {
         return () => {
             window.removeEventListener("keydown", handleHotkey)
         }
-    }, [clearValue, flushApplicationUsage, flushPluginUsage])
+    }, [clearValue])
 
     const handlePaste = useCallback(() => {
         setIsPasted(true)
@@ -1,65 +1,28 @@
-import {useEffect, useMemo, useState} from "react";
-import {CommandType, OperationCommandType} from "@/schemas/command";
-import {getOperationCommands} from "@/api/command";
+import {useMemo} from "react";
+import {CommandSearchResultType, CommandType} from "@/schemas/command";
+import {operationsOf} from "@/api/command";
 import {BaseItemProps} from "@/components/watools/wa-base-item";
-import Fuse from "fuse.js";
 import {WaIcon} from "@/components/watools/wa-icon";
 import {compareRankableItems, RankingInputContext, RankingSelectionRecord} from "@/lib/command-ranking";
 
-const dedupeOperations = (commands: OperationCommandType[]) => {
-    const uniqueCommands = new Map<string, OperationCommandType>();
-    for (const command of commands) {
-        if (!uniqueCommands.has(command.triggerId)) {
-            uniqueCommands.set(command.triggerId, command);
-        }
-    }
-    return Array.from(uniqueCommands.values());
-}
-
 type UseOperationItemsParams = {
-    searchKey: string;
+    searchResults: CommandSearchResultType[];
     rankingContext: RankingInputContext;
     rankingHistory: RankingSelectionRecord[];
     onTriggerCommand: (command: CommandType) => void;
 }
 
 export const useOperationItems = ({
-    searchKey,
+    searchResults,
     rankingContext,
     rankingHistory,
     onTriggerCommand
 }: UseOperationItemsParams) => {
-    const [operationCommands, setOperationCommands] = useState<OperationCommandType[]>([]);
-
-    const operationFuse = useMemo(() => {
-        if (operationCommands.length === 0) return null;
-        return new Fuse(operationCommands, {
-            threshold: 0.4,
-            minMatchCharLength: 1,
-            useExtendedSearch: true,
-            ignoreLocation: true,
-            shouldSort: false,
-            keys: [{name: 'name', weight: 1.0}]
-        });
-    }, [operationCommands]);
-
-    useEffect(() => {
-        const initApplication = () => {
-            getOperationCommands().then(commandGroup => {
-                setOperationCommands(dedupeOperations(commandGroup.commands));
-            });
-        };
-        initApplication();
-    }, []);
-
     const filteredItems = useMemo((): BaseItemProps[] => {
-        if (!searchKey || !operationFuse) {
-            return [];
-        }
-
-        const results = operationFuse.search(searchKey, {limit: 5})
-            .map((result, index) => ({
-                command: result.item,
+        const results = operationsOf(searchResults)
+            .slice(0, 5)
+            .map((command, index) => ({
+                command,
                 rankingMeta: {
                     source: "operation" as const,
                     sourceOrder: index,
@@ -88,7 +51,7 @@ export const useOperationItems = ({
                 onSelect: () => onTriggerCommand(command)
             };
         });
-    }, [searchKey, operationFuse, onTriggerCommand, rankingContext, rankingHistory]);
+    }, [searchResults, onTriggerCommand, rankingContext, rankingHistory]);
 
     return filteredItems;
 };
@@ -5,8 +5,6 @@ import {WaPlugin} from "@/components/watools/wa-plugin";
 import {WaPluginManagement} from "@/components/watools/wa-plugin-management";
 import {useEffect} from "react";
 import {WaApi} from "@/api/api";
-import {usePluginStore} from "@/stores/pluginStore";
-import {useApplicationCommandStore} from "@/stores/applicationCommandStore";
 import {useLocation} from "wouter";
 import {cn} from "@/lib/utils";
 
@@ -17,8 +15,6 @@ const Watools = () => {
     const windowRef = useElementResize<HTMLDivElement>({
         onResize: resizeWindowHeight
     })
-    const flushPluginUsage = usePluginStore(state => state.flushBufferUpdates)
-    const flushApplicationUsage = useApplicationCommandStore(state => state.flushBufferUpdates)
 
     useEffect(() => {
         // @ts-ignore
@@ -29,22 +25,6 @@ const Watools = () => {
         }
     }, []);
 
-    useEffect(() => {
-        const flushUsageBuffers = () => {
-            void flushApplicationUsage()
-            void flushPluginUsage()
-        }
-
-        window.addEventListener("beforeunload", flushUsageBuffers)
-        window.addEventListener("pagehide", flushUsageBuffers)
-
-        return () => {
-            window.removeEventListener("beforeunload", flushUsageBuffers)
-            window.removeEventListener("pagehide", flushUsageBuffers)
-            flushUsageBuffers()
-        }
-    }, [flushApplicationUsage, flushPluginUsage]);
-
     const isFixedHeightRoute = location === '/plugin' || location === '/plugin-management'
 
     return <div
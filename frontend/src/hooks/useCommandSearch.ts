@@ -0,0 +1,41 @@
+import {useEffect, useState} from "react";
+import {CommandSearchResultType} from "@/schemas/command";
+import {searchCommands} from "@/api/command";
+import {EventsOn} from "../../wailsjs/runtime";
+import {Logger} from "@/lib/logger";
+
+// COMMAND_SEARCH_LIMIT leaves room for the commands of the sources that are not shown in the launcher
+const COMMAND_SEARCH_LIMIT = 50
+
+/**
+ * Searches the backend index for searchKey, the results are searched again when a command source changes.
+ * A response that arrives after the key changed is dropped.
+ */
+export const useCommandSearch = (searchKey: string): CommandSearchResultType[] => {
+    const [results, setResults] = useState<CommandSearchResultType[]>([])
+    const [version, setVersion] = useState(0)
+
+    useEffect(() => {
+        return EventsOn('watools.commandsChanged', () => setVersion(current => current + 1))
+    }, [])
+
+    useEffect(() => {
+        if (!searchKey.trim()) {
+            setResults([])
+            return
+        }
+        let cancelled = false
+        searchCommands(searchKey, COMMAND_SEARCH_LIMIT).then(found => {
+            if (!cancelled) {
+                setResults(found)
+            }
+        }).catch(error => {
+            Logger.error(`Failed to search commands: ${error}`)
+        })
+        return () => {
+            cancelled = true
+        }
+    }, [searchKey, version])
+
+    return results
+}
@@ -17,10 +17,6 @@ export type ApplicationCommandType = CommandType & {
     iconPath: string
     id: string
 
-    // calculated
-    namePinyin: string | null
-    nameInitial: string | null
-    pathName: string
     isUserApp: boolean
 
     lastUsedAt: Date | null
@@ -32,6 +28,20 @@ export type OperationCommandType = CommandType & {
 }
 
 
+/**
+ * A command matched by SearchApi
+ * - field: which text of the command matched, its name, an alias or keyword, or its description
+ * - highlights: rune offsets into text
+ */
+export type CommandSearchResultType = {
+    command: CommandType & Record<string, any>
+    source: string
+    score: number
+    field: "title" | "keyword" | "description"
+    text: string
+    highlights: { start: number, end: number }[]
+}
+
 export type CommandGroupType<T extends CommandType> = {
     category: CommandCategoryType,
     commands: T[]
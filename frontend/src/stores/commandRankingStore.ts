@@ -1,6 +1,8 @@
 import {create} from "zustand";
 import {createJSONStorage, persist} from "zustand/middleware";
 import {RankingInputContext, RankingSelectionRecord, RankingSourceType} from "@/lib/command-ranking";
+import {recordSelection} from "@/api/command";
+import {Logger} from "@/lib/logger";
 
 const MAX_SELECTION_HISTORY = 30;
 
@@ -19,17 +21,25 @@ export const useCommandRankingStore = create<CommandRankingState>()(
     persist(
         (set) => ({
             history: [],
-            recordSelection: ({triggerId, source, input}) => set(state => ({
-                history: [{
+            recordSelection: ({triggerId, source, input}) => {
+                recordSelection({
                     triggerId,
                     source,
-                    inputKey: input.key,
-                    normalizedValue: input.normalizedValue,
-                    valueType: input.valueType,
-                    clipboardContentType: input.clipboardContentType,
-                    selectedAt: new Date().toISOString(),
-                }, ...state.history].slice(0, MAX_SELECTION_HISTORY)
-            })),
+                    query: input.normalizedValue,
+                    clipboardType: input.clipboardContentType,
+                }).catch(error => Logger.error(`Failed to record selection: ${error}`))
+                set(state => ({
+                    history: [{
+                        triggerId,
+                        source,
+                        inputKey: input.key,
+                        normalizedValue: input.normalizedValue,
+                        valueType: input.valueType,
+                        clipboardContentType: input.clipboardContentType,
+                        selectedAt: new Date().toISOString(),
+                    }, ...state.history].slice(0, MAX_SELECTION_HISTORY)
+                }))
+            },
         }),
         {
             name: "watools-command-ranking",
@@ -1,4 +1,3 @@
 export {usePluginStore} from './pluginStore'
-export {useApplicationCommandStore} from './applicationCommandStore'
 export {useAppStore} from './appStore'
 export {useCommandRankingStore} from './commandRankingStore'
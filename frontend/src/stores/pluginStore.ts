@@ -1,56 +1,26 @@
 import {create} from 'zustand'
 import {Plugin} from '@/schemas/plugin'
-import {getPlugins, updatePluginUsage, togglePlugin as togglePluginApi, uninstallPlugin as uninstallPluginApi} from "@/api/plugin";
+import {getPlugins, togglePlugin as togglePluginApi, uninstallPlugin as uninstallPluginApi} from "@/api/plugin";
 import {Logger} from "@/lib/logger";
 
 interface PluginState {
     plugins: Plugin[]
     isLoading: boolean
     error: string | null
-    updateBuffer: Map<string, { lastUsedAt: Date, usedCount: number }>
     fetchPlugins: () => Promise<void>
     refreshPlugins: () => Promise<void>
     getPluginById: (packageId: string) => Plugin | undefined
     getEnabledPlugins: () => Plugin[]
     getPluginsByType: (type: "executable" | "ui") => Plugin[]
     updatePluginUsage: (packageId: string) => Promise<void>
-    flushBufferUpdates: () => Promise<void>
     togglePlugin: (packageId: string, enabled: boolean) => Promise<void>
     uninstallPlugin: (packageId: string) => Promise<void>
 }
 
-const DEBOUNCE_DELAY = 60000
-
 export const usePluginStore = create<PluginState>((set, get) => {
-    let debounceTimer: ReturnType<typeof setTimeout> | null = null
     let isInitialized = false
     let loadPromise: Promise<void> | null = null
 
-    const debouncedFlushUpdates = () => {
-        if (debounceTimer) {
-            clearTimeout(debounceTimer)
-        }
-
-        debounceTimer = setTimeout(async () => {
-            const {updateBuffer} = get()
-
-            if (updateBuffer.size === 0) return
-
-            try {
-                const updates = Array.from(updateBuffer.entries()).map(([packageId, data]) => ({
-                    packageId,
-                    lastUsedAt: data.lastUsedAt,
-                    usedCount: data.usedCount
-                }))
-
-                await updatePluginUsage(updates)
-                set({updateBuffer: new Map()})
-            } catch (error) {
-                Logger.error(`Failed to flush plugin buffer updates: ${error}`)
-            }
-        }, DEBOUNCE_DELAY)
-    }
-
     const fetchPlugins = async () => {
         if (isInitialized) return
         if (loadPromise) return loadPromise
@@ -94,51 +64,19 @@ export const usePluginStore = create<PluginState>((set, get) => {
         )
     }
 
-    const updatePluginUsageMethod = async (packageId: string) => {
+    // Only updates the local state, the stored usage count is incremented by RecordSelectionApi
+    const updatePluginUsage = async (packageId: string) => {
         const plugin = getPluginById(packageId);
         if (!plugin) return;
 
         const now = new Date();
-        const newUsedCount = plugin.usedCount + 1;
-
-        // Update local state immediately for UI responsiveness
         set(state => ({
             plugins: state.plugins.map(p =>
                 p.packageId === packageId
-                    ? {...p, usedCount: newUsedCount, lastUsedAt: now}
+                    ? {...p, usedCount: plugin.usedCount + 1, lastUsedAt: now}
                     : p
-            ),
-            updateBuffer: new Map(state.updateBuffer).set(packageId, {
-                lastUsedAt: now,
-                usedCount: newUsedCount
-            })
+            )
         }));
-
-        // Trigger debounced batch update
-        debouncedFlushUpdates();
-    }
-
-    const flushBufferUpdates = async () => {
-        if (debounceTimer) {
-            clearTimeout(debounceTimer);
-            debounceTimer = null;
-        }
-
-        const {updateBuffer} = get();
-        if (updateBuffer.size === 0) return;
-
-        try {
-            const updates = Array.from(updateBuffer.entries()).map(([packageId, data]) => ({
-                packageId,
-                lastUsedAt: data.lastUsedAt,
-                usedCount: data.usedCount
-            }));
-
-            await updatePluginUsage(updates);
-            set({updateBuffer: new Map()});
-        } catch (error) {
-            Logger.error(`Failed to flush plugin buffer updates: ${error}`);
-        }
     }
 
     const togglePlugin = async (packageId: string, enabled: boolean) => {
@@ -173,14 +111,12 @@ export const usePluginStore = create<PluginState>((set, get) => {
         plugins: [],
         isLoading: false,
         error: null,
-        updateBuffer: new Map(),
         fetchPlugins,
         refreshPlugins,
         getPluginById,
         getEnabledPlugins,
         getPluginsByType,
-        updatePluginUsage: updatePluginUsageMethod,
-        flushBufferUpdates,
+        updatePluginUsage,
         togglePlugin,
         uninstallPlugin
     }
@@ -1,17 +1,70 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 import {app} from '../models';
+import {models} from '../models';
+
+export function AddApplicationAliasApi(arg1:string,arg2:string):Promise<void>;
+
+export function AddCommandExclusionApi(arg1:string):Promise<void>;
+
+export function AddFileIndexIgnoreApi(arg1:string):Promise<void>;
+
+export function AddFileIndexRootApi(arg1:string):Promise<void>;
+
+export function AddGitRepoIgnoreApi(arg1:string):Promise<void>;
+
+export function AddGitRepoRootApi(arg1:string,arg2:number):Promise<void>;
+
+export function ClearClipboardHistoryApi():Promise<void>;
 
 export function ClearPluginStorageApi(arg1:Record<string, any>):Promise<void>;
 
 export function CopyBase64ImageToClipboard(arg1:string):Promise<void>;
 
+export function CopyClipboardHistoryItemApi(arg1:number):Promise<void>;
+
+export function CreateQuicklinkApi(arg1:Record<string, any>):Promise<Record<string, any>>;
+
+export function CreateSnippetApi(arg1:Record<string, any>):Promise<Record<string, any>>;
+
+export function DeleteClipboardHistoryItemApi(arg1:number):Promise<void>;
+
 export function DeletePluginStorageKeyApi(arg1:Record<string, any>):Promise<void>;
 
+export function DeleteQuicklinkApi(arg1:string):Promise<void>;
+
+export function DeleteSnippetApi(arg1:string):Promise<void>;
+
+export function ExportSnippetsApi(arg1:string):Promise<void>;
+
+export function ExportSnippetsByFileDialogApi():Promise<void>;
+
+export function GetApplicationAliasesApi(arg1:string):Promise<Array<string>>;
+
 export function GetApplicationCommandsApi():Promise<Array<any>>;
 
 export function GetClipboardContentApi():Promise<app.ClipboardContent>;
 
+export function GetClipboardHistorySettingsApi():Promise<Record<string, any>>;
+
+export function GetCommandExclusionsApi():Promise<Array<string>>;
+
+export function GetCommandSourcesApi():Promise<Array<string>>;
+
+export function GetCommandVisibilitiesApi():Promise<Array<any>>;
+
+export function GetCommandsApi(arg1:string):Promise<Array<any>>;
+
+export function GetFileIndexIgnoresApi():Promise<Array<string>>;
+
+export function GetFileIndexRootsApi():Promise<Array<string>>;
+
+export function GetFileIndexStatsApi():Promise<Record<string, any>>;
+
+export function GetGitRepoIgnoresApi():Promise<Array<string>>;
+
+export function GetGitRepoRootsApi():Promise<Array<Record<string, any>>>;
+
 export function GetHotkeyEnvironmentStatusApi():Promise<app.HotkeyEnvironmentStatus>;
 
 export function GetOperatorCommandsApi():Promise<Array<any>>;
@@ -22,12 +75,22 @@ export function GetPluginStorageKeyApi(arg1:Record<string, any>):Promise<any>;
 
 export function GetPluginsApi():Promise<Array<Record<string, any>>>;
 
+export function GetQuicklinksApi():Promise<Array<any>>;
+
+export function GetSelectionScoresApi(arg1:string,arg2:string):Promise<Array<Record<string, any>>>;
+
+export function GetSnippetsApi():Promise<Array<any>>;
+
 export function HideAppApi():Promise<void>;
 
 export function HideOrShowAppApi():Promise<void>;
 
 export function HttpProxyApi(arg1:Record<string, any>):Promise<Record<string, any>>;
 
+export function ImportSnippetsApi(arg1:string):Promise<number>;
+
+export function ImportSnippetsByFileDialogApi():Promise<number>;
+
 export function InstallPluginApi(arg1:string):Promise<void>;
 
 export function InstallPluginByFileDialogApi():Promise<void>;
@@ -36,16 +99,52 @@ export function ListPluginStorageKeysApi(arg1:Record<string, any>):Promise<Array
 
 export function OpenFolder(arg1:string):Promise<void>;
 
+export function PinClipboardHistoryItemApi(arg1:number,arg2:boolean):Promise<void>;
+
+export function PinCommandApi(arg1:string,arg2:string,arg3:number):Promise<void>;
+
+export function RebuildFileIndexApi():Promise<void>;
+
+export function RecordSelectionApi(arg1:Record<string, any>):Promise<void>;
+
+export function RefreshCommandsApi(arg1:string):Promise<void>;
+
+export function RemoveApplicationAliasApi(arg1:string,arg2:string):Promise<void>;
+
+export function RemoveCommandExclusionApi(arg1:string):Promise<void>;
+
+export function RemoveFileIndexIgnoreApi(arg1:string):Promise<void>;
+
+export function RemoveFileIndexRootApi(arg1:string):Promise<void>;
+
+export function RemoveGitRepoIgnoreApi(arg1:string):Promise<void>;
+
+export function RemoveGitRepoRootApi(arg1:string):Promise<void>;
+
+export function RunCommandActionApi(arg1:string,arg2:string,arg3:string,arg4:Record<string, any>):Promise<any>;
+
 export function SaveBase64Image(arg1:string):Promise<string>;
 
+export function SearchApi(arg1:string,arg2:number):Promise<Array<any>>;
+
+export function SearchClipboardHistoryApi(arg1:string,arg2:string,arg3:number,arg4:number):Promise<Array<any>>;
+
+export function SearchFilesApi(arg1:string,arg2:number):Promise<Array<any>>;
+
+export function SetCommandHiddenApi(arg1:string,arg2:string,arg3:boolean):Promise<void>;
+
 export function SetPluginStorageKeyApi(arg1:Record<string, any>):Promise<void>;
 
 export function TogglePluginApi(arg1:string,arg2:boolean):Promise<void>;
 
-export function TriggerCommandApi(arg1:string,arg2:string):Promise<void>;
+export function TriggerCommandApi(arg1:string,arg2:string,arg3:Record<string, any>):Promise<void>;
 
 export function UninstallPluginApi(arg1:string):Promise<void>;
 
-export function UpdateApplicationUsageApi(arg1:Array<Record<string, any>>):Promise<void>;
+export function UnpinCommandApi(arg1:string,arg2:string):Promise<void>;
+
+export function UpdateClipboardHistorySettingsApi(arg1:Record<string, any>):Promise<Record<string, any>>;
+
+export function UpdateQuicklinkApi(arg1:Record<string, any>):Promise<Record<string, any>>;
 
-export function UpdatePluginUsageApi(arg1:Array<Record<string, any>>):Promise<void>;
+export function UpdateSnippetApi(arg1:Record<string, any>):Promise<Record<string, any>>;
@@ -2,6 +2,34 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddApplicationAliasApi(arg1, arg2) {
+  return window['go']['coordinator']['WaAppCoordinator']['AddApplicationAliasApi'](arg1, arg2);
+}
+
+export function AddCommandExclusionApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['AddCommandExclusionApi'](arg1);
+}
+
+export function AddFileIndexIgnoreApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['AddFileIndexIgnoreApi'](arg1);
+}
+
+export function AddFileIndexRootApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['AddFileIndexRootApi'](arg1);
+}
+
+export function AddGitRepoIgnoreApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['AddGitRepoIgnoreApi'](arg1);
+}
+
+export function AddGitRepoRootApi(arg1, arg2) {
+  return window['go']['coordinator']['WaAppCoordinator']['AddGitRepoRootApi'](arg1, arg2);
+}
+
+export function ClearClipboardHistoryApi() {
+  return window['go']['coordinator']['WaAppCoordinator']['ClearClipboardHistoryApi']();
+}
+
 export function ClearPluginStorageApi(arg1) {
   return window['go']['coordinator']['WaAppCoordinator']['ClearPluginStorageApi'](arg1);
 }
@@ -10,10 +38,46 @@ export function CopyBase64ImageToClipboard(arg1) {
   return window['go']['coordinator']['WaAppCoordinator']['CopyBase64ImageToClipboard'](arg1);
 }
 
+export function CopyClipboardHistoryItemApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['CopyClipboardHistoryItemApi'](arg1);
+}
+
+export function CreateQuicklinkApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['CreateQuicklinkApi'](arg1);
+}
+
+export function CreateSnippetApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['CreateSnippetApi'](arg1);
+}
+
+export function DeleteClipboardHistoryItemApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['DeleteClipboardHistoryItemApi'](arg1);
+}
+
 export function DeletePluginStorageKeyApi(arg1) {
   return window['go']['coordinator']['WaAppCoordinator']['DeletePluginStorageKeyApi'](arg1);
 }
 
+export function DeleteQuicklinkApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['DeleteQuicklinkApi'](arg1);
+}
+
+export function DeleteSnippetApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['DeleteSnippetApi'](arg1);
+}
+
+export function ExportSnippetsApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['ExportSnippetsApi'](arg1);
+}
+
+export function ExportSnippetsByFileDialogApi() {
+  return window['go']['coordinator']['WaAppCoordinator']['ExportSnippetsByFileDialogApi']();
+}
+
+export function GetApplicationAliasesApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['GetApplicationAliasesApi'](arg1);
+}
+
 export function GetApplicationCommandsApi() {
   return window['go']['coordinator']['WaAppCoordinator']['GetApplicationCommandsApi']();
 }
@@ -22,6 +86,46 @@ export function GetClipboardContentApi() {
   return window['go']['coordinator']['WaAppCoordinator']['GetClipboardContentApi']();
 }
 
+export function GetClipboardHistorySettingsApi() {
+  return window['go']['coordinator']['WaAppCoordinator']['GetClipboardHistorySettingsApi']();
+}
+
+export function GetCommandExclusionsApi() {
+  return window['go']['coordinator']['WaAppCoordinator']['GetCommandExclusionsApi']();
+}
+
+export function GetCommandSourcesApi() {
+  return window['go']['coordinator']['WaAppCoordinator']['GetCommandSourcesApi']();
+}
+
+export function GetCommandVisibilitiesApi() {
+  return window['go']['coordinator']['WaAppCoordinator']['GetCommandVisibilitiesApi']();
+}
+
+export function GetCommandsApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['GetCommandsApi'](arg1);
+}
+
+export function GetFileIndexIgnoresApi() {
+  return window['go']['coordinator']['WaAppCoordinator']['GetFileIndexIgnoresApi']();
+}
+
+export function GetFileIndexRootsApi() {
+  return window['go']['coordinator']['WaAppCoordinator']['GetFileIndexRootsApi']();
+}
+
+export function GetFileIndexStatsApi() {
+  return window['go']['coordinator']['WaAppCoordinator']['GetFileIndexStatsApi']();
+}
+
+export function GetGitRepoIgnoresApi() {
+  return window['go']['coordinator']['WaAppCoordinator']['GetGitRepoIgnoresApi']();
+}
+
+export function GetGitRepoRootsApi() {
+  return window['go']['coordinator']['WaAppCoordinator']['GetGitRepoRootsApi']();
+}
+
 export function GetHotkeyEnvironmentStatusApi() {
   return window['go']['coordinator']['WaAppCoordinator']['GetHotkeyEnvironmentStatusApi']();
 }
@@ -42,6 +146,18 @@ export function GetPluginsApi() {
   return window['go']['coordinator']['WaAppCoordinator']['GetPluginsApi']();
 }
 
+export function GetQuicklinksApi() {
+  return window['go']['coordinator']['WaAppCoordinator']['GetQuicklinksApi']();
+}
+
+export function GetSelectionScoresApi(arg1, arg2) {
+  return window['go']['coordinator']['WaAppCoordinator']['GetSelectionScoresApi'](arg1, arg2);
+}
+
+export function GetSnippetsApi() {
+  return window['go']['coordinator']['WaAppCoordinator']['GetSnippetsApi']();
+}
+
 export function HideAppApi() {
   return window['go']['coordinator']['WaAppCoordinator']['HideAppApi']();
 }
@@ -54,6 +170,14 @@ export function HttpProxyApi(arg1) {
   return window['go']['coordinator']['WaAppCoordinator']['HttpProxyApi'](arg1);
 }
 
+export function ImportSnippetsApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['ImportSnippetsApi'](arg1);
+}
+
+export function ImportSnippetsByFileDialogApi() {
+  return window['go']['coordinator']['WaAppCoordinator']['ImportSnippetsByFileDialogApi']();
+}
+
 export function InstallPluginApi(arg1) {
   return window['go']['coordinator']['WaAppCoordinator']['InstallPluginApi'](arg1);
 }
@@ -70,10 +194,74 @@ export function OpenFolder(arg1) {
   return window['go']['coordinator']['WaAppCoordinator']['OpenFolder'](arg1);
 }
 
+export function PinClipboardHistoryItemApi(arg1, arg2) {
+  return window['go']['coordinator']['WaAppCoordinator']['PinClipboardHistoryItemApi'](arg1, arg2);
+}
+
+export function PinCommandApi(arg1, arg2, arg3) {
+  return window['go']['coordinator']['WaAppCoordinator']['PinCommandApi'](arg1, arg2, arg3);
+}
+
+export function RebuildFileIndexApi() {
+  return window['go']['coordinator']['WaAppCoordinator']['RebuildFileIndexApi']();
+}
+
+export function RecordSelectionApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['RecordSelectionApi'](arg1);
+}
+
+export function RefreshCommandsApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['RefreshCommandsApi'](arg1);
+}
+
+export function RemoveApplicationAliasApi(arg1, arg2) {
+  return window['go']['coordinator']['WaAppCoordinator']['RemoveApplicationAliasApi'](arg1, arg2);
+}
+
+export function RemoveCommandExclusionApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['RemoveCommandExclusionApi'](arg1);
+}
+
+export function RemoveFileIndexIgnoreApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['RemoveFileIndexIgnoreApi'](arg1);
+}
+
+export function RemoveFileIndexRootApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['RemoveFileIndexRootApi'](arg1);
+}
+
+export function RemoveGitRepoIgnoreApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['RemoveGitRepoIgnoreApi'](arg1);
+}
+
+export function RemoveGitRepoRootApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['RemoveGitRepoRootApi'](arg1);
+}
+
+export function RunCommandActionApi(arg1, arg2, arg3, arg4) {
+  return window['go']['coordinator']['WaAppCoordinator']['RunCommandActionApi'](arg1, arg2, arg3, arg4);
+}
+
 export function SaveBase64Image(arg1) {
   return window['go']['coordinator']['WaAppCoordinator']['SaveBase64Image'](arg1);
 }
 
+export function SearchApi(arg1, arg2) {
+  return window['go']['coordinator']['WaAppCoordinator']['SearchApi'](arg1, arg2);
+}
+
+export function SearchClipboardHistoryApi(arg1, arg2, arg3, arg4) {
+  return window['go']['coordinator']['WaAppCoordinator']['SearchClipboardHistoryApi'](arg1, arg2, arg3, arg4);
+}
+
+export function SearchFilesApi(arg1, arg2) {
+  return window['go']['coordinator']['WaAppCoordinator']['SearchFilesApi'](arg1, arg2);
+}
+
+export function SetCommandHiddenApi(arg1, arg2, arg3) {
+  return window['go']['coordinator']['WaAppCoordinator']['SetCommandHiddenApi'](arg1, arg2, arg3);
+}
+
 export function SetPluginStorageKeyApi(arg1) {
   return window['go']['coordinator']['WaAppCoordinator']['SetPluginStorageKeyApi'](arg1);
 }
@@ -82,18 +270,26 @@ export function TogglePluginApi(arg1, arg2) {
   return window['go']['coordinator']['WaAppCoordinator']['TogglePluginApi'](arg1, arg2);
 }
 
-export function TriggerCommandApi(arg1, arg2) {
-  return window['go']['coordinator']['WaAppCoordinator']['TriggerCommandApi'](arg1, arg2);
+export function TriggerCommandApi(arg1, arg2, arg3) {
+  return window['go']['coordinator']['WaAppCoordinator']['TriggerCommandApi'](arg1, arg2, arg3);
 }
 
 export function UninstallPluginApi(arg1) {
   return window['go']['coordinator']['WaAppCoordinator']['UninstallPluginApi'](arg1);
 }
 
-export function UpdateApplicationUsageApi(arg1) {
-  return window['go']['coordinator']['WaAppCoordinator']['UpdateApplicationUsageApi'](arg1);
+export function UnpinCommandApi(arg1, arg2) {
+  return window['go']['coordinator']['WaAppCoordinator']['UnpinCommandApi'](arg1, arg2);
+}
+
+export function UpdateClipboardHistorySettingsApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['UpdateClipboardHistorySettingsApi'](arg1);
+}
+
+export function UpdateQuicklinkApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['UpdateQuicklinkApi'](arg1);
 }
 
-export function UpdatePluginUsageApi(arg1) {
-  return window['go']['coordinator']['WaAppCoordinator']['UpdatePluginUsageApi'](arg1);
+export function UpdateSnippetApi(arg1) {
+  return window['go']['coordinator']['WaAppCoordinator']['UpdateSnippetApi'](arg1);
 }
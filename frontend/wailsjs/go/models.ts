@@ -43,3 +43,24 @@ export namespace app {
 
 }
 
+export namespace models {
+	
+	export class ScriptResult {
+	    stdout: string;
+	    stderr: string;
+	    exitCode: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScriptResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.stdout = source["stdout"];
+	        this.stderr = source["stderr"];
+	        this.exitCode = source["exitCode"];
+	    }
+	}
+
+}
+
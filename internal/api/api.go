@@ -67,6 +67,16 @@ func (a *WaApi) CopyBase64ImageToClipboard(base64Data string) error {
 	return a.copyImageBytesToClipboard(imgBytes)
 }
 
+// CopyImageFileToClipboard copies the PNG file at filePath to the clipboard
+func (a *WaApi) CopyImageFileToClipboard(filePath string) error {
+	imgBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read image: %w", err)
+	}
+
+	return a.copyImageBytesToClipboard(imgBytes)
+}
+
 // HttpProxyRequest represents a generic HTTP request
 type HttpProxyRequest struct {
 	URL     string            `json:"url"`
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"watools/pkg/clipboard"
+)
+
+func (a *WaApi) OpenFolderWithPath(path string) {
+	if strings.HasPrefix(path, "~/") {
+		path = strings.TrimPrefix(path, "~/")
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return
+		}
+		path = filepath.Join(homeDir, path)
+	}
+	path = filepath.Clean(path)
+	stat, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+
+	// file managers implementing org.freedesktop.FileManager1 select the item in its folder
+	method := "org.freedesktop.FileManager1.ShowItems"
+	if stat.IsDir() {
+		method = "org.freedesktop.FileManager1.ShowFolders"
+	}
+	fileURI := (&url.URL{Scheme: "file", Path: path}).String()
+	cmd := exec.Command("dbus-send", "--session", "--print-reply", "--dest=org.freedesktop.FileManager1",
+		"--type=method_call", "/org/freedesktop/FileManager1", method, "array:string:"+fileURI, "string:")
+	if err := cmd.Run(); err == nil {
+		return
+	}
+
+	folder := path
+	if !stat.IsDir() {
+		folder = filepath.Dir(path)
+	}
+	openCmd := exec.Command("xdg-open", folder)
+	openCmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := openCmd.Start(); err == nil {
+		go func() {
+			_ = openCmd.Wait()
+		}()
+	}
+}
+
+func (a *WaApi) copyImageBytesToClipboard(imgBytes []byte) error {
+	if len(imgBytes) == 0 {
+		return fmt.Errorf("image data is empty")
+	}
+
+	return clipboard.DefaultBackend().Write(clipboard.MimePNG, imgBytes)
+}
@@ -455,6 +455,11 @@ func (a *WaApp) GetClipboardText() (string, error) {
 	return C.GoString(cText), nil
 }
 
+// SetClipboardText replaces the clipboard with plain text
+func (a *WaApp) SetClipboardText(text string) error {
+	return runtime.ClipboardSetText(a.ctx, text)
+}
+
 // GetClipboardImage returns clipboard image as base64-encoded PNG
 // Automatically converts TIFF/JPEG to PNG format for consistency
 func (a *WaApp) GetClipboardImage() (string, error) {
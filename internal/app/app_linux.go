@@ -0,0 +1,269 @@
+package app
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"strings"
+	"time"
+	"watools/pkg/clipboard"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	_ "golang.org/x/image/bmp"
+)
+
+// ClipboardContentType represents the primary type of content in clipboard
+type ClipboardContentType string
+
+const (
+	ClipboardTypeText  ClipboardContentType = "text"
+	ClipboardTypeImage ClipboardContentType = "image"
+	ClipboardTypeFiles ClipboardContentType = "files"
+	ClipboardTypeEmpty ClipboardContentType = "empty"
+)
+
+// ClipboardContent contains comprehensive clipboard data with automatic type detection
+type ClipboardContent struct {
+	Types       []string             `json:"types"`       // All MIME targets offered by the clipboard owner
+	ContentType ClipboardContentType `json:"contentType"` // Primary detected type
+	HasText     bool                 `json:"hasText"`
+	HasImage    bool                 `json:"hasImage"`
+	HasFiles    bool                 `json:"hasFiles"`
+	Text        string               `json:"text,omitempty"`
+	ImageBase64 string               `json:"imageBase64,omitempty"` // PNG format, base64 encoded
+	Files       []string             `json:"files,omitempty"`       // Absolute file paths
+}
+
+// ==================== Window Management ====================
+
+func (a *WaApp) showAppAsPanel() {
+	time.Sleep(10 * time.Millisecond)
+	a.checkAndRepositionIfNeeded()
+	runtime.WindowShow(a.ctx)
+	a.positionWindow()
+	a.isHidden = false
+}
+
+func (a *WaApp) hideAppWithFocusReturn() {
+	if !a.isHidden {
+		runtime.WindowHide(a.ctx)
+		a.isHidden = true
+	}
+}
+
+func (a *WaApp) HideApp() {
+	a.hideAppWithFocusReturn()
+}
+
+func (a *WaApp) ShowApp() {
+	a.showAppAsPanel()
+}
+
+func (a *WaApp) HideOrShowApp() {
+	if a.isHidden {
+		a.ShowApp()
+	} else {
+		a.HideApp()
+	}
+}
+
+func (a *WaApp) IsSecureEventInputEnabled() bool {
+	return false
+}
+
+func (a *WaApp) positionWindowOnScreen(windowWidth int, windowHeight int) bool {
+	return false
+}
+
+// ==================== Clipboard API ====================
+
+func containsTarget(targets []string, target string) bool {
+	for _, t := range targets {
+		if strings.EqualFold(t, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasImageTarget(targets []string) bool {
+	for _, t := range targets {
+		if strings.HasPrefix(strings.ToLower(t), "image/") {
+			return true
+		}
+	}
+	return false
+}
+
+// GetClipboardTypes returns the MIME targets offered by the clipboard owner
+// Common types: text/plain;charset=utf-8, image/png, text/uri-list
+func (a *WaApp) GetClipboardTypes() ([]string, error) {
+	return clipboard.DefaultBackend().Targets()
+}
+
+// HasClipboardType checks if clipboard contains a specific type identifier
+func (a *WaApp) HasClipboardType(typeStr string) bool {
+	types, err := a.GetClipboardTypes()
+	if err != nil {
+		return false
+	}
+	return containsTarget(types, typeStr)
+}
+
+// GetClipboardText returns plain text from clipboard
+func (a *WaApp) GetClipboardText() (string, error) {
+	types, err := a.GetClipboardTypes()
+	if err != nil {
+		return "", err
+	}
+	return readClipboardText(clipboard.DefaultBackend(), types)
+}
+
+// SetClipboardText replaces the clipboard with plain text
+func (a *WaApp) SetClipboardText(text string) error {
+	return clipboard.DefaultBackend().Write(clipboard.MimeTextUTF8, []byte(text))
+}
+
+// GetClipboardImage returns clipboard image as base64-encoded PNG
+// Other image targets are converted to PNG for consistency
+func (a *WaApp) GetClipboardImage() (string, error) {
+	types, err := a.GetClipboardTypes()
+	if err != nil {
+		return "", err
+	}
+	return readClipboardImage(clipboard.DefaultBackend(), types)
+}
+
+// GetClipboardFiles returns absolute file paths from clipboard
+func (a *WaApp) GetClipboardFiles() ([]string, error) {
+	types, err := a.GetClipboardTypes()
+	if err != nil {
+		return nil, err
+	}
+	return readClipboardFiles(clipboard.DefaultBackend(), types)
+}
+
+// GetClipboardContent performs automatic type detection and returns all available content
+// Priority order for ContentType: Files > Image > Text > Empty
+func (a *WaApp) GetClipboardContent() (*ClipboardContent, error) {
+	types, err := a.GetClipboardTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clipboard types: %w", err)
+	}
+	return readClipboardContent(clipboard.DefaultBackend(), types), nil
+}
+
+func readClipboardContent(backend *clipboard.Backend, types []string) *ClipboardContent {
+	content := &ClipboardContent{Types: types}
+
+	if len(types) == 0 {
+		content.ContentType = ClipboardTypeEmpty
+		return content
+	}
+
+	// Detect available content types
+	for _, target := range clipboard.TextTargets {
+		content.HasText = content.HasText || containsTarget(types, target)
+	}
+	content.HasImage = hasImageTarget(types)
+	content.HasFiles = containsTarget(types, clipboard.MimeGnomeCopiedFiles) || containsTarget(types, clipboard.MimeURIList)
+
+	// Populate available content, file managers also offer the paths as text
+	if content.HasFiles {
+		if files, err := readClipboardFiles(backend, types); err == nil && len(files) > 0 {
+			content.Files = files
+		} else {
+			content.HasFiles = false
+		}
+	}
+
+	if content.HasImage {
+		if imageBase64, err := readClipboardImage(backend, types); err == nil {
+			content.ImageBase64 = imageBase64
+		}
+	}
+
+	if content.HasText {
+		if text, err := readClipboardText(backend, types); err == nil {
+			content.Text = text
+		}
+	}
+
+	// Determine primary type by priority
+	if content.HasFiles {
+		content.ContentType = ClipboardTypeFiles
+	} else if content.HasImage {
+		content.ContentType = ClipboardTypeImage
+	} else if content.HasText {
+		content.ContentType = ClipboardTypeText
+	} else {
+		content.ContentType = ClipboardTypeEmpty
+	}
+
+	return content
+}
+
+func readClipboardText(backend *clipboard.Backend, types []string) (string, error) {
+	for _, target := range clipboard.TextTargets {
+		if !containsTarget(types, target) {
+			continue
+		}
+		data, err := backend.Read(target)
+		if err != nil {
+			continue
+		}
+		return string(data), nil
+	}
+	return "", fmt.Errorf("no text in clipboard")
+}
+
+func readClipboardImage(backend *clipboard.Backend, types []string) (string, error) {
+	if containsTarget(types, clipboard.MimePNG) {
+		data, err := backend.Read(clipboard.MimePNG)
+		if err == nil && len(data) > 0 {
+			return base64.StdEncoding.EncodeToString(data), nil
+		}
+	}
+
+	for _, target := range types {
+		if !strings.HasPrefix(strings.ToLower(target), "image/") || strings.EqualFold(target, clipboard.MimePNG) {
+			continue
+		}
+		data, err := backend.Read(target)
+		if err != nil {
+			continue
+		}
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			continue
+		}
+		return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+	}
+	return "", fmt.Errorf("no image in clipboard")
+}
+
+func readClipboardFiles(backend *clipboard.Backend, types []string) ([]string, error) {
+	if containsTarget(types, clipboard.MimeGnomeCopiedFiles) {
+		if data, err := backend.Read(clipboard.MimeGnomeCopiedFiles); err == nil {
+			if files := clipboard.ParseGnomeCopiedFiles(data); len(files) > 0 {
+				return files, nil
+			}
+		}
+	}
+	if containsTarget(types, clipboard.MimeURIList) {
+		data, err := backend.Read(clipboard.MimeURIList)
+		if err != nil {
+			return nil, err
+		}
+		return clipboard.ParseURIList(data), nil
+	}
+	return []string{}, nil
+}
@@ -132,6 +132,11 @@ func (a *WaApp) GetClipboardText() (string, error) {
 	return out, nil
 }
 
+// SetClipboardText replaces the clipboard with plain text
+func (a *WaApp) SetClipboardText(text string) error {
+	return runtime.ClipboardSetText(a.ctx, text)
+}
+
 // GetClipboardImage returns clipboard image as base64-encoded PNG
 func (a *WaApp) GetClipboardImage() (string, error) {
 	script := "Add-Type -AssemblyName System.Drawing; " +
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// clipboardPollInterval is how often the clipboard change counter is read where the platform has no change notification
+const clipboardPollInterval = 500 * time.Millisecond
+
+// concealedClipboardTypes are set by password managers to ask clipboard managers not to record the content
+var concealedClipboardTypes = []string{
+	"org.nspasteboard.ConcealedType",
+	"org.nspasteboard.TransientType",
+	"x-kde-passwordManagerHint",
+}
+
+func hasConcealedType(types []string) bool {
+	for _, clipboardType := range types {
+		for _, concealed := range concealedClipboardTypes {
+			if strings.EqualFold(clipboardType, concealed) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pollClipboardChanges calls onChange whenever changeCount returns a new value, until ctx is done
+func pollClipboardChanges(ctx context.Context, changeCount func() int64, onChange func()) {
+	ticker := time.NewTicker(clipboardPollInterval)
+	defer ticker.Stop()
+	last := changeCount()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if count := changeCount(); count != last {
+				last = count
+				onChange()
+			}
+		}
+	}
+}
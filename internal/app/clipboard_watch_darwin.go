@@ -0,0 +1,98 @@
+package app
+
+/*
+   #cgo CFLAGS: -x objective-c
+   #cgo LDFLAGS: -framework Cocoa -framework Foundation
+   #import <Cocoa/Cocoa.h>
+
+   long getClipboardChangeCount() {
+       return [[NSPasteboard generalPasteboard] changeCount];
+   }
+
+   // Returns the bundle identifier and name of the frontmost app separated by a newline (caller must free)
+   char* getFrontmostApp() {
+       @autoreleasepool {
+           NSRunningApplication *app = [[NSWorkspace sharedWorkspace] frontmostApplication];
+           if (app == nil) {
+               return NULL;
+           }
+           NSString *bundleID = app.bundleIdentifier ?: @"";
+           NSString *name = app.localizedName ?: @"";
+           return strdup([[NSString stringWithFormat:@"%@\n%@", bundleID, name] UTF8String]);
+       }
+   }
+
+   // Writes the file paths of a JSON array to the clipboard as file URLs
+   int setClipboardFiles(const char *pathsJSON) {
+       @autoreleasepool {
+           NSData *data = [NSData dataWithBytes:pathsJSON length:strlen(pathsJSON)];
+           NSArray *paths = [NSJSONSerialization JSONObjectWithData:data options:0 error:nil];
+           if (![paths isKindOfClass:[NSArray class]] || [paths count] == 0) {
+               return 0;
+           }
+           NSMutableArray *urls = [NSMutableArray array];
+           for (NSString *path in paths) {
+               [urls addObject:[NSURL fileURLWithPath:path]];
+           }
+           NSPasteboard *pasteboard = [NSPasteboard generalPasteboard];
+           [pasteboard clearContents];
+           return [pasteboard writeObjects:urls] ? 1 : 0;
+       }
+   }
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// WatchClipboard calls onChange whenever the pasteboard change count moves, it blocks until ctx is done
+func (a *WaApp) WatchClipboard(ctx context.Context, onChange func()) error {
+	pollClipboardChanges(ctx, func() int64 {
+		return int64(C.getClipboardChangeCount())
+	}, onChange)
+	return nil
+}
+
+// IsClipboardConcealed reports whether the pasteboard owner marked the content as concealed or transient
+func (a *WaApp) IsClipboardConcealed() bool {
+	types, err := a.GetClipboardTypes()
+	return err == nil && hasConcealedType(types)
+}
+
+// GetFrontmostApp returns the bundle identifier and the name of the frontmost app
+func (a *WaApp) GetFrontmostApp() []string {
+	cApp := C.getFrontmostApp()
+	if cApp == nil {
+		return nil
+	}
+	defer C.free(unsafe.Pointer(cApp))
+	var names []string
+	for _, name := range strings.Split(C.GoString(cApp), "\n") {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// SetClipboardFiles replaces the clipboard with file URLs
+func (a *WaApp) SetClipboardFiles(paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("no files to copy")
+	}
+	pathsJSON, err := json.Marshal(paths)
+	if err != nil {
+		return err
+	}
+	cPaths := C.CString(string(pathsJSON))
+	defer C.free(unsafe.Pointer(cPaths))
+	if C.setClipboardFiles(cPaths) == 0 {
+		return fmt.Errorf("failed to write files to clipboard")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"watools/pkg/clipboard"
+)
+
+var (
+	activeWindowPattern = regexp.MustCompile(`window id # (0x[0-9a-fA-F]+)`)
+	wmClassPattern      = regexp.MustCompile(`WM_CLASS\(STRING\) = (.*)`)
+	wmPIDPattern        = regexp.MustCompile(`_NET_WM_PID\(CARDINAL\) = (\d+)`)
+)
+
+// WatchClipboard calls onChange whenever the clipboard changes, it blocks until ctx is done
+func (a *WaApp) WatchClipboard(ctx context.Context, onChange func()) error {
+	return clipboard.DefaultBackend().Watch(ctx, clipboardPollInterval, onChange)
+}
+
+// IsClipboardConcealed reports whether the clipboard owner marked the content as a secret
+func (a *WaApp) IsClipboardConcealed() bool {
+	types, err := a.GetClipboardTypes()
+	return err == nil && hasConcealedType(types)
+}
+
+// GetFrontmostApp returns the WM_CLASS names and the process name of the active X11 window.
+// Wayland does not expose the active window, only XWayland windows are found there.
+func (a *WaApp) GetFrontmostApp() []string {
+	output, err := exec.Command("xprop", "-root", "_NET_ACTIVE_WINDOW").Output()
+	if err != nil {
+		return nil
+	}
+	match := activeWindowPattern.FindStringSubmatch(string(output))
+	if match == nil {
+		return nil
+	}
+	output, err = exec.Command("xprop", "-id", match[1], "WM_CLASS", "_NET_WM_PID").Output()
+	if err != nil {
+		return nil
+	}
+	var names []string
+	if classes := wmClassPattern.FindStringSubmatch(string(output)); classes != nil {
+		for _, class := range strings.Split(classes[1], ",") {
+			if class = strings.Trim(strings.TrimSpace(class), `"`); class != "" {
+				names = append(names, class)
+			}
+		}
+	}
+	if pid := wmPIDPattern.FindStringSubmatch(string(output)); pid != nil {
+		if comm, err := os.ReadFile("/proc/" + pid[1] + "/comm"); err == nil {
+			names = append(names, strings.TrimSpace(string(comm)))
+		}
+	}
+	return names
+}
+
+// SetClipboardFiles replaces the clipboard with a list of files, offered as text/uri-list
+func (a *WaApp) SetClipboardFiles(paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("no files to copy")
+	}
+	var uris strings.Builder
+	for _, path := range paths {
+		uris.WriteString((&url.URL{Scheme: "file", Path: path}).String())
+		uris.WriteString("\r\n")
+	}
+	return clipboard.DefaultBackend().Write(clipboard.MimeURIList, []byte(uris.String()))
+}
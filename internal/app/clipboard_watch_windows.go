@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	modUser32                      = windows.NewLazySystemDLL("user32.dll")
+	procGetClipboardSequenceNumber = modUser32.NewProc("GetClipboardSequenceNumber")
+	procRegisterClipboardFormatW   = modUser32.NewProc("RegisterClipboardFormatW")
+	procIsClipboardFormatAvailable = modUser32.NewProc("IsClipboardFormatAvailable")
+	procGetForegroundWindow        = modUser32.NewProc("GetForegroundWindow")
+	procGetWindowThreadProcessId   = modUser32.NewProc("GetWindowThreadProcessId")
+)
+
+// concealedClipboardFormats are the registered formats password managers add to keep content out of clipboard history
+var concealedClipboardFormats = []string{"ExcludeClipboardContentFromMonitorProcessing", "Clipboard Viewer Ignore"}
+
+// WatchClipboard calls onChange whenever the clipboard sequence number changes, it blocks until ctx is done
+func (a *WaApp) WatchClipboard(ctx context.Context, onChange func()) error {
+	pollClipboardChanges(ctx, func() int64 {
+		sequence, _, _ := procGetClipboardSequenceNumber.Call()
+		return int64(sequence)
+	}, onChange)
+	return nil
+}
+
+// IsClipboardConcealed reports whether the clipboard owner asked monitors to ignore the content
+func (a *WaApp) IsClipboardConcealed() bool {
+	for _, name := range concealedClipboardFormats {
+		namePtr, err := windows.UTF16PtrFromString(name)
+		if err != nil {
+			continue
+		}
+		format, _, _ := procRegisterClipboardFormatW.Call(uintptr(unsafe.Pointer(namePtr)))
+		if format == 0 {
+			continue
+		}
+		if available, _, _ := procIsClipboardFormatAvailable.Call(format); available != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// GetFrontmostApp returns the executable name of the foreground window's process, such as "KeePassXC.exe"
+func (a *WaApp) GetFrontmostApp() []string {
+	hwnd, _, _ := procGetForegroundWindow.Call()
+	if hwnd == 0 {
+		return nil
+	}
+	var pid uint32
+	procGetWindowThreadProcessId.Call(hwnd, uintptr(unsafe.Pointer(&pid)))
+	if pid == 0 {
+		return nil
+	}
+	process, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return nil
+	}
+	defer windows.CloseHandle(process)
+	buffer := make([]uint16, windows.MAX_PATH)
+	size := uint32(len(buffer))
+	if err := windows.QueryFullProcessImageName(process, 0, &buffer[0], &size); err != nil {
+		return nil
+	}
+	return []string{filepath.Base(windows.UTF16ToString(buffer[:size]))}
+}
+
+// SetClipboardFiles replaces the clipboard with a file drop list
+func (a *WaApp) SetClipboardFiles(paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("no files to copy")
+	}
+	quoted := make([]string, len(paths))
+	for i, path := range paths {
+		quoted[i] = "'" + strings.ReplaceAll(path, "'", "''") + "'"
+	}
+	_, err := runPowerShell("Set-Clipboard -LiteralPath " + strings.Join(quoted, ","))
+	return err
+}
@@ -181,3 +181,8 @@ func GetDefaultIconPath() string {
 func GetAppPathInfos() []AppPathInfo {
 	return getMacApplicationPath()
 }
+
+// ParseApplicationActions returns the launchable actions of parent, none are discovered on this platform yet
+func ParseApplicationActions(parent *models.ApplicationCommand) []*models.ApplicationCommand {
+	return nil
+}
@@ -0,0 +1,269 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+	"watools/pkg/xdg"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+// ErrDesktopEntryNotShown is returned for desktop entries that exist but must not be listed
+var ErrDesktopEntryNotShown = errors.New("desktop entry is not shown")
+
+type AppPathInfo struct {
+	Path     string
+	UpdateAt time.Time
+	ID       string
+}
+
+// desktopFileIndex maps desktop file IDs to the path that wins the XDG lookup.
+// Earlier applications directories shadow later ones, whether or not the winning entry is visible.
+func desktopFileIndex() map[string]string {
+	index := make(map[string]string)
+	for _, appDir := range xdg.ApplicationDirs() {
+		if _, err := os.Stat(appDir); err != nil {
+			continue
+		}
+		_ = filepath.WalkDir(appDir, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() || !strings.HasSuffix(d.Name(), xdg.DesktopEntrySuffix) {
+				return nil
+			}
+			id, err := xdg.DesktopFileID(appDir, path)
+			if err != nil {
+				return nil
+			}
+			if _, exists := index[id]; !exists {
+				index[id] = path
+			}
+			return nil
+		})
+	}
+	return index
+}
+
+// desktopFileIDOf returns the desktop file ID of path using the applications directory that contains it
+func desktopFileIDOf(path string) (string, error) {
+	for _, appDir := range xdg.ApplicationDirs() {
+		if !strings.HasPrefix(path, appDir+string(os.PathSeparator)) {
+			continue
+		}
+		return xdg.DesktopFileID(appDir, path)
+	}
+	return "", fmt.Errorf("desktop file '%s' is not inside an applications directory", path)
+}
+
+// GetDesktopFilePaths returns every existing desktop file sharing the desktop file ID of appPath, in lookup precedence order
+func GetDesktopFilePaths(appPath string) []string {
+	id, err := desktopFileIDOf(appPath)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, appDir := range xdg.ApplicationDirs() {
+		_ = filepath.WalkDir(appDir, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(d.Name(), xdg.DesktopEntrySuffix) {
+				return nil
+			}
+			if candidateID, err := xdg.DesktopFileID(appDir, path); err == nil && candidateID == id {
+				paths = append(paths, path)
+			}
+			return nil
+		})
+	}
+	return paths
+}
+
+func matchesDesktops(desktops []string, currentDesktops []string) bool {
+	return lo.SomeBy(desktops, func(desktop string) bool {
+		return lo.ContainsBy(currentDesktops, func(current string) bool {
+			return strings.EqualFold(desktop, current)
+		})
+	})
+}
+
+func tryExecAvailable(tryExec string) bool {
+	if tryExec == "" {
+		return true
+	}
+	if filepath.IsAbs(tryExec) {
+		fi, err := os.Stat(tryExec)
+		return err == nil && !fi.IsDir() && fi.Mode()&0111 != 0
+	}
+	_, err := exec.LookPath(tryExec)
+	return err == nil
+}
+
+// validateDesktopEntry reports why a desktop entry must not be shown in the launcher
+func validateDesktopEntry(entry *xdg.DesktopEntry) error {
+	group := xdg.GroupDesktopEntry
+	if entryType := entry.String(group, "Type"); entryType != "Application" {
+		return fmt.Errorf("unsupported desktop entry type '%s'", entryType)
+	}
+	if entry.Bool(group, "Hidden") {
+		return fmt.Errorf("desktop entry is hidden")
+	}
+	if entry.Bool(group, "NoDisplay") {
+		return fmt.Errorf("desktop entry is not displayed")
+	}
+	currentDesktops := xdg.CurrentDesktops()
+	if onlyShowIn := entry.StringList(group, "OnlyShowIn"); len(onlyShowIn) > 0 && !matchesDesktops(onlyShowIn, currentDesktops) {
+		return fmt.Errorf("desktop entry is only shown in %v", onlyShowIn)
+	}
+	if notShowIn := entry.StringList(group, "NotShowIn"); matchesDesktops(notShowIn, currentDesktops) {
+		return fmt.Errorf("desktop entry is not shown in %v", notShowIn)
+	}
+	if entry.String(group, "Exec") == "" && !entry.Bool(group, "DBusActivatable") {
+		return fmt.Errorf("desktop entry has no Exec key")
+	}
+	if tryExec := entry.String(group, "TryExec"); !tryExecAvailable(tryExec) {
+		return fmt.Errorf("TryExec '%s' is not installed", tryExec)
+	}
+	if entry.LocaleString(group, "Name") == "" {
+		return fmt.Errorf("desktop entry has no Name key")
+	}
+	return nil
+}
+
+// resolveIconValue keeps absolute icon paths that exist and passes theme icon names through for the icon route
+func resolveIconValue(icon string) mo.Option[string] {
+	icon = strings.TrimSpace(icon)
+	if icon == "" {
+		return mo.None[string]()
+	}
+	if filepath.IsAbs(icon) {
+		if _, err := os.Stat(icon); err != nil {
+			return mo.None[string]()
+		}
+	}
+	return mo.Some(icon)
+}
+
+func parseDesktopEntryFile(desktopPath string) (*models.ApplicationCommand, error) {
+	fi, err := os.Stat(desktopPath)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		return nil, fmt.Errorf("path is directory: %s", desktopPath)
+	}
+	entry, err := xdg.ParseDesktopEntryFile(desktopPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateDesktopEntry(entry); err != nil {
+		return nil, fmt.Errorf("skip '%s': %w: %w", desktopPath, ErrDesktopEntryNotShown, err)
+	}
+
+	group := xdg.GroupDesktopEntry
+	commandName := entry.LocaleString(group, "Name")
+	description := entry.LocaleString(group, "Comment")
+	if description == "" {
+		description = entry.LocaleString(group, "GenericName")
+	}
+	commandDescription := mo.TupleToOption(description, description != "")
+	commandIconPath := resolveIconValue(entry.String(group, "Icon"))
+
+	return models.NewApplicationCommand(commandName, commandDescription, desktopPath, commandIconPath, mo.None[string](), fi.ModTime()), nil
+}
+
+func getLinuxApplicationPath() []AppPathInfo {
+	var appPathInfos []AppPathInfo
+	logger.Info(fmt.Sprintf("Scanning app folders: %v", xdg.ApplicationDirs()))
+	for id, desktopPath := range desktopFileIndex() {
+		fi, err := os.Stat(desktopPath)
+		if err != nil {
+			continue
+		}
+		appPathInfos = append(appPathInfos, AppPathInfo{Path: desktopPath, UpdateAt: fi.ModTime(), ID: id})
+	}
+	sort.Slice(appPathInfos, func(i, j int) bool {
+		return appPathInfos[i].Path < appPathInfos[j].Path
+	})
+	return appPathInfos
+}
+
+func GetApplications() ([]*models.ApplicationCommand, error) {
+	var commands []*models.ApplicationCommand
+
+	for _, appPathInfo := range getLinuxApplicationPath() {
+		command, err := parseDesktopEntryFile(appPathInfo.Path)
+		if err != nil {
+			logger.Debug(fmt.Sprintf("Failed to parse desktop entry '%s': %v", appPathInfo.Path, err))
+			continue
+		}
+		commands = append(commands, command)
+	}
+	return commands, nil
+}
+
+func ParseApplication(appPath string) (*models.ApplicationCommand, error) {
+	if !strings.HasSuffix(appPath, xdg.DesktopEntrySuffix) {
+		return nil, fmt.Errorf("unsupported application path: %s", appPath)
+	}
+	id, err := desktopFileIDOf(appPath)
+	if err != nil {
+		return nil, err
+	}
+	if winner, exists := desktopFileIndex()[id]; exists && winner != appPath {
+		return nil, fmt.Errorf("desktop file '%s' is shadowed by '%s': %w", appPath, winner, ErrDesktopEntryNotShown)
+	}
+	return parseDesktopEntryFile(appPath)
+}
+
+func GetDefaultIconPath() string {
+	return "application-x-executable"
+}
+
+// GetAppPathInfos returns the visible desktop entries, hidden and shadowed ones are left out
+func GetAppPathInfos() []AppPathInfo {
+	return lo.Filter(getLinuxApplicationPath(), func(info AppPathInfo, _ int) bool {
+		entry, err := xdg.ParseDesktopEntryFile(info.Path)
+		if err != nil {
+			return false
+		}
+		return validateDesktopEntry(entry) == nil
+	})
+}
+
+// ParseApplicationActions returns the [Desktop Action] groups listed in the Actions key of parent as child commands.
+// Actions without Exec are skipped, activating them over D-Bus is not supported.
+func ParseApplicationActions(parent *models.ApplicationCommand) []*models.ApplicationCommand {
+	entry, err := xdg.ParseDesktopEntryFile(parent.Path)
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Failed to parse desktop entry '%s': %v", parent.Path, err))
+		return nil
+	}
+
+	var actions []*models.ApplicationCommand
+	seen := make(map[string]struct{})
+	for _, actionID := range entry.StringList(xdg.GroupDesktopEntry, "Actions") {
+		if _, exists := seen[actionID]; exists {
+			continue
+		}
+		seen[actionID] = struct{}{}
+		group := xdg.ActionGroup(actionID)
+		if !entry.HasGroup(group) {
+			continue
+		}
+		name := entry.LocaleString(group, "Name")
+		if name == "" || entry.String(group, "Exec") == "" {
+			continue
+		}
+		iconPath := resolveIconValue(entry.String(group, "Icon"))
+		actions = append(actions, models.NewApplicationActionCommand(parent, actionID, name, iconPath, mo.None[string]()))
+	}
+	return actions
+}
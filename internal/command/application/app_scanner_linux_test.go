@@ -0,0 +1,166 @@
+package application
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"watools/pkg/xdg"
+)
+
+func writeDesktopFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create applications dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write desktop file: %v", err)
+	}
+}
+
+func setupApplicationDirs(t *testing.T) (string, string) {
+	t.Helper()
+	rootDir := t.TempDir()
+	dataHome := filepath.Join(rootDir, "home")
+	dataDir := filepath.Join(rootDir, "system")
+	t.Setenv("XDG_DATA_HOME", dataHome)
+	t.Setenv("XDG_DATA_DIRS", dataDir)
+	t.Setenv("XDG_CURRENT_DESKTOP", "GNOME")
+	t.Setenv("LC_ALL", "C")
+	xdg.SetSystemSandboxDataDir(xdg.OriginFlatpak, filepath.Join(rootDir, "flatpak", "exports", "share"))
+	xdg.SetSystemSandboxDataDir(xdg.OriginSnap, filepath.Join(rootDir, "snapd", "desktop"))
+	t.Cleanup(func() {
+		xdg.SetSystemSandboxDataDir(xdg.OriginFlatpak, xdg.FlatpakSystemDataDir)
+		xdg.SetSystemSandboxDataDir(xdg.OriginSnap, xdg.SnapDataDir)
+	})
+	return filepath.Join(dataHome, "applications"), filepath.Join(dataDir, "applications")
+}
+
+func TestGetApplicationsFiltersEntries(t *testing.T) {
+	userAppDir, systemAppDir := setupApplicationDirs(t)
+
+	writeDesktopFile(t, filepath.Join(systemAppDir, "editor.desktop"), "[Desktop Entry]\nType=Application\nName=Editor\nComment=Edit files\nIcon=accessories-text-editor\nExec=editor %F\n")
+	writeDesktopFile(t, filepath.Join(systemAppDir, "helper.desktop"), "[Desktop Entry]\nType=Application\nName=Helper\nExec=helper\nNoDisplay=true\n")
+	writeDesktopFile(t, filepath.Join(systemAppDir, "kde-only.desktop"), "[Desktop Entry]\nType=Application\nName=KDE Only\nExec=kde\nOnlyShowIn=KDE;\n")
+	writeDesktopFile(t, filepath.Join(systemAppDir, "not-gnome.desktop"), "[Desktop Entry]\nType=Application\nName=Not GNOME\nExec=x\nNotShowIn=GNOME;\n")
+	writeDesktopFile(t, filepath.Join(systemAppDir, "missing.desktop"), "[Desktop Entry]\nType=Application\nName=Missing\nExec=x\nTryExec=/nonexistent/watools-test-binary\n")
+	writeDesktopFile(t, filepath.Join(systemAppDir, "link.desktop"), "[Desktop Entry]\nType=Link\nName=Link\nURL=https://example.com\n")
+	writeDesktopFile(t, filepath.Join(systemAppDir, "removed.desktop"), "[Desktop Entry]\nType=Application\nName=Removed\nExec=removed\n")
+	writeDesktopFile(t, filepath.Join(userAppDir, "removed.desktop"), "[Desktop Entry]\nType=Application\nName=Removed\nExec=removed\nHidden=true\n")
+
+	commands, err := GetApplications()
+	if err != nil {
+		t.Fatalf("expected applications to be scanned: %v", err)
+	}
+	if len(commands) != 1 {
+		t.Fatalf("expected exactly one visible application, got %d", len(commands))
+	}
+	command := commands[0]
+	if command.Name != "Editor" || command.Description.OrEmpty() != "Edit files" || command.IconPath.OrEmpty() != "accessories-text-editor" {
+		t.Fatalf("unexpected application command: %+v", command)
+	}
+	if command.Path != filepath.Join(systemAppDir, "editor.desktop") {
+		t.Fatalf("unexpected application path %s", command.Path)
+	}
+	if len(GetAppPathInfos()) != 1 {
+		t.Fatal("expected path infos to match visible applications")
+	}
+}
+
+func TestParseApplicationHonorsShadowing(t *testing.T) {
+	userAppDir, systemAppDir := setupApplicationDirs(t)
+
+	systemPath := filepath.Join(systemAppDir, "org.example.App.desktop")
+	userPath := filepath.Join(userAppDir, "org.example.App.desktop")
+	writeDesktopFile(t, systemPath, "[Desktop Entry]\nType=Application\nName=System App\nExec=app\n")
+	writeDesktopFile(t, userPath, "[Desktop Entry]\nType=Application\nName=User App\nExec=app\n")
+
+	if _, err := ParseApplication(systemPath); err == nil {
+		t.Fatal("expected shadowed desktop file to be rejected")
+	}
+	command, err := ParseApplication(userPath)
+	if err != nil {
+		t.Fatalf("expected user desktop file to parse: %v", err)
+	}
+	if command.Name != "User App" {
+		t.Fatalf("unexpected application name %s", command.Name)
+	}
+	if !command.IsUserApp {
+		t.Fatal("expected application in data home to be a user application")
+	}
+}
+
+func TestParseApplicationActions(t *testing.T) {
+	_, systemAppDir := setupApplicationDirs(t)
+
+	path := filepath.Join(systemAppDir, "browser.desktop")
+	writeDesktopFile(t, path, "[Desktop Entry]\nType=Application\nName=Browser\nIcon=browser\nExec=browser %u\nActions=new-window;private;missing;no-exec;\n\n"+
+		"[Desktop Action new-window]\nName=New Window\nExec=browser --new-window\n\n"+
+		"[Desktop Action private]\nName=New Private Window\nIcon=browser-private\nExec=browser --private-window\n\n"+
+		"[Desktop Action no-exec]\nName=No Exec\n\n"+
+		"[Desktop Action unlisted]\nName=Unlisted\nExec=browser --unlisted\n")
+
+	parent, err := ParseApplication(path)
+	if err != nil {
+		t.Fatalf("expected desktop file to parse: %v", err)
+	}
+	actions := ParseApplicationActions(parent)
+	if len(actions) != 2 {
+		t.Fatalf("expected two launchable actions, got %d", len(actions))
+	}
+	cases := []struct {
+		actionID string
+		name     string
+		icon     string
+	}{
+		{actionID: "new-window", name: "New Window", icon: "browser"},
+		{actionID: "private", name: "New Private Window", icon: "browser-private"},
+	}
+	for i, tc := range cases {
+		action := actions[i]
+		if action.ActionID != tc.actionID || action.Name != tc.name || action.IconPath.OrEmpty() != tc.icon {
+			t.Fatalf("unexpected action %d: %+v", i, action)
+		}
+		if action.ParentID != parent.ID || action.Path != path || action.Description.OrEmpty() != "Browser" {
+			t.Fatalf("action %s is not linked to its application: %+v", tc.actionID, action)
+		}
+		if action.ID == parent.ID || action.TriggerID == parent.TriggerID {
+			t.Fatalf("action %s must have its own identity", tc.actionID)
+		}
+	}
+}
+
+func TestGetApplicationsIncludesSandboxExports(t *testing.T) {
+	userAppDir, _ := setupApplicationDirs(t)
+	dataHome := filepath.Dir(userAppDir)
+	systemFlatpakDir := filepath.Join(xdg.FlatpakDataDirs()[1], "applications")
+	userFlatpakDir := filepath.Join(dataHome, "flatpak", "exports", "share", "applications")
+	snapDir := filepath.Join(xdg.SandboxDataDirs()[2], "applications")
+
+	writeDesktopFile(t, filepath.Join(systemFlatpakDir, "org.example.Editor.desktop"), "[Desktop Entry]\nType=Application\nName=Flatpak Editor\nExec=/usr/bin/flatpak run org.example.Editor\nX-Flatpak=org.example.Editor\n")
+	writeDesktopFile(t, filepath.Join(userFlatpakDir, "org.example.Chat.desktop"), "[Desktop Entry]\nType=Application\nName=Flatpak Chat\nExec=/usr/bin/flatpak run org.example.Chat\nX-Flatpak=org.example.Chat\n")
+	writeDesktopFile(t, filepath.Join(snapDir, "player_player.desktop"), "[Desktop Entry]\nType=Application\nName=Snap Player\nExec=env BAMF_DESKTOP_FILE_HINT=player_player.desktop /snap/bin/player %U\nX-SnapInstanceName=player\n")
+	writeDesktopFile(t, filepath.Join(userAppDir, "native.desktop"), "[Desktop Entry]\nType=Application\nName=Native\nExec=native\n")
+
+	commands, err := GetApplications()
+	if err != nil {
+		t.Fatalf("expected applications to be scanned: %v", err)
+	}
+	origins := make(map[string]xdg.PackagingOrigin)
+	for _, command := range commands {
+		origins[command.Name] = command.Origin
+	}
+	want := map[string]xdg.PackagingOrigin{
+		"Flatpak Editor": xdg.OriginFlatpak,
+		"Flatpak Chat":   xdg.OriginFlatpak,
+		"Snap Player":    xdg.OriginSnap,
+		"Native":         xdg.OriginNative,
+	}
+	if len(origins) != len(want) {
+		t.Fatalf("unexpected applications %v", origins)
+	}
+	for name, origin := range want {
+		if origins[name] != origin {
+			t.Fatalf("expected %s to have origin %s, got %s", name, origin, origins[name])
+		}
+	}
+}
@@ -511,3 +511,8 @@ func GetDefaultIconPath() string {
 func GetAppPathInfos() []AppPathInfo {
 	return getWindowsApplicationPath()
 }
+
+// ParseApplicationActions returns the launchable actions of parent, none are discovered on this platform yet
+func ParseApplicationActions(parent *models.ApplicationCommand) []*models.ApplicationCommand {
+	return nil
+}
@@ -0,0 +1,146 @@
+// Package bookmark reads the bookmarks of the installed Chromium-family browsers and Firefox.
+// Browser databases are copied before they are opened, so a running browser never sees a lock from the launcher.
+package bookmark
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// Kind is the bookmark format of a browser family
+type Kind string
+
+const (
+	KindChromium Kind = "chromium"
+	KindFirefox  Kind = "firefox"
+)
+
+const (
+	chromiumBookmarksFile = "Bookmarks"
+	chromiumFaviconsFile  = "Favicons"
+	firefoxPlacesFile     = "places.sqlite"
+	firefoxFaviconsFile   = "favicons.sqlite"
+	// maxFaviconSize keeps oversized icons out of the database, browsers cache 16 and 32 pixel icons for pages
+	maxFaviconSize = 16 * 1024
+)
+
+// Store is the bookmark file of one browser profile
+type Store struct {
+	Browser string
+	// Profile is the name the browser shows for the profile, or its directory name
+	Profile string
+	Kind    Kind
+	// Path is the Bookmarks file or places.sqlite of the profile
+	Path string
+}
+
+// Bookmark is a bookmarked page
+type Bookmark struct {
+	Title string
+	URL   string
+	// FolderPath is the folders the bookmark is in, joined with "/"
+	FolderPath string
+	// Favicon is a data URL, empty when the browser has no icon for the page
+	Favicon string
+}
+
+// browserRoot is the user data directory of a browser, it holds one directory per profile
+type browserRoot struct {
+	browser string
+	dir     string
+}
+
+// FindStores returns the bookmark files of every profile of the installed browsers
+func FindStores() []Store {
+	var stores []Store
+	seen := make(map[string]bool)
+	add := func(found []Store) {
+		for _, store := range found {
+			if !seen[store.Path] {
+				seen[store.Path] = true
+				stores = append(stores, store)
+			}
+		}
+	}
+	for _, root := range chromiumRoots() {
+		add(findChromiumStores(root))
+	}
+	for _, root := range firefoxRoots() {
+		add(findFirefoxStores(root))
+	}
+	return stores
+}
+
+// Read returns the bookmarks of a store with their favicons
+func Read(store Store) ([]Bookmark, error) {
+	switch store.Kind {
+	case KindChromium:
+		return readChromium(store.Path)
+	case KindFirefox:
+		return readFirefox(store.Path)
+	default:
+		return nil, fmt.Errorf("unknown bookmark store kind '%s'", store.Kind)
+	}
+}
+
+// IsStoreFile reports whether path is a file whose change means the bookmarks of its profile changed.
+// Firefox writes bookmarks to the write-ahead log first.
+func IsStoreFile(path string) bool {
+	switch filepath.Base(path) {
+	case chromiumBookmarksFile, firefoxPlacesFile, firefoxPlacesFile + "-wal":
+		return true
+	}
+	return false
+}
+
+// StoreOf returns the store a changed file belongs to
+func StoreOf(stores []Store, path string) (Store, bool) {
+	for _, store := range stores {
+		if filepath.Dir(store.Path) == filepath.Dir(path) {
+			return store, true
+		}
+	}
+	return Store{}, false
+}
+
+// faviconSet keeps the icon closest to 32 pixels for each page
+type faviconSet map[string]favicon
+
+type favicon struct {
+	data  []byte
+	width int
+}
+
+func (s faviconSet) add(pageURL string, data []byte, width int) {
+	if len(data) == 0 || len(data) > maxFaviconSize {
+		return
+	}
+	if current, ok := s[pageURL]; ok && distance(current.width) <= distance(width) {
+		return
+	}
+	s[pageURL] = favicon{data: data, width: width}
+}
+
+func distance(width int) int {
+	if width > 32 {
+		return width - 32
+	}
+	return 32 - width
+}
+
+// dataURL returns the icon of a page as a data URL, or "" when there is none
+func (s faviconSet) dataURL(pageURL string) string {
+	icon, ok := s[pageURL]
+	if !ok {
+		return ""
+	}
+	mimeType := http.DetectContentType(icon.data)
+	if strings.HasPrefix(mimeType, "text/") {
+		// Firefox stores SVG icons as text
+		mimeType = "image/svg+xml"
+	}
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(icon.data)
+}
@@ -0,0 +1,29 @@
+package bookmark
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func applicationSupportDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, "Library", "Application Support")
+}
+
+func chromiumRoots() []browserRoot {
+	support := applicationSupportDir()
+	return []browserRoot{
+		{browser: "Google Chrome", dir: filepath.Join(support, "Google", "Chrome")},
+		{browser: "Chromium", dir: filepath.Join(support, "Chromium")},
+		{browser: "Brave", dir: filepath.Join(support, "BraveSoftware", "Brave-Browser")},
+		{browser: "Microsoft Edge", dir: filepath.Join(support, "Microsoft Edge")},
+		{browser: "Vivaldi", dir: filepath.Join(support, "Vivaldi")},
+		{browser: "Arc", dir: filepath.Join(support, "Arc", "User Data")},
+	}
+}
+
+func firefoxRoots() []browserRoot {
+	return []browserRoot{
+		{browser: "Firefox", dir: filepath.Join(applicationSupportDir(), "Firefox")},
+	}
+}
@@ -0,0 +1,30 @@
+package bookmark
+
+import (
+	"os"
+	"path/filepath"
+	"watools/pkg/xdg"
+)
+
+func chromiumRoots() []browserRoot {
+	config := xdg.ConfigHome()
+	homeDir, _ := os.UserHomeDir()
+	return []browserRoot{
+		{browser: "Google Chrome", dir: filepath.Join(config, "google-chrome")},
+		{browser: "Google Chrome", dir: filepath.Join(homeDir, ".var", "app", "com.google.Chrome", "config", "google-chrome")},
+		{browser: "Chromium", dir: filepath.Join(config, "chromium")},
+		{browser: "Chromium", dir: filepath.Join(homeDir, "snap", "chromium", "common", "chromium")},
+		{browser: "Brave", dir: filepath.Join(config, "BraveSoftware", "Brave-Browser")},
+		{browser: "Microsoft Edge", dir: filepath.Join(config, "microsoft-edge")},
+		{browser: "Vivaldi", dir: filepath.Join(config, "vivaldi")},
+	}
+}
+
+func firefoxRoots() []browserRoot {
+	homeDir, _ := os.UserHomeDir()
+	return []browserRoot{
+		{browser: "Firefox", dir: filepath.Join(homeDir, ".mozilla", "firefox")},
+		{browser: "Firefox", dir: filepath.Join(homeDir, "snap", "firefox", "common", ".mozilla", "firefox")},
+		{browser: "Firefox", dir: filepath.Join(homeDir, ".var", "app", "org.mozilla.firefox", ".mozilla", "firefox")},
+	}
+}
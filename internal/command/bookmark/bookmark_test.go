@@ -0,0 +1,139 @@
+package bookmark
+
+import (
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseChromiumBookmarks(t *testing.T) {
+	t.Parallel()
+
+	data := `{
+  "checksum": "abc",
+  "roots": {
+    "bookmark_bar": {"type": "folder", "name": "Bookmarks bar", "children": [
+      {"type": "url", "name": "Go", "url": "https://go.dev/"},
+      {"type": "folder", "name": "Work", "children": [
+        {"type": "url", "name": "Tracker", "url": "https://tracker.example.com/"},
+        {"type": "url", "name": "Bookmarklet", "url": "javascript:alert(1)"}
+      ]}
+    ]},
+    "other": {"type": "folder", "name": "Other bookmarks", "children": [
+      {"type": "url", "name": "Docs", "url": "https://docs.example.com/"}
+    ]},
+    "synced": {"type": "folder", "name": "Mobile bookmarks", "children": []}
+  },
+  "version": 1
+}`
+	bookmarks, err := ParseChromiumBookmarks(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("expected bookmarks to parse: %v", err)
+	}
+	want := []Bookmark{
+		{Title: "Go", URL: "https://go.dev/", FolderPath: "Bookmarks bar"},
+		{Title: "Tracker", URL: "https://tracker.example.com/", FolderPath: "Bookmarks bar/Work"},
+		{Title: "Docs", URL: "https://docs.example.com/", FolderPath: "Other bookmarks"},
+	}
+	if !reflect.DeepEqual(bookmarks, want) {
+		t.Fatalf("unexpected bookmarks %+v", bookmarks)
+	}
+}
+
+func TestParseProfilesINI(t *testing.T) {
+	t.Parallel()
+
+	data := `[Install4F96D1932A9F858E]
+Default=Profiles/abc.default-release
+
+[Profile1]
+Name=work
+IsRelative=0
+Path=/data/firefox/work
+
+[Profile0]
+Name=default-release
+IsRelative=1
+Path=Profiles/abc.default-release
+Default=1
+
+[General]
+StartWithLastProfile=1
+`
+	dir := filepath.FromSlash("/home/user/.mozilla/firefox")
+	profiles := parseProfilesINI(strings.NewReader(data), dir)
+	want := []firefoxProfile{
+		{Name: "work", Path: "/data/firefox/work"},
+		{Name: "default-release", Path: filepath.Join(dir, "Profiles", "abc.default-release")},
+	}
+	if !reflect.DeepEqual(profiles, want) {
+		t.Fatalf("unexpected profiles %+v", profiles)
+	}
+}
+
+func TestReadFirefoxBookmarks(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), firefoxPlacesFile))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	_, err = db.Exec(`
+CREATE TABLE moz_places (id INTEGER PRIMARY KEY, url TEXT);
+CREATE TABLE moz_bookmarks (id INTEGER PRIMARY KEY, type INTEGER, fk INTEGER, parent INTEGER, position INTEGER, title TEXT, guid TEXT);
+INSERT INTO moz_places VALUES (1, 'https://go.dev/'), (2, 'https://tracker.example.com/'), (3, 'place:sort=8');
+INSERT INTO moz_bookmarks VALUES
+    (1, 2, NULL, 0, 0, '', 'root________'),
+    (2, 2, NULL, 1, 0, 'menu', 'menu________'),
+    (3, 2, NULL, 1, 1, 'toolbar', 'toolbar_____'),
+    (4, 2, NULL, 1, 2, 'tags', 'tags________'),
+    (5, 2, NULL, 3, 0, 'Work', 'work00000000'),
+    (6, 1, 1, 3, 1, 'Go', 'go0000000000'),
+    (7, 1, 2, 5, 0, 'Tracker', 'tracker00000'),
+    (8, 1, 3, 2, 0, 'Most Visited', 'smart0000000'),
+    (9, 2, NULL, 4, 0, 'golang', 'tag000000000'),
+    (10, 1, 1, 9, 0, NULL, 'tagged000000');
+`)
+	if err != nil {
+		t.Fatalf("failed to create places: %v", err)
+	}
+
+	bookmarks, err := readFirefoxBookmarks(db)
+	if err != nil {
+		t.Fatalf("expected bookmarks to read: %v", err)
+	}
+	want := []Bookmark{
+		{Title: "Go", URL: "https://go.dev/", FolderPath: "Bookmarks Toolbar"},
+		{Title: "Tracker", URL: "https://tracker.example.com/", FolderPath: "Bookmarks Toolbar/Work"},
+	}
+	if !reflect.DeepEqual(bookmarks, want) {
+		t.Fatalf("unexpected bookmarks %+v", bookmarks)
+	}
+}
+
+func TestFaviconSetPrefersIconsNear32Pixels(t *testing.T) {
+	t.Parallel()
+
+	png := []byte("\x89PNG\r\n\x1a\n")
+	icons := make(faviconSet)
+	icons.add("https://go.dev/", append(png, 16), 16)
+	icons.add("https://go.dev/", append(png, 32), 32)
+	icons.add("https://go.dev/", append(png, 64), 64)
+	icons.add("https://svg.example.com/", []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`), 0)
+
+	if icons["https://go.dev/"].width != 32 {
+		t.Fatalf("expected the 32 pixel icon, got %d", icons["https://go.dev/"].width)
+	}
+	if got := icons.dataURL("https://go.dev/"); !strings.HasPrefix(got, "data:image/png;base64,") {
+		t.Fatalf("unexpected data URL %q", got)
+	}
+	if got := icons.dataURL("https://svg.example.com/"); !strings.HasPrefix(got, "data:image/svg+xml;base64,") {
+		t.Fatalf("unexpected data URL %q", got)
+	}
+	if got := icons.dataURL("https://missing.example.com/"); got != "" {
+		t.Fatalf("expected no icon, got %q", got)
+	}
+}
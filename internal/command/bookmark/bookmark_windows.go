@@ -0,0 +1,30 @@
+package bookmark
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func chromiumRoots() []browserRoot {
+	local := os.Getenv("LOCALAPPDATA")
+	if local == "" {
+		return nil
+	}
+	return []browserRoot{
+		{browser: "Google Chrome", dir: filepath.Join(local, "Google", "Chrome", "User Data")},
+		{browser: "Chromium", dir: filepath.Join(local, "Chromium", "User Data")},
+		{browser: "Brave", dir: filepath.Join(local, "BraveSoftware", "Brave-Browser", "User Data")},
+		{browser: "Microsoft Edge", dir: filepath.Join(local, "Microsoft", "Edge", "User Data")},
+		{browser: "Vivaldi", dir: filepath.Join(local, "Vivaldi", "User Data")},
+	}
+}
+
+func firefoxRoots() []browserRoot {
+	roaming := os.Getenv("APPDATA")
+	if roaming == "" {
+		return nil
+	}
+	return []browserRoot{
+		{browser: "Firefox", dir: filepath.Join(roaming, "Mozilla", "Firefox")},
+	}
+}
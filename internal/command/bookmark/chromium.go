@@ -0,0 +1,181 @@
+package bookmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"watools/pkg/utils"
+)
+
+type chromiumFile struct {
+	Roots map[string]json.RawMessage `json:"roots"`
+}
+
+type chromiumNode struct {
+	Type     string         `json:"type"`
+	Name     string         `json:"name"`
+	URL      string         `json:"url"`
+	Children []chromiumNode `json:"children"`
+}
+
+// chromiumRootOrder lists the roots in the order the browser shows them, "roots" also holds non-folder keys
+var chromiumRootOrder = []string{"bookmark_bar", "other", "synced"}
+
+// ParseChromiumBookmarks reads the Bookmarks file of a Chromium-family profile
+func ParseChromiumBookmarks(r io.Reader) ([]Bookmark, error) {
+	var file chromiumFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to parse bookmarks: %w", err)
+	}
+	var bookmarks []Bookmark
+	for _, key := range chromiumRootOrder {
+		raw, ok := file.Roots[key]
+		if !ok {
+			continue
+		}
+		var root chromiumNode
+		if err := json.Unmarshal(raw, &root); err != nil {
+			return nil, fmt.Errorf("failed to parse bookmark root %s: %w", key, err)
+		}
+		bookmarks = collectChromium(root, root.Name, bookmarks)
+	}
+	return bookmarks, nil
+}
+
+func collectChromium(folder chromiumNode, path string, bookmarks []Bookmark) []Bookmark {
+	for _, child := range folder.Children {
+		switch child.Type {
+		case "url":
+			if isOpenable(child.URL) {
+				bookmarks = append(bookmarks, Bookmark{Title: child.Name, URL: child.URL, FolderPath: path})
+			}
+		case "folder":
+			bookmarks = collectChromium(child, joinFolder(path, child.Name), bookmarks)
+		}
+	}
+	return bookmarks
+}
+
+func readChromium(path string) ([]Bookmark, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	bookmarks, err := ParseChromiumBookmarks(f)
+	if err != nil {
+		return nil, err
+	}
+	icons, err := chromiumFavicons(filepath.Join(filepath.Dir(path), chromiumFaviconsFile))
+	if err != nil {
+		// bookmarks are still useful without icons
+		return bookmarks, nil
+	}
+	for i := range bookmarks {
+		bookmarks[i].Favicon = icons.dataURL(bookmarks[i].URL)
+	}
+	return bookmarks, nil
+}
+
+func chromiumFavicons(path string) (faviconSet, error) {
+	dir, err := os.MkdirTemp("", "watools-favicons-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	db, err := utils.OpenSQLiteCopy(path, dir)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT m.page_url, b.image_data, b.width
+FROM icon_mapping m
+         JOIN favicon_bitmaps b ON b.icon_id = m.icon_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read favicons: %w", err)
+	}
+	defer rows.Close()
+	icons := make(faviconSet)
+	for rows.Next() {
+		var pageURL string
+		var data []byte
+		var width int
+		if err := rows.Scan(&pageURL, &data, &width); err != nil {
+			return nil, err
+		}
+		icons.add(pageURL, data, width)
+	}
+	return icons, rows.Err()
+}
+
+// findChromiumStores returns the profiles of a Chromium user data directory that have bookmarks
+func findChromiumStores(root browserRoot) []Store {
+	entries, err := os.ReadDir(root.dir)
+	if err != nil {
+		return nil
+	}
+	names := chromiumProfileNames(filepath.Join(root.dir, "Local State"))
+	var stores []Store
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(root.dir, entry.Name(), chromiumBookmarksFile)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		profile := names[entry.Name()]
+		if profile == "" {
+			profile = entry.Name()
+		}
+		stores = append(stores, Store{Browser: root.browser, Profile: profile, Kind: KindChromium, Path: path})
+	}
+	return stores
+}
+
+// chromiumProfileNames maps profile directories to the names set by the user, from the Local State file
+func chromiumProfileNames(path string) map[string]string {
+	names := make(map[string]string)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return names
+	}
+	var state struct {
+		Profile struct {
+			InfoCache map[string]struct {
+				Name string `json:"name"`
+			} `json:"info_cache"`
+		} `json:"profile"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return names
+	}
+	for dir, info := range state.Profile.InfoCache {
+		names[dir] = info.Name
+	}
+	return names
+}
+
+func joinFolder(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "/" + name
+}
+
+// isOpenable skips bookmarklets and internal pages that the system browser cannot open from a URL
+func isOpenable(url string) bool {
+	scheme, _, found := strings.Cut(url, ":")
+	if !found {
+		return false
+	}
+	switch strings.ToLower(scheme) {
+	case "javascript", "place", "chrome", "edge", "brave", "vivaldi", "about":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,228 @@
+package bookmark
+
+import (
+	"bufio"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"watools/pkg/utils"
+)
+
+const (
+	firefoxTypeBookmark = 1
+	firefoxTypeFolder   = 2
+	firefoxRootGUID     = "root________"
+	// the tags root holds one folder per tag, its entries duplicate real bookmarks
+	firefoxTagsGUID = "tags________"
+)
+
+// firefoxRootNames are the names Firefox shows for its root folders, which are stored without a title
+var firefoxRootNames = map[string]string{
+	"menu________":  "Bookmarks Menu",
+	"toolbar_____":  "Bookmarks Toolbar",
+	"unfiled_____":  "Other Bookmarks",
+	"mobile______":  "Mobile Bookmarks",
+	firefoxTagsGUID: "Tags",
+	firefoxRootGUID: "",
+}
+
+type firefoxEntry struct {
+	id     int64
+	parent int64
+	kind   int
+	title  string
+	guid   string
+	url    string
+}
+
+func readFirefox(path string) ([]Bookmark, error) {
+	dir, err := os.MkdirTemp("", "watools-bookmarks-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	db, err := utils.OpenSQLiteCopy(path, dir)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	bookmarks, err := readFirefoxBookmarks(db)
+	if err != nil {
+		return nil, err
+	}
+
+	iconsDB, err := utils.OpenSQLiteCopy(filepath.Join(filepath.Dir(path), firefoxFaviconsFile), dir)
+	if err != nil {
+		return bookmarks, nil
+	}
+	defer iconsDB.Close()
+	icons, err := firefoxFavicons(iconsDB)
+	if err != nil {
+		return bookmarks, nil
+	}
+	for i := range bookmarks {
+		bookmarks[i].Favicon = icons.dataURL(bookmarks[i].URL)
+	}
+	return bookmarks, nil
+}
+
+// readFirefoxBookmarks reads the bookmarks of a places database, tags and smart queries are skipped
+func readFirefoxBookmarks(db *sql.DB) ([]Bookmark, error) {
+	rows, err := db.Query(`SELECT b.id, b.parent, b.type, IFNULL(b.title, ''), IFNULL(b.guid, ''), IFNULL(p.url, '')
+FROM moz_bookmarks b
+         LEFT JOIN moz_places p ON p.id = b.fk
+ORDER BY b.parent, b.position`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bookmarks: %w", err)
+	}
+	defer rows.Close()
+	var entries []firefoxEntry
+	folders := make(map[int64]firefoxEntry)
+	for rows.Next() {
+		var entry firefoxEntry
+		if err := rows.Scan(&entry.id, &entry.parent, &entry.kind, &entry.title, &entry.guid, &entry.url); err != nil {
+			return nil, err
+		}
+		if entry.kind == firefoxTypeFolder {
+			folders[entry.id] = entry
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var bookmarks []Bookmark
+	for _, entry := range entries {
+		if entry.kind != firefoxTypeBookmark || !isOpenable(entry.url) {
+			continue
+		}
+		path, ok := firefoxFolderPath(folders, entry.parent)
+		if !ok {
+			continue
+		}
+		bookmarks = append(bookmarks, Bookmark{Title: entry.title, URL: entry.url, FolderPath: path})
+	}
+	return bookmarks, nil
+}
+
+// firefoxFolderPath joins the folder names from the root down to id, ok is false below the tags root
+func firefoxFolderPath(folders map[int64]firefoxEntry, id int64) (string, bool) {
+	var names []string
+	for depth := 0; depth < 64; depth++ {
+		folder, exists := folders[id]
+		if !exists || folder.guid == firefoxRootGUID {
+			break
+		}
+		if folder.guid == firefoxTagsGUID {
+			return "", false
+		}
+		name := folder.title
+		if rootName, isRoot := firefoxRootNames[folder.guid]; isRoot {
+			name = rootName
+		}
+		names = append(names, name)
+		id = folder.parent
+	}
+	path := ""
+	for i := len(names) - 1; i >= 0; i-- {
+		path = joinFolder(path, names[i])
+	}
+	return path, true
+}
+
+func firefoxFavicons(db *sql.DB) (faviconSet, error) {
+	rows, err := db.Query(`SELECT p.page_url, i.data, i.width
+FROM moz_pages_w_icons p
+         JOIN moz_icons_to_pages ip ON ip.page_id = p.id
+         JOIN moz_icons i ON i.id = ip.icon_id
+WHERE i.data IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read favicons: %w", err)
+	}
+	defer rows.Close()
+	icons := make(faviconSet)
+	for rows.Next() {
+		var pageURL string
+		var data []byte
+		var width int
+		if err := rows.Scan(&pageURL, &data, &width); err != nil {
+			return nil, err
+		}
+		icons.add(pageURL, data, width)
+	}
+	return icons, rows.Err()
+}
+
+// firefoxProfile is a profile section of profiles.ini
+type firefoxProfile struct {
+	Name string
+	Path string
+}
+
+// parseProfilesINI reads the profiles of a Firefox profiles.ini, relative paths are resolved against dir
+func parseProfilesINI(r io.Reader, dir string) []firefoxProfile {
+	var profiles []firefoxProfile
+	var current *firefoxProfile
+	relative := true
+	flush := func() {
+		if current != nil && current.Path != "" {
+			if relative {
+				current.Path = filepath.Join(dir, filepath.FromSlash(current.Path))
+			}
+			profiles = append(profiles, *current)
+		}
+		current = nil
+		relative = true
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			flush()
+			if strings.HasPrefix(line, "[Profile") {
+				current = &firefoxProfile{}
+			}
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if current == nil || !found {
+			continue
+		}
+		switch key {
+		case "Name":
+			current.Name = value
+		case "Path":
+			current.Path = value
+		case "IsRelative":
+			relative = value == "1"
+		}
+	}
+	flush()
+	return profiles
+}
+
+// findFirefoxStores returns the profiles listed in the profiles.ini of a Firefox root that have bookmarks
+func findFirefoxStores(root browserRoot) []Store {
+	f, err := os.Open(filepath.Join(root.dir, "profiles.ini"))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	var stores []Store
+	for _, profile := range parseProfilesINI(f, root.dir) {
+		path := filepath.Join(profile.Path, firefoxPlacesFile)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		name := profile.Name
+		if name == "" {
+			name = filepath.Base(profile.Path)
+		}
+		stores = append(stores, Store{Browser: root.browser, Profile: name, Kind: KindFirefox, Path: path})
+	}
+	return stores
+}
@@ -2,20 +2,15 @@ package command
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
 	"sync"
-	"time"
-	"watools/internal/command/application"
-	"watools/internal/command/operator"
+	"watools/internal/command/snippet"
 	"watools/internal/command/watcher"
 	"watools/pkg/db"
 	"watools/pkg/logger"
 	"watools/pkg/models"
 
 	"github.com/samber/lo"
-	"github.com/samber/mo"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
@@ -24,247 +19,326 @@ var (
 	launchAppOnce     sync.Once
 )
 
+// CommandsChangedEvent is emitted with the source name whenever the commands of a source change
+const CommandsChangedEvent = "watools.commandsChanged"
+
+// applicationChangedEvent is kept for listeners that predate command sources
+const applicationChangedEvent = "watools.applicationChanged"
+
 type WaLaunchApp struct {
-	ctx               context.Context
-	applicationRunner []models.CommandRunner
-	operationRunner   []models.CommandRunner
-	watchManager      watcher.AppWatchManager
+	ctx          context.Context
+	registry     *Registry
+	applications *applicationSource
+	quicklinks   *quicklinkSource
+	snippets     *snippetSource
+	processes    *processSource
+	gitRepos     *gitRepoSource
+	files        *fileSource
+	search       *commandIndex
 }
 
 func GetWaLaunch() *WaLaunchApp {
 	launchAppOnce.Do(func() {
-		launchAppInstance = &WaLaunchApp{}
+		launchAppInstance = &WaLaunchApp{
+			registry:     NewRegistry(),
+			applications: newApplicationSource(),
+			quicklinks:   newQuicklinkSource(),
+			snippets:     newSnippetSource(),
+			processes:    newProcessSource(),
+			gitRepos:     newGitRepoSource(),
+			files:        newFileSource(),
+			search:       newCommandIndex(),
+		}
+		launchAppInstance.registerSource(launchAppInstance.applications)
+		launchAppInstance.registerSource(newOperationSource())
+		launchAppInstance.registerSource(newScriptSource())
+		launchAppInstance.registerSource(launchAppInstance.quicklinks)
+		launchAppInstance.registerSource(newRecentDocumentSource())
+		launchAppInstance.registerSource(launchAppInstance.processes)
+		launchAppInstance.registerSource(newBookmarkSource())
+		launchAppInstance.registerSource(newSSHHostSource())
+		launchAppInstance.registerSource(launchAppInstance.snippets)
+		launchAppInstance.registerSource(newProjectSource())
+		launchAppInstance.registerSource(launchAppInstance.gitRepos)
+		launchAppInstance.registerSource(launchAppInstance.files)
 	})
 	return launchAppInstance
 }
 
+func (w *WaLaunchApp) registerSource(source CommandSource) {
+	if err := w.registry.Register(source); err != nil {
+		logger.Error(err, "Failed to register command source")
+	}
+}
+
 func (w *WaLaunchApp) OnStartup(ctx context.Context) {
 	w.ctx = ctx
-	w.initAppWatcher()
-	w.asyncUpdateApplications(30 * time.Second)
+	// The change is announced once the search index has it, so a search run on the event sees the change
+	w.registry.OnChange(func(source models.CommandCategory) {
+		go func() {
+			w.search.update(w.ctx, w.listVisible, source)
+			runtime.EventsEmit(w.ctx, CommandsChangedEvent, string(source))
+			if source == models.CategoryApplication {
+				runtime.EventsEmit(w.ctx, applicationChangedEvent)
+			}
+		}()
+	})
+	w.registry.Start(ctx)
+	go w.search.build(ctx, w.registry.Sources(), w.listVisible)
 }
 
 func (w *WaLaunchApp) Shutdown(ctx context.Context) {
-	if w.watchManager != nil {
-		if err := w.watchManager.Stop(); err != nil {
-			logger.Error(err, "Failed to stop app watch manager")
-		}
-	}
+	w.registry.Stop()
 }
 
-func (w *WaLaunchApp) asyncUpdateApplications(delay time.Duration) {
-	go func() {
-		time.Sleep(delay)
-		w.updateApplications()
-	}()
-}
-
-func (w *WaLaunchApp) updateApplications() {
-	dbInstance := db.GetWaDB()
-	commands := dbInstance.GetCommands(w.ctx)
-	seen := make(map[string]struct{})
-	var updateCommands []*models.ApplicationCommand
-	var insertCommands []*models.ApplicationCommand
-	var removeCommands []*models.ApplicationCommand
-	for _, command := range commands {
-		seen[command.Path] = struct{}{}
-		id := command.ID
-		fi, err := os.Stat(command.Path)
-		if err != nil {
-			if os.IsNotExist(err) {
-				logger.Error(err, fmt.Sprintf("Command is not exists %s", command.Path))
-				removeCommands = append(removeCommands, command)
-			} else {
-				logger.Error(err, fmt.Sprintf("Failed to stat command %s", command.Path))
-			}
-			continue
-		}
-		if fi.ModTime().Format(time.DateTime) == command.DirUpdatedAt.Format(time.DateTime) {
-			continue
-		}
-		logger.Info(fmt.Sprintf("Update dir updated for command: %s, %s", command.Name, command.Path))
-		parsedCommand, err := application.ParseApplication(command.Path)
-		if err != nil {
-			logger.Error(err, "Failed to parse application")
-			removeCommands = append(removeCommands, command)
-			continue
+// GetCommandSources returns the names of the registered command sources
+func (w *WaLaunchApp) GetCommandSources() []string {
+	return lo.Map(w.registry.Sources(), func(source models.CommandCategory, _ int) string {
+		return string(source)
+	})
+}
+
+// GetCommands returns the visible commands of a source as plain maps for the frontend.
+// Pinned commands come first and have a "pinOrder".
+func (w *WaLaunchApp) GetCommands(source string) ([]interface{}, error) {
+	runners, pinOrders, err := w.listVisible(w.ctx, models.CommandCategory(source))
+	if err != nil {
+		return nil, err
+	}
+	items := commandsToMaps(runners)
+	for i, runner := range runners {
+		if order, ok := pinOrders[runner.GetTriggerID()]; ok {
+			items[i].(map[string]interface{})["pinOrder"] = order
 		}
-		parsedCommand.ID = id
-		updateCommands = append(updateCommands, parsedCommand)
 	}
-	logger.Info(fmt.Sprintf("Update commands result: updated %d / total %d", len(updateCommands), len(commands)))
-	err := dbInstance.BatchUpdateCommands(w.ctx, updateCommands)
+	return items, nil
+}
+
+func (w *WaLaunchApp) GetApplicationCommands() []interface{} {
+	commands, err := w.GetCommands(string(models.CategoryApplication))
 	if err != nil {
-		logger.Error(err, "Failed to batch update updated commands to db")
+		logger.Error(err, "Failed to get application commands")
 	}
-	logger.Info(fmt.Sprintf("Delete commands result: removed %d / total %d", len(removeCommands), len(commands)))
-	err = dbInstance.DeleteCommands(w.ctx, lo.Map(removeCommands, func(command *models.ApplicationCommand, _ int) string {
-		return command.ID
-	}))
+	return commands
+}
+
+func (w *WaLaunchApp) GetOperationCommands() []interface{} {
+	commands, err := w.GetCommands(string(models.CategoryOperation))
 	if err != nil {
-		logger.Error(err, "Failed to delete commands")
+		logger.Error(err, "Failed to get operation commands")
 	}
+	return commands
+}
 
-	logger.Info("Checking commands from disk")
-	appPathInfos := application.GetAppPathInfos()
-	for _, appPathInfo := range appPathInfos {
-		if _, exists := seen[appPathInfo.Path]; exists {
-			continue
-		}
-		logger.Info(fmt.Sprintf("Adding command from path: %s", appPathInfo.Path))
-		command, err := application.ParseApplication(appPathInfo.Path)
-		if err != nil {
-			logger.Error(err, "Failed to parse application")
-			continue
-		}
-		insertCommands = append(insertCommands, command)
-	}
-	logger.Info(fmt.Sprintf("Scan disk commands result: added %d / total %d", len(insertCommands), len(appPathInfos)))
-	err = dbInstance.BatchInsertCommands(w.ctx, insertCommands)
+// RefreshCommands asks a source to rescan, changes are announced with CommandsChangedEvent
+func (w *WaLaunchApp) RefreshCommands(source string) error {
+	return w.registry.Refresh(w.ctx, models.CommandCategory(source))
+}
+
+// TriggerCommand runs a command with arguments, they are checked against the kinds the command accepts
+func (w *WaLaunchApp) TriggerCommand(uniqueTriggerID string, source string, arguments models.CommandArguments) error {
+	err := w.registry.Trigger(w.ctx, models.CommandCategory(source), uniqueTriggerID, arguments)
 	if err != nil {
-		logger.Error(err, "Failed to batch insert new commands to db")
+		logger.Error(err, fmt.Sprintf("cant trigger runner: %s", uniqueTriggerID))
+		return err
 	}
-	if len(insertCommands)+len(updateCommands)+len(removeCommands) > 0 {
-		runtime.EventsEmit(w.ctx, "watools.applicationChanged")
+	logger.Info(fmt.Sprintf("trigger runner success: %s", uniqueTriggerID))
+	return nil
+}
+
+// RunCommandAction runs one of the actions a command lists besides its trigger and returns its result.
+// Arguments are checked like for TriggerCommand.
+func (w *WaLaunchApp) RunCommandAction(uniqueTriggerID string, source string, action string, arguments models.CommandArguments) (interface{}, error) {
+	result, err := w.registry.RunAction(w.ctx, models.CommandCategory(source), uniqueTriggerID, action, arguments)
+	if err != nil {
+		logger.Error(err, fmt.Sprintf("cant run action %s: %s", action, uniqueTriggerID))
+		return nil, err
 	}
+	logger.Info(fmt.Sprintf("action %s success: %s", action, uniqueTriggerID))
+	return result, nil
 }
 
-var ApiMutex sync.Mutex
+func (w *WaLaunchApp) GetGitRepoRoots() ([]models.GitRepoRoot, error) {
+	return db.GetWaDB().GetGitRepoRoots(w.ctx)
+}
 
-func (w *WaLaunchApp) getApplicationCommands() []*models.ApplicationCommand {
-	ApiMutex.Lock()
-	defer ApiMutex.Unlock()
-	dbInstance := db.GetWaDB()
-	commands := dbInstance.GetCommands(w.ctx)
-	if len(commands) == 0 {
-		commands, err := application.GetApplications()
-		if err != nil {
-			logger.Error(err, "Failed to get application")
-			return []*models.ApplicationCommand{}
-		}
-		err = dbInstance.BatchInsertCommands(w.ctx, commands)
-		if err != nil {
-			logger.Error(err, "Failed to batch insert commands")
-		}
-	}
-	for _, command := range commands {
-		if command.IconPath.IsNone() {
-			command.IconPath = mo.Some(application.GetDefaultIconPath())
-		}
-	}
-	return commands
+// AddGitRepoRoot searches a directory for repositories maxDepth levels deep, "~/" is expanded to the home directory.
+// Adding a root again changes its depth, the roots are scanned again in the background.
+func (w *WaLaunchApp) AddGitRepoRoot(path string, maxDepth int) error {
+	return w.gitRepos.addRoot(w.ctx, path, maxDepth)
 }
 
-func (w *WaLaunchApp) GetApplicationCommands() []interface{} {
-	var commands []interface{}
-	w.applicationRunner = nil
+func (w *WaLaunchApp) RemoveGitRepoRoot(path string) error {
+	return w.gitRepos.removeRoot(w.ctx, path)
+}
 
-	apps := lo.UniqBy(w.getApplicationCommands(), func(app *models.ApplicationCommand) string {
-		if app.ID != "" {
-			return app.ID
-		}
-		return app.Path
-	})
-	w.applicationRunner = append(w.applicationRunner, lo.Map(apps, func(app *models.ApplicationCommand, _ int) models.CommandRunner { return app })...)
-	commands = append(commands, lo.Map(apps, func(app *models.ApplicationCommand, _ int) interface{} {
-		var m map[string]interface{}
-		data, _ := json.Marshal(app)
-		_ = json.Unmarshal(data, &m)
-		return m
-	})...)
+func (w *WaLaunchApp) GetGitRepoIgnores() ([]string, error) {
+	return db.GetWaDB().GetGitRepoIgnores(w.ctx)
+}
 
-	return commands
+// AddGitRepoIgnore skips directories matching a .gitignore pattern below every root
+func (w *WaLaunchApp) AddGitRepoIgnore(pattern string) error {
+	return w.gitRepos.addIgnore(w.ctx, pattern)
 }
 
-func (w *WaLaunchApp) GetOperationCommands() []interface{} {
-	w.operationRunner = nil
+func (w *WaLaunchApp) RemoveGitRepoIgnore(pattern string) error {
+	return w.gitRepos.removeIgnore(w.ctx, pattern)
+}
 
-	operations := operator.GetOperations()
-	uniqueOperations := lo.UniqBy(operations, func(operation *models.OperationCommand) string {
-		return operation.TriggerID
-	})
-	w.operationRunner = append(w.operationRunner, lo.Map(uniqueOperations, func(operation *models.OperationCommand, _ int) models.CommandRunner { return operation })...)
+// GetApplicationAliases returns the aliases of the application with the given id
+func (w *WaLaunchApp) GetApplicationAliases(id string) ([]string, error) {
+	return db.GetWaDB().GetCommandAliases(w.ctx, id)
+}
 
-	return lo.Map(uniqueOperations, func(operation *models.OperationCommand, _ int) interface{} {
-		var m map[string]interface{}
-		data, _ := json.Marshal(operation)
-		_ = json.Unmarshal(data, &m)
-		return m
-	})
+// AddApplicationAlias stores a search alias for an application, it is kept when the application is rescanned
+func (w *WaLaunchApp) AddApplicationAlias(id string, alias string) error {
+	return w.applications.addAlias(w.ctx, id, alias)
+}
+
+func (w *WaLaunchApp) RemoveApplicationAlias(id string, alias string) error {
+	return w.applications.removeAlias(w.ctx, id, alias)
 }
 
-func (w *WaLaunchApp) TriggerCommand(uniqueTriggerID string, triggerCategory models.CommandCategory) {
-	var runners []models.CommandRunner
-	if triggerCategory == models.CategoryApplication {
-		runners = w.applicationRunner
-	} else if triggerCategory == models.CategoryOperation {
-		runners = w.operationRunner
-	} else {
-		logger.Error(fmt.Errorf("trigger category is not valid: %s", triggerCategory))
+// SetClipboardTextReader sets how commands read the clipboard text, it is needed to expand {clipboard}
+func (w *WaLaunchApp) SetClipboardTextReader(clipboardText func() (string, error)) {
+	w.quicklinks.clipboardText = clipboardText
+	w.snippets.clipboardText = clipboardText
+}
+
+// SetClipboardTextWriter sets how snippets, PIDs, repository and file paths are written to the clipboard
+func (w *WaLaunchApp) SetClipboardTextWriter(writeClipboard func(text string) error) {
+	w.snippets.writeClipboard = writeClipboard
+	w.processes.writeClipboard = writeClipboard
+	w.gitRepos.writeClipboard = writeClipboard
+	w.files.writeClipboard = writeClipboard
+}
+
+// SetRevealer sets how repositories and files are shown in the file manager
+func (w *WaLaunchApp) SetRevealer(reveal func(path string)) {
+	w.gitRepos.reveal = reveal
+	w.files.reveal = reveal
+}
+
+func (w *WaLaunchApp) GetQuicklinks() ([]interface{}, error) {
+	quicklinks, err := db.GetWaDB().GetQuicklinks(w.ctx)
+	if err != nil {
+		return nil, err
 	}
+	return commandsToMaps(quicklinks), nil
+}
 
-	for _, runner := range runners {
-		if runner.GetTriggerID() == uniqueTriggerID {
-			err := runner.OnTrigger()
-			if err != nil {
-				logger.Error(err, fmt.Sprintf("cant trigger runner: %s", uniqueTriggerID))
-			} else {
-				logger.Info(fmt.Sprintf("trigger runner success: %s", uniqueTriggerID))
-			}
-			return
-		}
+func (w *WaLaunchApp) CreateQuicklink(name string, keyword string, url string, icon string, targetApp string) (*models.QuicklinkCommand, error) {
+	return w.quicklinks.create(w.ctx, name, keyword, url, icon, targetApp)
+}
+
+func (w *WaLaunchApp) UpdateQuicklink(id string, name string, keyword string, url string, icon string, targetApp string) (*models.QuicklinkCommand, error) {
+	return w.quicklinks.update(w.ctx, id, name, keyword, url, icon, targetApp)
+}
+
+func (w *WaLaunchApp) DeleteQuicklink(id string) error {
+	return w.quicklinks.delete(w.ctx, id)
+}
+
+func (w *WaLaunchApp) GetSnippets() ([]interface{}, error) {
+	snippets, err := w.snippets.snippets(w.ctx)
+	if err != nil {
+		return nil, err
 	}
+	return commandsToMaps(snippets), nil
+}
+
+func (w *WaLaunchApp) CreateSnippet(name string, keyword string, body string, tags []string) (*models.SnippetCommand, error) {
+	return w.snippets.create(w.ctx, name, keyword, body, tags)
+}
 
-	logger.Error(fmt.Errorf("not find runner: %s", uniqueTriggerID))
+func (w *WaLaunchApp) UpdateSnippet(id string, name string, keyword string, body string, tags []string) (*models.SnippetCommand, error) {
+	return w.snippets.update(w.ctx, id, name, keyword, body, tags)
 }
 
-func (w *WaLaunchApp) initAppWatcher() {
-	eventHandler := watcher.NewDefaultAppEventHandler(w.ctx)
+func (w *WaLaunchApp) DeleteSnippet(id string) error {
+	return w.snippets.delete(w.ctx, id)
+}
+
+// ImportSnippets imports a .json or .alfredsnippets file and returns how many snippets were stored
+func (w *WaLaunchApp) ImportSnippets(path string) (int, error) {
+	count, err := w.snippets.importFile(w.ctx, path)
+	if err != nil {
+		logger.Error(err, fmt.Sprintf("cant import snippets from %s", path))
+		return 0, err
+	}
+	logger.Info(fmt.Sprintf("imported %d snippets from %s", count, path))
+	return count, nil
+}
 
-	watchManager, err := watcher.NewAppWatchManager(eventHandler, w.ctx)
+// ImportSnippetsByFileDialog asks for a snippet file and imports it, the count is 0 when the dialog is cancelled
+func (w *WaLaunchApp) ImportSnippetsByFileDialog() (int, error) {
+	path, err := runtime.OpenFileDialog(w.ctx, runtime.OpenDialogOptions{
+		Title:   "Select Snippet File",
+		Filters: snippetFileFilters,
+	})
 	if err != nil {
-		logger.Error(err, "Failed to create app watch manager")
-		return
+		return 0, err
+	}
+	if path == "" {
+		return 0, nil
 	}
+	return w.ImportSnippets(path)
+}
 
-	w.watchManager = watchManager
+// ExportSnippets writes every snippet to path, the extension selects JSON or an Alfred collection
+func (w *WaLaunchApp) ExportSnippets(path string) error {
+	if err := w.snippets.exportFile(w.ctx, path); err != nil {
+		logger.Error(err, fmt.Sprintf("cant export snippets to %s", path))
+		return err
+	}
+	return nil
+}
 
-	if err := w.watchManager.Start(); err != nil {
-		logger.Error(err, "Failed to start app watch manager")
-		w.watchManager = nil
-		return
+// ExportSnippetsByFileDialog asks where to export the snippets, nothing is written when the dialog is cancelled
+func (w *WaLaunchApp) ExportSnippetsByFileDialog() error {
+	path, err := runtime.SaveFileDialog(w.ctx, runtime.SaveDialogOptions{
+		Title:           "Export Snippets",
+		DefaultFilename: "snippets.json",
+		Filters:         snippetFileFilters,
+	})
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return nil
 	}
+	return w.ExportSnippets(path)
+}
 
-	logger.Info("App watcher initialized successfully")
+var snippetFileFilters = []runtime.FileFilter{
+	{DisplayName: "Snippets (*.json)", Pattern: "*.json"},
+	{DisplayName: "Alfred Snippets (*" + snippet.AlfredExtension + ")", Pattern: "*" + snippet.AlfredExtension},
 }
 
 func (w *WaLaunchApp) GetWatchStatus() map[string]interface{} {
 	status := make(map[string]interface{})
 
-	if w.watchManager == nil {
+	watchManager := w.applications.watchManager
+	if watchManager == nil {
 		status["enabled"] = false
 		status["error"] = "watch manager not initialized"
 		return status
 	}
 
 	status["enabled"] = true
-	status["running"] = w.watchManager.IsRunning()
+	status["running"] = watchManager.IsRunning()
 
-	status["watchDirs"] = w.watchManager.GetWatchDirs()
-	status["config"] = w.watchManager.GetConfig()
-	status["metrics"] = w.watchManager.GetMetrics()
+	status["watchDirs"] = watchManager.GetWatchDirs()
+	status["config"] = watchManager.GetConfig()
+	status["metrics"] = watchManager.GetMetrics()
 
 	return status
 }
 
 func (w *WaLaunchApp) GetWatchMetrics() *watcher.WatcherMetrics {
-	if w.watchManager == nil {
+	watchManager := w.applications.watchManager
+	if watchManager == nil {
 		return watcher.NewWatcherMetrics()
 	}
-	return w.watchManager.GetMetrics()
-}
-
-func (w *WaLaunchApp) UpdateApplicationUsage(usageUpdates []models.ApplicationUsageUpdate) error {
-	dbInstance := db.GetWaDB()
-	return dbInstance.BatchUpdateApplicationUsage(w.ctx, usageUpdates)
+	return watchManager.GetMetrics()
 }
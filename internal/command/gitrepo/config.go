@@ -0,0 +1,162 @@
+package gitrepo
+
+import (
+	"bufio"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// configEntry is one variable of a git config file, section and key are lower case, the subsection keeps its case
+type configEntry struct {
+	section    string
+	subsection string
+	key        string
+	value      string
+}
+
+var sectionPattern = regexp.MustCompile(`^\[\s*([A-Za-z0-9.-]+)(?:\s+"((?:[^"\\]|\\.)*)")?\s*\]`)
+
+// parseConfig reads the variables of a git config file, includes are not followed
+func parseConfig(r io.Reader) []configEntry {
+	var entries []configEntry
+	section, subsection := "", ""
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if line[0] == '[' {
+			match := sectionPattern.FindStringSubmatch(line)
+			if match == nil {
+				section, subsection = "", ""
+				continue
+			}
+			section = strings.ToLower(match[1])
+			subsection = strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(match[2])
+			// the old [section.subsection] syntax
+			if match[2] == "" {
+				if name, sub, found := strings.Cut(section, "."); found {
+					section, subsection = name, sub
+				}
+			}
+			line = strings.TrimSpace(line[len(match[0]):])
+			if line == "" {
+				continue
+			}
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			// a key without a value is a true boolean
+			value = "true"
+		}
+		entries = append(entries, configEntry{
+			section:    section,
+			subsection: subsection,
+			key:        strings.ToLower(strings.TrimSpace(key)),
+			value:      configValue(value),
+		})
+	}
+	return entries
+}
+
+// configValue strips comments and quotes from a value and resolves its escapes
+func configValue(raw string) string {
+	var value strings.Builder
+	quoted := false
+	raw = strings.TrimSpace(raw)
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case (c == '#' || c == ';') && !quoted:
+			return strings.TrimSpace(value.String())
+		case c == '\\' && i+1 < len(raw):
+			i++
+			switch raw[i] {
+			case 'n':
+				value.WriteByte('\n')
+			case 't':
+				value.WriteByte('\t')
+			default:
+				value.WriteByte(raw[i])
+			}
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return strings.TrimSpace(value.String())
+}
+
+func readConfig(gitDir string) []configEntry {
+	file, err := os.Open(filepath.Join(commonDir(gitDir), "config"))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+	return parseConfig(file)
+}
+
+// remoteURL returns the url of the "origin" remote, or of the first remote with a url
+func remoteURL(gitDir string) string {
+	return remoteURLOf(readConfig(gitDir))
+}
+
+func remoteURLOf(entries []configEntry) string {
+	first := ""
+	for _, entry := range entries {
+		if entry.section != "remote" || entry.key != "url" || entry.value == "" {
+			continue
+		}
+		if entry.subsection == "origin" {
+			return entry.value
+		}
+		if first == "" {
+			first = entry.value
+		}
+	}
+	return first
+}
+
+// scpLikePattern matches "git@github.com:owner/repo.git", git's short form of ssh urls
+var scpLikePattern = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+
+// BrowserURL returns the web page of a remote url, such as https://github.com/owner/repo for git@github.com:owner/repo.git.
+// It is empty for local paths and urls that have no web page.
+func BrowserURL(remote string) string {
+	remote = strings.TrimSpace(remote)
+	host, path := "", ""
+	scheme := "https"
+	if strings.Contains(remote, "://") {
+		parsed, err := url.Parse(remote)
+		if err != nil || parsed.Host == "" {
+			return ""
+		}
+		switch parsed.Scheme {
+		case "http", "https":
+			scheme = parsed.Scheme
+			host = parsed.Host
+		case "ssh", "git", "git+ssh", "ssh+git":
+			// the web page is not served on the ssh port
+			host = parsed.Hostname()
+		default:
+			return ""
+		}
+		path = parsed.Path
+	} else if match := scpLikePattern.FindStringSubmatch(remote); match != nil && !filepath.IsAbs(remote) && len(match[1]) > 1 {
+		// a single letter before the colon is a Windows drive
+		host, path = match[1], match[2]
+	} else {
+		return ""
+	}
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if path == "" {
+		return ""
+	}
+	return scheme + "://" + host + "/" + path
+}
@@ -0,0 +1,126 @@
+// Package gitrepo finds git repositories below a directory and reads their remote, branch and unstaged changes from the repository files.
+// git itself is never run.
+package gitrepo
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"watools/pkg/gitignore"
+	"watools/pkg/logger"
+)
+
+// dotGit is the directory, or for worktrees and submodules the file pointing to it, that marks a repository
+const dotGit = ".git"
+
+// Repo is a repository found by Scan
+type Repo struct {
+	Path string
+	Name string
+	// RemoteURL is the url of the "origin" remote, or of the first remote, as written in the config
+	RemoteURL string
+}
+
+// Scan returns the repositories in root and below it, at most maxDepth directories deep.
+// Directories matching ignores are skipped, and so is everything inside a repository, submodules included.
+func Scan(ctx context.Context, root string, maxDepth int, ignores gitignore.Matcher) ([]Repo, error) {
+	root = filepath.Clean(root)
+	var repos []Repo
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			logger.Debug("Skip unreadable path " + path)
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if path != root && ignores.Ignored(path, true) {
+			return filepath.SkipDir
+		}
+		if gitDir, ok := GitDir(path); ok {
+			repos = append(repos, Repo{Path: path, Name: filepath.Base(path), RemoteURL: remoteURL(gitDir)})
+			return filepath.SkipDir
+		}
+		if depth(root, path) >= maxDepth {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return repos, err
+}
+
+// depth is how many directories path is below root
+func depth(root string, path string) int {
+	relative, err := filepath.Rel(root, path)
+	if err != nil || relative == "." {
+		return 0
+	}
+	return strings.Count(relative, string(filepath.Separator)) + 1
+}
+
+// GitDir returns the git directory of the repository at path.
+// A .git file, as written for worktrees and submodules, holds "gitdir: <path>".
+func GitDir(path string) (string, bool) {
+	dotGitPath := filepath.Join(path, dotGit)
+	info, err := os.Stat(dotGitPath)
+	if err != nil {
+		return "", false
+	}
+	if info.IsDir() {
+		if _, err := os.Stat(filepath.Join(dotGitPath, "HEAD")); err != nil {
+			return "", false
+		}
+		return dotGitPath, true
+	}
+	data, err := os.ReadFile(dotGitPath)
+	if err != nil {
+		return "", false
+	}
+	gitDir, found := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !found {
+		return "", false
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(path, gitDir)
+	}
+	return filepath.Clean(gitDir), true
+}
+
+// commonDir returns the directory holding the config of gitDir, worktrees share it with the main repository
+func commonDir(gitDir string) string {
+	data, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return gitDir
+	}
+	dir := strings.TrimSpace(string(data))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(gitDir, dir)
+	}
+	return filepath.Clean(dir)
+}
+
+// NormalizeRoot cleans a root given by the user, "~/" is expanded to the home directory
+func NormalizeRoot(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home dir: %w", err)
+		}
+		path = filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+	}
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("root '%s' must be an absolute path", path)
+	}
+	return filepath.Clean(path), nil
+}
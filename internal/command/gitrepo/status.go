@@ -0,0 +1,263 @@
+package gitrepo
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	indexSignature = "DIRC"
+	// indexEntryFixedSize is the size of the stat data and the mode of an entry, before its object hash
+	indexEntryFixedSize = 40
+	shortHashLength     = 7
+
+	flagAssumeValid  = 0x8000
+	flagExtended     = 0x4000
+	flagStageMask    = 0x3000
+	flagSkipWorktree = 0x4000
+	flagIntentToAdd  = 0x2000
+
+	modeTypeMask = 0170000
+	modeSymlink  = 0120000
+	modeGitlink  = 0160000
+)
+
+// Status is the checked out branch and whether tracked files have unstaged changes
+type Status struct {
+	// Branch is the checked out branch, or the short hash of the commit when HEAD is detached
+	Branch   string
+	Detached bool
+	// Unstaged is true when a tracked file differs from the index or was deleted, or the index has conflicts or files
+	// added with intent. Untracked files are not counted, and neither are changes staged in the index: comparing the
+	// index with the tree of HEAD needs the object database, which is not read.
+	Unstaged bool
+}
+
+// Stamp changes whenever HEAD or the index of a repository is written, a Status stays valid while it does not
+func Stamp(repoPath string) string {
+	gitDir, ok := GitDir(repoPath)
+	if !ok {
+		return ""
+	}
+	var stamp strings.Builder
+	for _, name := range []string{"HEAD", "index"} {
+		if info, err := os.Stat(filepath.Join(gitDir, name)); err == nil {
+			stamp.WriteString(strconv.FormatInt(info.ModTime().UnixNano(), 36))
+			stamp.WriteString(strconv.FormatInt(info.Size(), 36))
+		}
+		stamp.WriteByte('/')
+	}
+	return stamp.String()
+}
+
+// ReadStatus reads the branch from HEAD and compares the tracked files with the stat data of the index.
+// Files whose modification time changed are hashed, so touched but unchanged files are clean.
+func ReadStatus(repoPath string) (Status, error) {
+	gitDir, ok := GitDir(repoPath)
+	if !ok {
+		return Status{}, fmt.Errorf("'%s' is not a git repository", repoPath)
+	}
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to read HEAD: %w", err)
+	}
+	status := parseHead(string(head))
+
+	newHash := sha1.New
+	for _, entry := range readConfig(gitDir) {
+		if entry.section == "extensions" && entry.key == "objectformat" && strings.EqualFold(entry.value, "sha256") {
+			newHash = sha256.New
+		}
+	}
+	index, err := os.Open(filepath.Join(gitDir, "index"))
+	if errors.Is(err, os.ErrNotExist) {
+		// nothing was ever added
+		return status, nil
+	}
+	if err != nil {
+		return status, fmt.Errorf("failed to read index: %w", err)
+	}
+	defer index.Close()
+	status.Unstaged, err = hasUnstagedChanges(bufio.NewReader(index), repoPath, newHash)
+	return status, err
+}
+
+func parseHead(head string) Status {
+	head = strings.TrimSpace(head)
+	if ref, found := strings.CutPrefix(head, "ref:"); found {
+		ref = strings.TrimSpace(ref)
+		return Status{Branch: strings.TrimPrefix(ref, "refs/heads/")}
+	}
+	if len(head) > shortHashLength {
+		head = head[:shortHashLength]
+	}
+	return Status{Branch: head, Detached: true}
+}
+
+// indexEntry is the part of an index entry needed to tell whether the file changed
+type indexEntry struct {
+	mtimeSeconds uint32
+	mtimeNanos   uint32
+	mode         uint32
+	size         uint32
+	hash         []byte
+	flags        uint16
+	extended     uint16
+	path         string
+}
+
+// hasUnstagedChanges reads the entries of an index in format 2, 3 or 4 and stops at the first changed file
+func hasUnstagedChanges(r io.Reader, workTree string, newHash func() hash.Hash) (bool, error) {
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return false, fmt.Errorf("failed to read index header: %w", err)
+	}
+	if string(header[:4]) != indexSignature {
+		return false, fmt.Errorf("not a git index")
+	}
+	version := binary.BigEndian.Uint32(header[4:])
+	if version < 2 || version > 4 {
+		return false, fmt.Errorf("unsupported index version %d", version)
+	}
+	count := binary.BigEndian.Uint32(header[8:])
+	hashSize := newHash().Size()
+
+	previousPath := ""
+	fixed := make([]byte, indexEntryFixedSize+hashSize+2)
+	for i := uint32(0); i < count; i++ {
+		if _, err := io.ReadFull(r, fixed); err != nil {
+			return false, fmt.Errorf("failed to read index entry: %w", err)
+		}
+		entry := indexEntry{
+			mtimeSeconds: binary.BigEndian.Uint32(fixed[8:]),
+			mtimeNanos:   binary.BigEndian.Uint32(fixed[12:]),
+			mode:         binary.BigEndian.Uint32(fixed[24:]),
+			size:         binary.BigEndian.Uint32(fixed[36:]),
+			hash:         append([]byte(nil), fixed[indexEntryFixedSize:indexEntryFixedSize+hashSize]...),
+			flags:        binary.BigEndian.Uint16(fixed[indexEntryFixedSize+hashSize:]),
+		}
+		entrySize := len(fixed)
+		if version >= 3 && entry.flags&flagExtended != 0 {
+			extended := make([]byte, 2)
+			if _, err := io.ReadFull(r, extended); err != nil {
+				return false, fmt.Errorf("failed to read index entry: %w", err)
+			}
+			entry.extended = binary.BigEndian.Uint16(extended)
+			entrySize += 2
+		}
+
+		if version == 4 {
+			// the path drops the given number of bytes from the end of the previous path and appends the rest
+			strip, err := readOffset(r)
+			if err != nil || strip > len(previousPath) {
+				return false, fmt.Errorf("invalid index entry path")
+			}
+			suffix, err := readUntilNul(r)
+			if err != nil {
+				return false, err
+			}
+			entry.path = previousPath[:len(previousPath)-strip] + suffix
+		} else {
+			name, err := readUntilNul(r)
+			if err != nil {
+				return false, err
+			}
+			entry.path = name
+			// entries are padded with 1 to 8 NUL bytes to a multiple of 8, the terminating NUL included
+			entrySize += len(name)
+			padding := 8 - entrySize%8
+			if _, err := io.CopyN(io.Discard, r, int64(padding-1)); err != nil {
+				return false, fmt.Errorf("failed to read index entry: %w", err)
+			}
+		}
+		previousPath = entry.path
+
+		if entry.changed(workTree, newHash) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// changed compares an entry with the file in the work tree
+func (e indexEntry) changed(workTree string, newHash func() hash.Hash) bool {
+	if e.flags&flagStageMask != 0 || e.extended&flagIntentToAdd != 0 {
+		// a merge conflict, or a file that is not yet added
+		return true
+	}
+	if e.flags&flagAssumeValid != 0 || e.extended&flagSkipWorktree != 0 || e.mode&modeTypeMask == modeGitlink {
+		return false
+	}
+	path := filepath.Join(workTree, filepath.FromSlash(e.path))
+	info, err := os.Lstat(path)
+	if err != nil {
+		return true
+	}
+	isSymlink := info.Mode()&os.ModeSymlink != 0
+	if info.IsDir() || isSymlink != (e.mode&modeTypeMask == modeSymlink) {
+		return true
+	}
+	if uint32(info.Size()) != e.size {
+		return true
+	}
+	mtime := info.ModTime()
+	if uint32(mtime.Unix()) == e.mtimeSeconds && uint32(mtime.Nanosecond()) == e.mtimeNanos {
+		return false
+	}
+
+	var content []byte
+	if isSymlink {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return true
+		}
+		content = []byte(filepath.ToSlash(target))
+	} else if content, err = os.ReadFile(path); err != nil {
+		return true
+	}
+	objectHash := newHash()
+	fmt.Fprintf(objectHash, "blob %d\x00", len(content))
+	objectHash.Write(content)
+	return !bytes.Equal(objectHash.Sum(nil), e.hash)
+}
+
+// readOffset reads the variable length integer of index format 4
+func readOffset(r io.Reader) (int, error) {
+	buffer := make([]byte, 1)
+	if _, err := io.ReadFull(r, buffer); err != nil {
+		return 0, err
+	}
+	value := int(buffer[0] & 0x7f)
+	for buffer[0]&0x80 != 0 {
+		if _, err := io.ReadFull(r, buffer); err != nil {
+			return 0, err
+		}
+		value = ((value + 1) << 7) | int(buffer[0]&0x7f)
+	}
+	return value, nil
+}
+
+func readUntilNul(r io.Reader) (string, error) {
+	var path []byte
+	buffer := make([]byte, 1)
+	for {
+		if _, err := io.ReadFull(r, buffer); err != nil {
+			return "", fmt.Errorf("failed to read index entry path: %w", err)
+		}
+		if buffer[0] == 0 {
+			return string(path), nil
+		}
+		path = append(path, buffer[0])
+	}
+}
@@ -0,0 +1,127 @@
+package operator
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"watools/pkg/models"
+)
+
+// CommandRunner executes system commands, tests replace it to check the exact command lines
+type CommandRunner interface {
+	LookPath(file string) (string, error)
+	Run(name string, args ...string) error
+	Output(name string, args ...string) ([]byte, error)
+}
+
+type execRunner struct{}
+
+func (execRunner) LookPath(file string) (string, error) {
+	return exec.LookPath(file)
+}
+
+func (execRunner) Run(name string, args ...string) error {
+	return exec.Command(name, args...).Run()
+}
+
+func (execRunner) Output(name string, args ...string) ([]byte, error) {
+	return exec.Command(name, args...).Output()
+}
+
+const (
+	interfaceSchema = "org.gnome.desktop.interface"
+	colorSchemeKey  = "color-scheme"
+)
+
+func GetOperations() []*models.OperationCommand {
+	return getOperations(execRunner{}, os.Getenv("XDG_SESSION_ID"))
+}
+
+// getOperations returns the operations whose tools are installed and which logind allows for the session
+func getOperations(runner CommandRunner, sessionID string) []*models.OperationCommand {
+	has := func(file string) bool {
+		_, err := runner.LookPath(file)
+		return err == nil
+	}
+
+	var operations []*models.OperationCommand
+	if has("loginctl") {
+		operations = append(operations, models.NewOperationCommand("Lock Screen", "Lock the screen", "lock", func() error {
+			return runner.Run("loginctl", "lock-session")
+		}))
+	}
+	if has("systemctl") {
+		if canPower(runner, "CanSuspend") {
+			operations = append(operations, models.NewOperationCommand("Suspend", "Suspend the system to RAM", "moon", func() error {
+				return runner.Run("systemctl", "suspend")
+			}))
+		}
+		if canPower(runner, "CanHibernate") {
+			operations = append(operations, models.NewOperationCommand("Hibernate", "Hibernate the system to disk", "snowflake", func() error {
+				return runner.Run("systemctl", "hibernate")
+			}))
+		}
+	}
+	if has("loginctl") && sessionID != "" {
+		operations = append(operations, models.NewOperationCommand("Log Out", "Log out of the current session", "log-out", func() error {
+			return runner.Run("loginctl", "terminate-session", sessionID)
+		}))
+	}
+	if has("systemctl") {
+		if canPower(runner, "CanReboot") {
+			operations = append(operations, models.NewOperationCommand("Reboot", "Restart the system", "rotate-ccw", func() error {
+				return runner.Run("systemctl", "reboot")
+			}))
+		}
+		if canPower(runner, "CanPowerOff") {
+			operations = append(operations, models.NewOperationCommand("Power Off", "Shut down the system", "power", func() error {
+				return runner.Run("systemctl", "poweroff")
+			}))
+		}
+	}
+	if has("gio") {
+		operations = append(operations, models.NewOperationCommand("Empty Trash", "Empty the Trash", "trash-2", func() error {
+			return runner.Run("gio", "trash", "--empty")
+		}))
+	}
+	if has("gsettings") && colorSchemeWritable(runner) {
+		operations = append(operations, models.NewOperationCommand("Toggle Dark Mode", "Switch between light and dark mode", "sun-moon", func() error {
+			return toggleDarkMode(runner)
+		}))
+	}
+	return operations
+}
+
+// canPower asks logind whether a power action is permitted, "challenge" means it is allowed after authentication.
+// Without busctl the answer is unknown, so the action is offered and systemctl reports any refusal.
+func canPower(runner CommandRunner, method string) bool {
+	if _, err := runner.LookPath("busctl"); err != nil {
+		return true
+	}
+	output, err := runner.Output("busctl", "call", "org.freedesktop.login1", "/org/freedesktop/login1",
+		"org.freedesktop.login1.Manager", method)
+	if err != nil {
+		return false
+	}
+	// reply is formatted as: s "yes"
+	answer := strings.Trim(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(string(output)), "s")), "\"")
+	return answer == "yes" || answer == "challenge"
+}
+
+func colorSchemeWritable(runner CommandRunner) bool {
+	output, err := runner.Output("gsettings", "writable", interfaceSchema, colorSchemeKey)
+	return err == nil && strings.TrimSpace(string(output)) == "true"
+}
+
+func toggleDarkMode(runner CommandRunner) error {
+	output, err := runner.Output("gsettings", "get", interfaceSchema, colorSchemeKey)
+	if err != nil {
+		return fmt.Errorf("failed to read color scheme: %w", err)
+	}
+	next := "prefer-dark"
+	if strings.Trim(strings.TrimSpace(string(output)), "'") == "prefer-dark" {
+		next = "default"
+	}
+	return runner.Run("gsettings", "set", interfaceSchema, colorSchemeKey, next)
+}
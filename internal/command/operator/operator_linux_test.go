@@ -0,0 +1,123 @@
+package operator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"watools/pkg/models"
+)
+
+type fakeRunner struct {
+	installed map[string]bool
+	outputs   map[string]string
+	calls     []string
+}
+
+func (f *fakeRunner) LookPath(file string) (string, error) {
+	if f.installed[file] {
+		return "/usr/bin/" + file, nil
+	}
+	return "", fmt.Errorf("%s not found", file)
+}
+
+func (f *fakeRunner) Run(name string, args ...string) error {
+	f.calls = append(f.calls, strings.Join(append([]string{name}, args...), " "))
+	return nil
+}
+
+func (f *fakeRunner) Output(name string, args ...string) ([]byte, error) {
+	line := strings.Join(append([]string{name}, args...), " ")
+	output, exists := f.outputs[line]
+	if !exists {
+		return nil, fmt.Errorf("unexpected command: %s", line)
+	}
+	return []byte(output), nil
+}
+
+func operationNames(operations []*models.OperationCommand) []string {
+	names := make([]string, 0, len(operations))
+	for _, operation := range operations {
+		names = append(names, operation.Name)
+	}
+	return names
+}
+
+func findOperation(t *testing.T, operations []*models.OperationCommand, name string) *models.OperationCommand {
+	t.Helper()
+	for _, operation := range operations {
+		if operation.Name == name {
+			return operation
+		}
+	}
+	t.Fatalf("operation %q not found in %v", name, operationNames(operations))
+	return nil
+}
+
+func logindCall(method string) string {
+	return "busctl call org.freedesktop.login1 /org/freedesktop/login1 org.freedesktop.login1.Manager " + method
+}
+
+func TestGetOperationsCommands(t *testing.T) {
+	t.Parallel()
+
+	runner := &fakeRunner{
+		installed: map[string]bool{"loginctl": true, "systemctl": true, "busctl": true, "gio": true, "gsettings": true},
+		outputs: map[string]string{
+			logindCall("CanSuspend"):   "s \"yes\"\n",
+			logindCall("CanHibernate"): "s \"challenge\"\n",
+			logindCall("CanReboot"):    "s \"yes\"\n",
+			logindCall("CanPowerOff"):  "s \"yes\"\n",
+			"gsettings writable org.gnome.desktop.interface color-scheme": "true\n",
+			"gsettings get org.gnome.desktop.interface color-scheme":      "'prefer-dark'\n",
+		},
+	}
+	operations := getOperations(runner, "3")
+
+	cases := []struct {
+		name string
+		want string
+	}{
+		{name: "Lock Screen", want: "loginctl lock-session"},
+		{name: "Suspend", want: "systemctl suspend"},
+		{name: "Hibernate", want: "systemctl hibernate"},
+		{name: "Log Out", want: "loginctl terminate-session 3"},
+		{name: "Reboot", want: "systemctl reboot"},
+		{name: "Power Off", want: "systemctl poweroff"},
+		{name: "Empty Trash", want: "gio trash --empty"},
+		{name: "Toggle Dark Mode", want: "gsettings set org.gnome.desktop.interface color-scheme default"},
+	}
+	for _, tc := range cases {
+		runner.calls = nil
+		if err := findOperation(t, operations, tc.name).OnTrigger(models.NoArguments); err != nil {
+			t.Fatalf("%s: OnTrigger returned error: %v", tc.name, err)
+		}
+		if !reflect.DeepEqual(runner.calls, []string{tc.want}) {
+			t.Fatalf("%s: ran %v, want %q", tc.name, runner.calls, tc.want)
+		}
+	}
+}
+
+func TestGetOperationsHidesUnavailable(t *testing.T) {
+	t.Parallel()
+
+	runner := &fakeRunner{
+		installed: map[string]bool{"loginctl": true, "systemctl": true, "busctl": true, "gsettings": true},
+		outputs: map[string]string{
+			logindCall("CanSuspend"):   "s \"yes\"\n",
+			logindCall("CanHibernate"): "s \"na\"\n",
+			logindCall("CanReboot"):    "s \"no\"\n",
+			logindCall("CanPowerOff"):  "s \"yes\"\n",
+			"gsettings writable org.gnome.desktop.interface color-scheme": "false\n",
+		},
+	}
+	got := operationNames(getOperations(runner, ""))
+	want := []string{"Lock Screen", "Suspend", "Power Off"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("operations = %v, want %v", got, want)
+	}
+
+	if operations := getOperations(&fakeRunner{}, "3"); len(operations) != 0 {
+		t.Fatalf("expected no operations without tools, got %v", operationNames(operations))
+	}
+}
@@ -0,0 +1,145 @@
+// Package process lists the running processes and signals them
+package process
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+	"watools/pkg/models"
+)
+
+// Process is a running process
+type Process struct {
+	PID         int
+	Name        string
+	CommandLine string
+	// CPUPercent is the share of one core used since the previous sample, it exceeds 100 for multithreaded processes
+	CPUPercent  float64
+	MemoryBytes uint64
+	// Ports are the TCP ports the process listens on, sorted
+	Ports []int
+	// StartedAt tells the process apart from a later one reusing its PID, it is zero when it could not be read
+	StartedAt time.Time
+
+	// cpuTime is the CPU time used since the start, cpuMeasured is false when the platform reports CPUPercent itself
+	cpuTime     time.Duration
+	cpuMeasured bool
+}
+
+type cpuSample struct {
+	cpuTime   time.Duration
+	startedAt time.Time
+}
+
+// Sampler lists processes, the CPU usage is measured between consecutive calls
+type Sampler struct {
+	mu        sync.Mutex
+	previous  map[int]cpuSample
+	sampledAt time.Time
+}
+
+func NewSampler() *Sampler {
+	return &Sampler{previous: make(map[int]cpuSample)}
+}
+
+// List returns the running processes, the processes of other users may lack their command line and ports
+func (s *Sampler) List() ([]Process, error) {
+	processes, err := listProcesses()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current := make(map[int]cpuSample, len(processes))
+	for i := range processes {
+		p := &processes[i]
+		if !p.cpuMeasured || p.StartedAt.IsZero() {
+			continue
+		}
+		sample := cpuSample{cpuTime: p.cpuTime, startedAt: p.StartedAt}
+		previous, seen := s.previous[p.PID]
+		p.CPUPercent = cpuPercent(previous, seen, sample, s.sampledAt, now)
+		current[p.PID] = sample
+	}
+	s.previous = current
+	s.sampledAt = now
+	return processes, nil
+}
+
+// cpuPercent measures the usage since the previous sample, or since the start for new processes and reused PIDs
+func cpuPercent(previous cpuSample, seen bool, current cpuSample, previousAt time.Time, now time.Time) float64 {
+	used, elapsed := current.cpuTime, now.Sub(current.startedAt)
+	if seen && !previousAt.IsZero() && previous.startedAt.Equal(current.startedAt) {
+		used, elapsed = current.cpuTime-previous.cpuTime, now.Sub(previousAt)
+	}
+	if used < 0 || elapsed <= 0 {
+		return 0
+	}
+	return float64(used) / float64(elapsed) * 100
+}
+
+// IsProtected reports whether pid must never be signalled, which is the launcher itself and init
+func IsProtected(pid int) bool {
+	return pid <= 1 || pid == os.Getpid()
+}
+
+// Signal terminates or kills a process, protected processes are refused.
+// startedAt is the StartedAt the process was listed with, the signal is refused when the PID was reused since.
+func Signal(pid int, startedAt time.Time, action models.ProcessAction) error {
+	if IsProtected(pid) {
+		return fmt.Errorf("refusing to %s process %d", action, pid)
+	}
+	if err := checkStartTime(pid, startedAt); err != nil {
+		return fmt.Errorf("refusing to %s process %d: %w", action, pid, err)
+	}
+	switch action {
+	case models.ProcessActionTerminate:
+		return terminate(pid)
+	case models.ProcessActionKill:
+		p, err := os.FindProcess(pid)
+		if err != nil {
+			return fmt.Errorf("failed to find process %d: %w", pid, err)
+		}
+		if err := p.Kill(); err != nil {
+			return fmt.Errorf("failed to kill process %d: %w", pid, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown process action '%s'", action)
+	}
+}
+
+// checkStartTime reports an error unless pid still belongs to the process that started at startedAt
+func checkStartTime(pid int, startedAt time.Time) error {
+	if startedAt.IsZero() {
+		return fmt.Errorf("its start time is unknown")
+	}
+	current, err := startTime(pid)
+	if err != nil {
+		return fmt.Errorf("it is gone: %w", err)
+	}
+	// the boot time on Linux and ps on macOS have a resolution of one second
+	if difference := current.Sub(startedAt).Abs(); difference >= time.Second {
+		return fmt.Errorf("it exited and its PID was reused")
+	}
+	return nil
+}
+
+// sortedPorts removes duplicates, a process listening on IPv4 and IPv6 reports a port twice
+func sortedPorts(ports []int) []int {
+	if len(ports) == 0 {
+		return nil
+	}
+	sort.Ints(ports)
+	unique := ports[:1]
+	for _, port := range ports[1:] {
+		if port != unique[len(unique)-1] {
+			unique = append(unique, port)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,126 @@
+package process
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// lstartLayout is the format of the lstart column of ps in the C locale, such as "Mon Oct 17 09:05:00 2026"
+const lstartLayout = "Mon Jan 2 15:04:05 2006"
+
+// lstartFields is how many words the lstart column has
+const lstartFields = 5
+
+func listProcesses() ([]Process, error) {
+	// the executable path is the last column so that spaces in it stay intact
+	output, err := psCommand("-axww", "-o", "pid=,pcpu=,rss=,lstart=,comm=").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+	commandLines := listCommandLines()
+	ports := listeningPorts()
+
+	var processes []Process
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4+lstartFields {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		cpu, _ := strconv.ParseFloat(fields[1], 64)
+		rssKiB, _ := strconv.ParseUint(fields[2], 10, 64)
+		startedAt, _ := parseLstart(fields[3 : 3+lstartFields])
+		executable := strings.Join(fields[3+lstartFields:], " ")
+		processes = append(processes, Process{
+			PID:         pid,
+			Name:        filepath.Base(executable),
+			CommandLine: commandLines[pid],
+			CPUPercent:  cpu,
+			MemoryBytes: rssKiB * 1024,
+			Ports:       sortedPorts(ports[pid]),
+			StartedAt:   startedAt,
+		})
+	}
+	return processes, nil
+}
+
+// startTime asks ps when pid started, like listProcesses
+func startTime(pid int) (time.Time, error) {
+	output, err := psCommand("-o", "lstart=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read the start time of process %d: %w", pid, err)
+	}
+	return parseLstart(strings.Fields(string(output)))
+}
+
+// psCommand runs ps in the C locale, lstart is printed in the format of the locale otherwise
+func psCommand(args ...string) *exec.Cmd {
+	cmd := exec.Command("ps", args...)
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+	return cmd
+}
+
+func parseLstart(fields []string) (time.Time, error) {
+	return time.ParseInLocation(lstartLayout, strings.Join(fields, " "), time.Local)
+}
+
+func listCommandLines() map[int]string {
+	commandLines := make(map[int]string)
+	output, err := exec.Command("ps", "-axww", "-o", "pid=,args=").Output()
+	if err != nil {
+		return commandLines
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		pid, args, found := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		if !found {
+			continue
+		}
+		if value, err := strconv.Atoi(pid); err == nil {
+			commandLines[value] = strings.TrimSpace(args)
+		}
+	}
+	return commandLines
+}
+
+// listeningPorts asks lsof for the listening TCP sockets, keyed by PID
+func listeningPorts() map[int][]int {
+	ports := make(map[int][]int)
+	// lsof exits with 1 when nothing matched, the output is still valid
+	output, _ := exec.Command("lsof", "-nP", "-iTCP", "-sTCP:LISTEN", "-Fpn").Output()
+	pid := 0
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "p"):
+			pid, _ = strconv.Atoi(line[1:])
+		case strings.HasPrefix(line, "n"):
+			// the name is "*:3000", "127.0.0.1:5432" or "[::1]:8080"
+			index := strings.LastIndexByte(line, ':')
+			if port, err := strconv.Atoi(line[index+1:]); index > 0 && err == nil && pid > 0 {
+				ports[pid] = append(ports[pid], port)
+			}
+		}
+	}
+	return ports
+}
+
+func terminate(pid int) error {
+	if err := syscall.Kill(pid, syscall.SIGTERM); err != nil {
+		return fmt.Errorf("failed to terminate process %d: %w", pid, err)
+	}
+	return nil
+}
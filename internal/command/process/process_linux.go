@@ -0,0 +1,222 @@
+package process
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// clockTicks is USER_HZ, the unit of the times in /proc, which Linux fixes at 100 for user space
+const clockTicks = 100
+
+// tcpListen is the state of a listening socket in /proc/net/tcp
+const tcpListen = "0A"
+
+func listProcesses() ([]Process, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read /proc: %w", err)
+	}
+	bootTime, err := readBootTime()
+	if err != nil {
+		return nil, err
+	}
+	sockets := listeningSockets()
+	pageSize := uint64(os.Getpagesize())
+
+	processes := make([]Process, 0, len(entries))
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		p, ok := readProcess(pid, bootTime, pageSize)
+		if !ok {
+			continue
+		}
+		p.Ports = socketPorts(pid, sockets)
+		processes = append(processes, p)
+	}
+	return processes, nil
+}
+
+// readProcess reads one process, kernel threads and processes that exited meanwhile are skipped
+func readProcess(pid int, bootTime time.Time, pageSize uint64) (Process, bool) {
+	dir := filepath.Join("/proc", strconv.Itoa(pid))
+	stat, err := os.ReadFile(filepath.Join(dir, "stat"))
+	if err != nil {
+		return Process{}, false
+	}
+	fields, ok := parseStat(stat)
+	if !ok {
+		return Process{}, false
+	}
+	cmdline, err := os.ReadFile(filepath.Join(dir, "cmdline"))
+	if err != nil || len(cmdline) == 0 {
+		// kernel threads have no command line
+		return Process{}, false
+	}
+	args := strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")
+	return Process{
+		PID:         pid,
+		Name:        processName(fields.comm, args[0]),
+		CommandLine: strings.Join(args, " "),
+		MemoryBytes: fields.rssPages * pageSize,
+		StartedAt:   bootTime.Add(time.Duration(fields.startTicks) * time.Second / clockTicks),
+		cpuTime:     time.Duration(fields.cpuTicks) * time.Second / clockTicks,
+		cpuMeasured: true,
+	}, true
+}
+
+// startTime reads when pid started, like readProcess
+func startTime(pid int) (time.Time, error) {
+	stat, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	fields, ok := parseStat(stat)
+	if !ok {
+		return time.Time{}, fmt.Errorf("failed to parse /proc/%d/stat", pid)
+	}
+	bootTime, err := readBootTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return bootTime.Add(time.Duration(fields.startTicks) * time.Second / clockTicks), nil
+}
+
+type statFields struct {
+	comm       string
+	cpuTicks   uint64
+	startTicks uint64
+	rssPages   uint64
+}
+
+// parseStat reads /proc/<pid>/stat, the name is in parentheses and may itself contain spaces and parentheses
+func parseStat(data []byte) (statFields, bool) {
+	open := bytes.IndexByte(data, '(')
+	closing := bytes.LastIndexByte(data, ')')
+	if open < 0 || closing < open {
+		return statFields{}, false
+	}
+	// fields[0] is the state, the third field of the file
+	fields := strings.Fields(string(data[closing+1:]))
+	if len(fields) < 22 {
+		return statFields{}, false
+	}
+	numbers := make(map[int]uint64, 4)
+	for _, index := range []int{14, 15, 22, 24} {
+		value, err := strconv.ParseUint(fields[index-3], 10, 64)
+		if err != nil {
+			return statFields{}, false
+		}
+		numbers[index] = value
+	}
+	return statFields{
+		comm:       string(data[open+1 : closing]),
+		cpuTicks:   numbers[14] + numbers[15],
+		startTicks: numbers[22],
+		rssPages:   numbers[24],
+	}, true
+}
+
+// processName prefers the executable name over comm, which the kernel truncates to 15 bytes
+func processName(comm string, executable string) string {
+	base := filepath.Base(strings.Fields(executable + " ")[0])
+	if len(comm) == 15 && strings.HasPrefix(base, comm) {
+		return base
+	}
+	return comm
+}
+
+func readBootTime() (time.Time, error) {
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read /proc/stat: %w", err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if value, found := strings.CutPrefix(scanner.Text(), "btime "); found {
+			seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("failed to parse boot time: %w", err)
+			}
+			return time.Unix(seconds, 0), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("boot time missing from /proc/stat")
+}
+
+// listeningSockets maps the inodes of listening TCP sockets to their ports
+func listeningSockets() map[string]int {
+	sockets := make(map[string]int)
+	for _, name := range []string{"/proc/net/tcp", "/proc/net/tcp6"} {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			continue
+		}
+		for inode, port := range parseNetTCP(data) {
+			sockets[inode] = port
+		}
+	}
+	return sockets
+}
+
+// parseNetTCP reads the listening sockets of /proc/net/tcp or tcp6, keyed by inode
+func parseNetTCP(data []byte) map[string]int {
+	sockets := make(map[string]int)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Scan() // header
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 || fields[3] != tcpListen {
+			continue
+		}
+		_, hexPort, found := strings.Cut(fields[1], ":")
+		if !found {
+			continue
+		}
+		port, err := strconv.ParseUint(hexPort, 16, 16)
+		if err != nil {
+			continue
+		}
+		sockets[fields[9]] = int(port)
+	}
+	return sockets
+}
+
+// socketPorts returns the ports of the listening sockets pid holds, only readable for processes of the same user
+func socketPorts(pid int, sockets map[string]int) []int {
+	if len(sockets) == 0 {
+		return nil
+	}
+	fdDir := filepath.Join("/proc", strconv.Itoa(pid), "fd")
+	entries, err := os.ReadDir(fdDir)
+	if err != nil {
+		return nil
+	}
+	var ports []int
+	for _, entry := range entries {
+		target, err := os.Readlink(filepath.Join(fdDir, entry.Name()))
+		if err != nil || !strings.HasPrefix(target, "socket:[") {
+			continue
+		}
+		if port, ok := sockets[strings.TrimSuffix(strings.TrimPrefix(target, "socket:["), "]")]; ok {
+			ports = append(ports, port)
+		}
+	}
+	return sortedPorts(ports)
+}
+
+func terminate(pid int) error {
+	if err := syscall.Kill(pid, syscall.SIGTERM); err != nil {
+		return fmt.Errorf("failed to terminate process %d: %w", pid, err)
+	}
+	return nil
+}
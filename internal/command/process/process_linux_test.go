@@ -0,0 +1,74 @@
+package process
+
+import (
+	"net"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseStat(t *testing.T) {
+	t.Parallel()
+
+	stat := "4242 (tmux: server (1)) S 1 4242 4242 0 -1 4194368 1 0 0 0 120 30 0 0 20 0 1 0 5000 1000000 256 18446744073709551615"
+	fields, ok := parseStat([]byte(stat))
+	if !ok {
+		t.Fatal("expected stat to parse")
+	}
+	if fields.comm != "tmux: server (1)" || fields.cpuTicks != 150 || fields.startTicks != 5000 || fields.rssPages != 256 {
+		t.Fatalf("unexpected stat fields %+v", fields)
+	}
+}
+
+func TestParseNetTCP(t *testing.T) {
+	t.Parallel()
+
+	data := `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:0BB8 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 51234 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:9C40 0100007F:0BB8 01 00000000:00000000 00:00000000 00000000  1000        0 51299 1 0000000000000000 20 4 30 10 -1
+`
+	sockets := parseNetTCP([]byte(data))
+	if len(sockets) != 1 || sockets["51234"] != 3000 {
+		t.Fatalf("expected only the listening socket on port 3000, got %v", sockets)
+	}
+}
+
+func TestProcessName(t *testing.T) {
+	t.Parallel()
+
+	if got := processName("gnome-shell-cal", "/usr/libexec/gnome-shell-calendar-server"); got != "gnome-shell-calendar-server" {
+		t.Fatalf("expected the untruncated name, got %q", got)
+	}
+	if got := processName("node", "next-server (v14)"); got != "node" {
+		t.Fatalf("expected comm for rewritten arguments, got %q", got)
+	}
+}
+
+func TestListFindsListeningPort(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	processes, err := NewSampler().List()
+	if err != nil {
+		t.Fatalf("failed to list processes: %v", err)
+	}
+	for _, p := range processes {
+		if p.PID != os.Getpid() {
+			continue
+		}
+		if !strings.Contains(p.CommandLine, "process.test") || p.MemoryBytes == 0 {
+			t.Fatalf("unexpected own process %+v", p)
+		}
+		for _, listening := range p.Ports {
+			if listening == port {
+				return
+			}
+		}
+		t.Fatalf("expected port %d in %v", port, p.Ports)
+	}
+	t.Fatal("expected the test process to be listed")
+}
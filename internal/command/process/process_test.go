@@ -0,0 +1,81 @@
+package process
+
+import (
+	"os"
+	"os/exec"
+	"reflect"
+	"testing"
+	"time"
+	"watools/pkg/models"
+)
+
+func TestCPUPercent(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	previousAt := start.Add(time.Minute)
+	now := previousAt.Add(10 * time.Second)
+	previous := cpuSample{cpuTime: 2 * time.Second, startedAt: start}
+
+	tests := []struct {
+		name    string
+		seen    bool
+		current cpuSample
+		want    float64
+	}{
+		{"since previous sample", true, cpuSample{cpuTime: 7 * time.Second, startedAt: start}, 50},
+		{"new process since start", false, cpuSample{cpuTime: 7 * time.Second, startedAt: start}, 10},
+		{"reused pid since start", true, cpuSample{cpuTime: time.Second, startedAt: previousAt}, 10},
+	}
+	for _, tt := range tests {
+		if got := cpuPercent(previous, tt.seen, tt.current, previousAt, now); got != tt.want {
+			t.Errorf("%s: expected %v%%, got %v%%", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestSignalRefusesProtectedProcesses(t *testing.T) {
+	t.Parallel()
+
+	for _, pid := range []int{0, 1, os.Getpid()} {
+		if !IsProtected(pid) {
+			t.Errorf("expected pid %d to be protected", pid)
+		}
+		if err := Signal(pid, time.Now(), models.ProcessActionKill); err == nil {
+			t.Errorf("expected killing pid %d to be refused", pid)
+		}
+	}
+}
+
+func TestSignalRefusesReusedPID(t *testing.T) {
+	t.Parallel()
+
+	cmd := exec.Command("sleep", "30")
+	if err := cmd.Start(); err != nil {
+		t.Skipf("cannot start sleep: %v", err)
+	}
+	defer cmd.Process.Kill()
+	startedAt, err := startTime(cmd.Process.Pid)
+	if err != nil {
+		t.Fatalf("failed to read the start time: %v", err)
+	}
+
+	// the process listed before the PID was reused started earlier
+	if err := Signal(cmd.Process.Pid, startedAt.Add(-time.Hour), models.ProcessActionKill); err == nil {
+		t.Fatal("expected a signal to a reused PID to be refused")
+	}
+	if err := Signal(cmd.Process.Pid, time.Time{}, models.ProcessActionKill); err == nil {
+		t.Fatal("expected a signal without a start time to be refused")
+	}
+	if err := Signal(cmd.Process.Pid, startedAt, models.ProcessActionKill); err != nil {
+		t.Fatalf("failed to kill the listed process: %v", err)
+	}
+}
+
+func TestSortedPorts(t *testing.T) {
+	t.Parallel()
+
+	if got := sortedPorts([]int{8080, 3000, 8080, 22}); !reflect.DeepEqual(got, []int{22, 3000, 8080}) {
+		t.Fatalf("unexpected ports %v", got)
+	}
+}
@@ -0,0 +1,169 @@
+package process
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	tcpTableOwnerPIDListener = 3
+	tcp4RowSize              = 24
+	tcp6RowSize              = 56
+)
+
+var (
+	modKernel32                 = windows.NewLazySystemDLL("kernel32.dll")
+	modIphlpapi                 = windows.NewLazySystemDLL("iphlpapi.dll")
+	procK32GetProcessMemoryInfo = modKernel32.NewProc("K32GetProcessMemoryInfo")
+	procGetExtendedTcpTable     = modIphlpapi.NewProc("GetExtendedTcpTable")
+)
+
+// processMemoryCounters is PROCESS_MEMORY_COUNTERS
+type processMemoryCounters struct {
+	cb                         uint32
+	PageFaultCount             uint32
+	PeakWorkingSetSize         uintptr
+	WorkingSetSize             uintptr
+	QuotaPeakPagedPoolUsage    uintptr
+	QuotaPagedPoolUsage        uintptr
+	QuotaPeakNonPagedPoolUsage uintptr
+	QuotaNonPagedPoolUsage     uintptr
+	PagefileUsage              uintptr
+	PeakPagefileUsage          uintptr
+}
+
+func listProcesses() ([]Process, error) {
+	snapshot, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot processes: %w", err)
+	}
+	defer windows.CloseHandle(snapshot)
+	ports := listeningPorts()
+
+	var processes []Process
+	var entry windows.ProcessEntry32
+	entry.Size = uint32(unsafe.Sizeof(entry))
+	for err = windows.Process32First(snapshot, &entry); err == nil; err = windows.Process32Next(snapshot, &entry) {
+		p := Process{
+			PID:  int(entry.ProcessID),
+			Name: windows.UTF16ToString(entry.ExeFile[:]),
+		}
+		readProcessDetails(&p)
+		p.Ports = sortedPorts(ports[p.PID])
+		processes = append(processes, p)
+	}
+	return processes, nil
+}
+
+// readProcessDetails fills what a limited handle can query, protected processes keep only their name
+func readProcessDetails(p *Process) {
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(p.PID))
+	if err != nil {
+		return
+	}
+	defer windows.CloseHandle(handle)
+
+	buffer := make([]uint16, windows.MAX_LONG_PATH)
+	size := uint32(len(buffer))
+	if err := windows.QueryFullProcessImageName(handle, 0, &buffer[0], &size); err == nil {
+		// reading the command line of another process needs its PEB, the image path is the useful part
+		p.CommandLine = windows.UTF16ToString(buffer[:size])
+		if p.Name == "" {
+			p.Name = filepath.Base(p.CommandLine)
+		}
+	}
+	var creation, exit, kernel, user windows.Filetime
+	if err := windows.GetProcessTimes(handle, &creation, &exit, &kernel, &user); err == nil {
+		p.StartedAt = time.Unix(0, creation.Nanoseconds())
+		p.cpuTime = filetimeDuration(kernel) + filetimeDuration(user)
+		p.cpuMeasured = true
+	}
+	var counters processMemoryCounters
+	counters.cb = uint32(unsafe.Sizeof(counters))
+	if ret, _, _ := procK32GetProcessMemoryInfo.Call(uintptr(handle), uintptr(unsafe.Pointer(&counters)), uintptr(counters.cb)); ret != 0 {
+		p.MemoryBytes = uint64(counters.WorkingSetSize)
+	}
+}
+
+// startTime reads the creation time of pid, like readProcessDetails
+func startTime(pid int) (time.Time, error) {
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer windows.CloseHandle(handle)
+	var creation, exit, kernel, user windows.Filetime
+	if err := windows.GetProcessTimes(handle, &creation, &exit, &kernel, &user); err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, creation.Nanoseconds()), nil
+}
+
+// filetimeDuration converts a FILETIME holding a duration in 100ns units
+func filetimeDuration(ft windows.Filetime) time.Duration {
+	return time.Duration(uint64(ft.HighDateTime)<<32|uint64(ft.LowDateTime)) * 100
+}
+
+// listeningPorts reads the listening TCP sockets of both address families, keyed by PID
+func listeningPorts() map[int][]int {
+	ports := make(map[int][]int)
+	for _, table := range []struct {
+		family  uint32
+		rowSize int
+		// portOffset and pidOffset locate the fields in a MIB_TCPROW_OWNER_PID or MIB_TCP6ROW_OWNER_PID
+		portOffset int
+		pidOffset  int
+	}{
+		{family: windows.AF_INET, rowSize: tcp4RowSize, portOffset: 8, pidOffset: 20},
+		{family: windows.AF_INET6, rowSize: tcp6RowSize, portOffset: 20, pidOffset: 52},
+	} {
+		data, err := tcpTable(table.family)
+		if err != nil || len(data) < 4 {
+			continue
+		}
+		count := int(binary.LittleEndian.Uint32(data))
+		for i := 0; i < count; i++ {
+			offset := 4 + i*table.rowSize
+			if offset+table.rowSize > len(data) {
+				break
+			}
+			row := data[offset : offset+table.rowSize]
+			// the port is stored in network byte order in the low 16 bits
+			port := int(row[table.portOffset])<<8 | int(row[table.portOffset+1])
+			pid := int(binary.LittleEndian.Uint32(row[table.pidOffset:]))
+			ports[pid] = append(ports[pid], port)
+		}
+	}
+	return ports
+}
+
+func tcpTable(family uint32) ([]byte, error) {
+	size := uint32(0)
+	procGetExtendedTcpTable.Call(0, uintptr(unsafe.Pointer(&size)), 0, uintptr(family), tcpTableOwnerPIDListener, 0)
+	if size == 0 {
+		return nil, fmt.Errorf("failed to size the TCP table")
+	}
+	// the table may grow between the calls
+	size += 1024
+	data := make([]byte, size)
+	ret, _, _ := procGetExtendedTcpTable.Call(uintptr(unsafe.Pointer(&data[0])), uintptr(unsafe.Pointer(&size)), 0, uintptr(family), tcpTableOwnerPIDListener, 0)
+	if ret != 0 {
+		return nil, fmt.Errorf("failed to read the TCP table: %w", windows.Errno(ret))
+	}
+	return data[:size], nil
+}
+
+// terminate asks the windows of the process to close, console programs can only be killed
+func terminate(pid int) error {
+	if output, err := exec.Command("taskkill", "/PID", strconv.Itoa(pid)).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to terminate process %d: %s", pid, string(output))
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package project
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"watools/pkg/logger"
+)
+
+const (
+	jetbrainsRecentProjectsFile = "recentProjects.xml"
+	// jetbrainsHomeFile is written to the system directory of an IDE and holds its install directory
+	jetbrainsHomeFile = ".home"
+	jetbrainsUserHome = "$USER_HOME$"
+)
+
+// jetbrainsVendors are the directories below the config root holding one directory per IDE version, Android Studio is built by Google
+var jetbrainsVendors = []string{"JetBrains", "Google"}
+
+// jetbrainsConfigPattern splits a config directory name such as "GoLand2024.2" or "PyCharmCE2023.3" into product and version
+var jetbrainsConfigPattern = regexp.MustCompile(`^([A-Za-z]+?)(\d{4}\.\d+)$`)
+
+// jetbrainsProduct is an IDE, exe is the launcher name without the platform suffix
+type jetbrainsProduct struct {
+	name   string
+	exe    string
+	macApp string
+}
+
+var jetbrainsProducts = map[string]jetbrainsProduct{
+	"IntelliJIdea":  {name: "IntelliJ IDEA", exe: "idea", macApp: "IntelliJ IDEA"},
+	"IdeaIC":        {name: "IntelliJ IDEA CE", exe: "idea", macApp: "IntelliJ IDEA CE"},
+	"PyCharm":       {name: "PyCharm", exe: "pycharm", macApp: "PyCharm"},
+	"PyCharmCE":     {name: "PyCharm CE", exe: "pycharm", macApp: "PyCharm CE"},
+	"GoLand":        {name: "GoLand", exe: "goland", macApp: "GoLand"},
+	"WebStorm":      {name: "WebStorm", exe: "webstorm", macApp: "WebStorm"},
+	"CLion":         {name: "CLion", exe: "clion", macApp: "CLion"},
+	"Rider":         {name: "Rider", exe: "rider", macApp: "Rider"},
+	"PhpStorm":      {name: "PhpStorm", exe: "phpstorm", macApp: "PhpStorm"},
+	"RubyMine":      {name: "RubyMine", exe: "rubymine", macApp: "RubyMine"},
+	"DataGrip":      {name: "DataGrip", exe: "datagrip", macApp: "DataGrip"},
+	"DataSpell":     {name: "DataSpell", exe: "dataspell", macApp: "DataSpell"},
+	"RustRover":     {name: "RustRover", exe: "rustrover", macApp: "RustRover"},
+	"AndroidStudio": {name: "Android Studio", exe: "studio", macApp: "Android Studio"},
+}
+
+// jetbrainsConfig is the config directory of one IDE version
+type jetbrainsConfig struct {
+	product   jetbrainsProduct
+	configDir string
+	// systemDir holds the caches of the same version, and the .home file
+	systemDir string
+}
+
+func (c jetbrainsConfig) recentProjectsPath() string {
+	return filepath.Join(c.configDir, "options", jetbrainsRecentProjectsFile)
+}
+
+// findJetBrainsConfigs returns the config directories of the installed IDE versions that have recent projects
+func findJetBrainsConfigs() []jetbrainsConfig {
+	if jetbrainsConfigRoot() == "" {
+		return nil
+	}
+	var configs []jetbrainsConfig
+	for _, vendor := range jetbrainsVendors {
+		entries, err := os.ReadDir(filepath.Join(jetbrainsConfigRoot(), vendor))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			match := jetbrainsConfigPattern.FindStringSubmatch(entry.Name())
+			if !entry.IsDir() || match == nil {
+				continue
+			}
+			product, ok := jetbrainsProducts[match[1]]
+			if !ok {
+				continue
+			}
+			config := jetbrainsConfig{
+				product:   product,
+				configDir: filepath.Join(jetbrainsConfigRoot(), vendor, entry.Name()),
+				systemDir: filepath.Join(jetbrainsSystemRoot(), vendor, entry.Name()),
+			}
+			if _, err := os.Stat(config.recentProjectsPath()); err == nil {
+				configs = append(configs, config)
+			}
+		}
+	}
+	return configs
+}
+
+func readJetBrains(config jetbrainsConfig) []Project {
+	data, err := os.ReadFile(config.recentProjectsPath())
+	if err != nil {
+		logger.Error(err, fmt.Sprintf("Failed to read recent projects of %s", config.product.name))
+		return nil
+	}
+	homeDir, _ := os.UserHomeDir()
+	recent, err := parseRecentProjects(data, homeDir)
+	if err != nil {
+		logger.Error(err, fmt.Sprintf("Failed to parse %s", config.recentProjectsPath()))
+		return nil
+	}
+	installDir := ""
+	if home, err := os.ReadFile(filepath.Join(config.systemDir, jetbrainsHomeFile)); err == nil {
+		installDir = strings.TrimSpace(string(home))
+	}
+	launcher := jetbrainsLauncher(config.product, installDir)
+	for i := range recent {
+		recent[i].IDE = config.product.name
+		recent[i].Launcher = launcher
+	}
+	return recent
+}
+
+type recentProjectsDocument struct {
+	Components []struct {
+		Name    string            `xml:"name,attr"`
+		Options []jetbrainsOption `xml:"option"`
+	} `xml:"component"`
+}
+
+type jetbrainsOption struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+	Map   *struct {
+		Entries []struct {
+			Key  string `xml:"key,attr"`
+			Meta struct {
+				Options []jetbrainsOption `xml:"option"`
+			} `xml:"value>RecentProjectMetaInfo"`
+		} `xml:"entry"`
+	} `xml:"map"`
+	List *struct {
+		Options []jetbrainsOption `xml:"option"`
+	} `xml:"list"`
+}
+
+// parseRecentProjects returns the projects of a recentProjects.xml, dated by their last activation.
+// Newer IDEs keep the projects in the additionalInfo map, older ones only in the recentPaths list.
+func parseRecentProjects(data []byte, homeDir string) ([]Project, error) {
+	var document recentProjectsDocument
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	var projects []Project
+	add := func(path string, openedAt time.Time) {
+		path = strings.ReplaceAll(path, jetbrainsUserHome, filepath.ToSlash(homeDir))
+		if path == "" || strings.Contains(path, "$") {
+			// other macros point into the IDE itself
+			return
+		}
+		projects = append(projects, Project{Path: filepath.FromSlash(path), OpenedAt: openedAt})
+	}
+	for _, component := range document.Components {
+		if !strings.HasSuffix(component.Name, "ProjectsManager") {
+			continue
+		}
+		for _, option := range component.Options {
+			switch {
+			case option.Name == "additionalInfo" && option.Map != nil:
+				for _, entry := range option.Map.Entries {
+					add(entry.Key, jetbrainsOpenedAt(entry.Meta.Options))
+				}
+			case option.Name == "recentPaths" && option.List != nil:
+				for _, path := range option.List.Options {
+					add(path.Value, time.Time{})
+				}
+			}
+		}
+	}
+	return projects, nil
+}
+
+// jetbrainsOpenedAt returns when a project was last activated or opened, the IDE stores milliseconds
+func jetbrainsOpenedAt(options []jetbrainsOption) time.Time {
+	var latest int64
+	for _, option := range options {
+		if option.Name != "activationTimestamp" && option.Name != "projectOpenTimestamp" {
+			continue
+		}
+		if millis, err := strconv.ParseInt(option.Value, 10, 64); err == nil && millis > latest {
+			latest = millis
+		}
+	}
+	if latest == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(latest)
+}
@@ -0,0 +1,155 @@
+// Package project reads the recent projects of VS Code and the JetBrains IDEs from their state files.
+// The files are only read, databases are copied before they are opened so a running IDE never sees a lock from the launcher.
+package project
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxProjects bounds the list, IDEs remember far more projects than are useful in the launcher
+const maxProjects = 200
+
+// Project is a folder or workspace file recently opened in an IDE
+type Project struct {
+	Path string
+	// Name is the folder name, or the workspace name for a VS Code workspace file
+	Name string
+	// IDE is the name of the IDE that opened the project, such as "Visual Studio Code" or "GoLand"
+	IDE string
+	// Launcher is the application that opens the project, empty when the IDE was not found.
+	// It is an executable, a .app bundle or an application name on macOS.
+	Launcher string
+	// OpenedAt is zero when the IDE does not record when the project was opened
+	OpenedAt time.Time
+}
+
+// Load returns the recent projects of every IDE found, newest first, unique by path and only those that still exist
+func Load() []Project {
+	var projects []Project
+	for _, variant := range vscodeVariants {
+		projects = append(projects, readVSCode(variant)...)
+	}
+	for _, config := range findJetBrainsConfigs() {
+		projects = append(projects, readJetBrains(config)...)
+	}
+	return Normalize(projects, Exists)
+}
+
+// Normalize keeps the most recently opened entry of each path, drops the paths exists rejects
+// and returns at most maxProjects, newest first. Entries without a time keep their order after the others.
+func Normalize(projects []Project, exists func(path string) bool) []Project {
+	byPath := make(map[string]int, len(projects))
+	result := make([]Project, 0, len(projects))
+	for _, project := range projects {
+		if project.Path == "" {
+			continue
+		}
+		project.Path = filepath.Clean(project.Path)
+		if project.Name == "" {
+			project.Name = filepath.Base(project.Path)
+		}
+		key := pathKey(project.Path)
+		if index, seen := byPath[key]; seen {
+			if project.OpenedAt.After(result[index].OpenedAt) {
+				result[index] = project
+			}
+			continue
+		}
+		byPath[key] = len(result)
+		result = append(result, project)
+	}
+
+	existing := result[:0]
+	for _, project := range result {
+		if exists(project.Path) {
+			existing = append(existing, project)
+		}
+	}
+	sort.SliceStable(existing, func(i, j int) bool {
+		return existing[i].OpenedAt.After(existing[j].OpenedAt)
+	})
+	if len(existing) > maxProjects {
+		existing = existing[:maxProjects]
+	}
+	return existing
+}
+
+// Exists reports whether path is an existing file or directory
+func Exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// StoreDirs returns the directories holding the recent project stores of the IDEs
+func StoreDirs() []string {
+	var dirs []string
+	if vscodeConfigRoot() != "" {
+		for _, variant := range vscodeVariants {
+			dirs = append(dirs, vscodeStorageDir(variant))
+		}
+	}
+	for _, config := range findJetBrainsConfigs() {
+		dirs = append(dirs, filepath.Dir(config.recentProjectsPath()))
+	}
+	return dirs
+}
+
+// IsStoreFile reports whether path is a file whose change means the recent projects of an IDE changed
+func IsStoreFile(path string) bool {
+	switch filepath.Base(path) {
+	case vscodeStorageFile, vscodeStateFile, jetbrainsRecentProjectsFile:
+		return true
+	}
+	return false
+}
+
+var windowsDrivePattern = regexp.MustCompile(`^/[A-Za-z]:`)
+
+// fileURIPath returns the local path of a file:// URI, remote and other URIs are rejected
+func fileURIPath(uri string) (string, bool) {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "file" || parsed.Path == "" {
+		return "", false
+	}
+	if parsed.Host != "" && parsed.Host != "localhost" {
+		return "", false
+	}
+	path := parsed.Path
+	if windowsDrivePattern.MatchString(path) {
+		// file:///c:/Users/... names a drive on Windows
+		path = path[1:]
+	}
+	return filepath.FromSlash(path), true
+}
+
+// pathKey is how paths are compared, Windows and macOS file systems are case-insensitive by default
+func pathKey(path string) string {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		return strings.ToLower(path)
+	}
+	return path
+}
+
+// Editor returns the launcher of the IDE path was last opened with among projects,
+// or the first VS Code variant installed, empty when neither is found
+func Editor(path string, projects []Project) string {
+	key := pathKey(filepath.Clean(path))
+	for _, project := range projects {
+		if project.Launcher != "" && pathKey(filepath.Clean(project.Path)) == key {
+			return project.Launcher
+		}
+	}
+	for _, variant := range vscodeVariants {
+		if launcher := installedVSCode(variant); launcher != "" {
+			return launcher
+		}
+	}
+	return ""
+}
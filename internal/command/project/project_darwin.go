@@ -0,0 +1,56 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func applicationSupportDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, "Library", "Application Support")
+}
+
+func vscodeConfigRoot() string {
+	return applicationSupportDir()
+}
+
+func jetbrainsConfigRoot() string {
+	return applicationSupportDir()
+}
+
+func jetbrainsSystemRoot() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, "Library", "Caches")
+}
+
+// vscodeLauncher returns the application name, open -a finds the bundle wherever it is installed
+func vscodeLauncher(variant vscodeVariant) string {
+	return variant.macApp
+}
+
+// installedVSCode returns the bundle of variant in the system or the user Applications folder
+func installedVSCode(variant vscodeVariant) string {
+	homeDir, _ := os.UserHomeDir()
+	for _, dir := range []string{"/Applications", filepath.Join(homeDir, "Applications")} {
+		bundle := filepath.Join(dir, variant.macApp+".app")
+		if _, err := os.Stat(bundle); err == nil {
+			return bundle
+		}
+	}
+	return ""
+}
+
+// jetbrainsLauncher returns the bundle the install directory is in, such as "/Applications/GoLand.app/Contents",
+// or the application name when the IDE did not record it
+func jetbrainsLauncher(product jetbrainsProduct, installDir string) string {
+	for dir := installDir; dir != "" && dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if strings.HasSuffix(dir, ".app") {
+			if _, err := os.Stat(dir); err == nil {
+				return dir
+			}
+			break
+		}
+	}
+	return product.macApp
+}
@@ -0,0 +1,52 @@
+package project
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"watools/pkg/xdg"
+)
+
+func vscodeConfigRoot() string {
+	return xdg.ConfigHome()
+}
+
+func jetbrainsConfigRoot() string {
+	return xdg.ConfigHome()
+}
+
+func jetbrainsSystemRoot() string {
+	cacheDir, _ := os.UserCacheDir()
+	return cacheDir
+}
+
+func vscodeLauncher(variant vscodeVariant) string {
+	path, err := exec.LookPath(variant.command)
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+func installedVSCode(variant vscodeVariant) string {
+	return vscodeLauncher(variant)
+}
+
+// jetbrainsLauncher prefers the script of the install directory, then the Toolbox script and the command on PATH
+func jetbrainsLauncher(product jetbrainsProduct, installDir string) string {
+	if installDir != "" {
+		script := filepath.Join(installDir, "bin", product.exe+".sh")
+		if _, err := os.Stat(script); err == nil {
+			return script
+		}
+	}
+	toolboxScript := filepath.Join(xdg.DataHome(), "JetBrains", "Toolbox", "scripts", product.exe)
+	if _, err := os.Stat(toolboxScript); err == nil {
+		return toolboxScript
+	}
+	path, err := exec.LookPath(product.exe)
+	if err != nil {
+		return ""
+	}
+	return path
+}
@@ -0,0 +1,195 @@
+package project
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	api := filepath.FromSlash("/home/user/src/api")
+	projects := []Project{
+		{Path: api, IDE: "Visual Studio Code"},
+		{Path: filepath.FromSlash("/home/user/src/web"), IDE: "Visual Studio Code"},
+		{Path: filepath.FromSlash("/home/user/src/gone"), IDE: "GoLand", OpenedAt: day.Add(3 * time.Hour)},
+		{Path: api + string(filepath.Separator), IDE: "GoLand", OpenedAt: day},
+		{Path: filepath.FromSlash("/home/user/src/cli"), IDE: "GoLand", OpenedAt: day.Add(time.Hour)},
+		{Path: ""},
+	}
+	exists := func(path string) bool { return filepath.Base(path) != "gone" }
+
+	result := Normalize(projects, exists)
+	expected := []struct {
+		name string
+		ide  string
+	}{{"cli", "GoLand"}, {"api", "GoLand"}, {"web", "Visual Studio Code"}}
+	if len(result) != len(expected) {
+		t.Fatalf("expected %d projects, got %+v", len(expected), result)
+	}
+	for i, project := range result {
+		if project.Name != expected[i].name || project.IDE != expected[i].ide {
+			t.Fatalf("expected %v at %d, got %+v", expected[i], i, project)
+		}
+	}
+}
+
+func TestParseVSCodeRecent(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{
+  "entries": [
+    {"folderUri": "file:///home/user/src/api"},
+    {"fileUri": "file:///home/user/notes.md"},
+    {"workspace": {"id": "1f2e", "configPath": "file:///home/user/work/team.code-workspace"}},
+    {"folderUri": "vscode-remote://ssh-remote%2Bbox/srv/app", "label": "app [SSH: box]"},
+    {"folderUri": "file:///home/user/My%20Project"}
+  ],
+  "workspaces3": ["file:///home/user/legacy", {"id": "9a", "configURIPath": "file:///home/user/old.code-workspace"}]
+}`)
+	paths, err := parseVSCodeRecent(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"/home/user/src/api", "/home/user/work/team.code-workspace", "/home/user/My Project", "/home/user/legacy", "/home/user/old.code-workspace"}
+	if len(paths) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, paths)
+	}
+	for i := range expected {
+		if paths[i] != filepath.FromSlash(expected[i]) {
+			t.Fatalf("expected %v, got %v", expected, paths)
+		}
+	}
+}
+
+func TestFileURIPath(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"file:///home/user/a%23b":     "/home/user/a#b",
+		"file:///c%3A/Users/me/src":   "c:/Users/me/src",
+		"file://localhost/tmp/folder": "/tmp/folder",
+	}
+	for uri, expected := range cases {
+		path, ok := fileURIPath(uri)
+		if !ok || path != filepath.FromSlash(expected) {
+			t.Fatalf("expected %q for %s, got %q", expected, uri, path)
+		}
+	}
+	for _, uri := range []string{"vscode-remote://wsl%2Bubuntu/home/user", "file://server/share/src", "untitled:Untitled-1"} {
+		if _, ok := fileURIPath(uri); ok {
+			t.Fatalf("expected %s to be rejected", uri)
+		}
+	}
+}
+
+func TestReadVSCodeState(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), vscodeStateFile)
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`CREATE TABLE ItemTable (key TEXT UNIQUE ON CONFLICT REPLACE, value BLOB);
+INSERT INTO ItemTable (key, value) VALUES ('history.recentlyOpenedPathsList', '{"entries":[{"folderUri":"file:///home/user/src/api"}]}')`)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths, err := readVSCodeState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 1 || paths[0] != filepath.FromSlash("/home/user/src/api") {
+		t.Fatalf("expected the folder of the recent list, got %v", paths)
+	}
+}
+
+func TestParseRecentProjects(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`<application>
+  <component name="RecentProjectsManager">
+    <option name="additionalInfo">
+      <map>
+        <entry key="$USER_HOME$/GolandProjects/api">
+          <value>
+            <RecentProjectMetaInfo frameTitle="api" opened="true">
+              <option name="activationTimestamp" value="1709251200000" />
+              <option name="binFolder" value="$APPLICATION_HOME_DIR$/bin" />
+              <option name="projectOpenTimestamp" value="1709164800000" />
+            </RecentProjectMetaInfo>
+          </value>
+        </entry>
+        <entry key="/srv/tools">
+          <value>
+            <RecentProjectMetaInfo />
+          </value>
+        </entry>
+      </map>
+    </option>
+    <option name="lastOpenedProject" value="$USER_HOME$/GolandProjects/api" />
+  </component>
+  <component name="RecentDirectoryProjectsManager">
+    <option name="recentPaths">
+      <list>
+        <option value="$USER_HOME$/PycharmProjects/scripts" />
+        <option value="$APPLICATION_HOME_DIR$/plugins" />
+      </list>
+    </option>
+  </component>
+</application>`)
+	projects, err := parseRecentProjects(data, filepath.FromSlash("/home/user"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Project{
+		{Path: "/home/user/GolandProjects/api", OpenedAt: time.UnixMilli(1709251200000)},
+		{Path: "/srv/tools"},
+		{Path: "/home/user/PycharmProjects/scripts"},
+	}
+	if len(projects) != len(expected) {
+		t.Fatalf("expected %d projects, got %+v", len(expected), projects)
+	}
+	for i := range expected {
+		if projects[i].Path != filepath.FromSlash(expected[i].Path) || !projects[i].OpenedAt.Equal(expected[i].OpenedAt) {
+			t.Fatalf("expected %+v at %d, got %+v", expected[i], i, projects[i])
+		}
+	}
+}
+
+func TestJetBrainsConfigPattern(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{"GoLand2024.2": "GoLand", "PyCharmCE2023.3": "PyCharmCE", "AndroidStudio2023.1": "AndroidStudio"}
+	for name, product := range cases {
+		match := jetbrainsConfigPattern.FindStringSubmatch(name)
+		if match == nil || match[1] != product {
+			t.Fatalf("expected %s to be %s, got %v", name, product, match)
+		}
+	}
+	for _, name := range []string{"consentOptions", "Toolbox", "GoLand"} {
+		if jetbrainsConfigPattern.MatchString(name) {
+			t.Fatalf("expected %s not to be an IDE config", name)
+		}
+	}
+}
+
+func TestEditor(t *testing.T) {
+	t.Parallel()
+
+	repo := filepath.FromSlash("/home/user/code/api")
+	projects := []Project{
+		{Path: filepath.FromSlash("/home/user/code/web"), Launcher: "/usr/bin/code"},
+		{Path: filepath.FromSlash("/home/user/code/api/"), IDE: "GoLand"},
+		{Path: repo, Launcher: "/opt/goland/bin/goland.sh"},
+	}
+	if editor := Editor(repo, projects); editor != "/opt/goland/bin/goland.sh" {
+		t.Fatalf("expected the IDE the repository was opened with, got %q", editor)
+	}
+}
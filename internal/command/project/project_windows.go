@@ -0,0 +1,51 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func vscodeConfigRoot() string {
+	return os.Getenv("APPDATA")
+}
+
+func jetbrainsConfigRoot() string {
+	return os.Getenv("APPDATA")
+}
+
+func jetbrainsSystemRoot() string {
+	return os.Getenv("LOCALAPPDATA")
+}
+
+// vscodeLauncher looks for the user and the system installation
+func vscodeLauncher(variant vscodeVariant) string {
+	var roots []string
+	if local := os.Getenv("LOCALAPPDATA"); local != "" {
+		roots = append(roots, filepath.Join(local, "Programs"))
+	}
+	if programFiles := os.Getenv("ProgramFiles"); programFiles != "" {
+		roots = append(roots, programFiles)
+	}
+	for _, root := range roots {
+		path := filepath.Join(root, variant.windowsExe)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+func installedVSCode(variant vscodeVariant) string {
+	return vscodeLauncher(variant)
+}
+
+func jetbrainsLauncher(product jetbrainsProduct, installDir string) string {
+	if installDir == "" {
+		return ""
+	}
+	path := filepath.Join(installDir, "bin", product.exe+"64.exe")
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
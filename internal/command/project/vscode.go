@@ -0,0 +1,168 @@
+package project
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"watools/pkg/logger"
+	"watools/pkg/utils"
+)
+
+const (
+	vscodeStorageFile = "storage.json"
+	vscodeStateFile   = "state.vscdb"
+	// vscodeRecentKey holds the recently opened list in state.vscdb, VS Code before 1.64 kept it in storage.json
+	vscodeRecentKey       = "history.recentlyOpenedPathsList"
+	vscodeWorkspaceSuffix = ".code-workspace"
+)
+
+// vscodeVariant is an editor built from VS Code, they share the state file layout
+type vscodeVariant struct {
+	name string
+	// configDir is the name of the user data directory below the platform config directory
+	configDir string
+	// command is the CLI on Linux, macApp the application name on macOS and windowsExe the executable below the install directory on Windows
+	command    string
+	macApp     string
+	windowsExe string
+}
+
+var vscodeVariants = []vscodeVariant{
+	{name: "Visual Studio Code", configDir: "Code", command: "code", macApp: "Visual Studio Code", windowsExe: `Microsoft VS Code\Code.exe`},
+	{name: "Visual Studio Code - Insiders", configDir: "Code - Insiders", command: "code-insiders", macApp: "Visual Studio Code - Insiders", windowsExe: `Microsoft VS Code Insiders\Code - Insiders.exe`},
+	{name: "VSCodium", configDir: "VSCodium", command: "codium", macApp: "VSCodium", windowsExe: `VSCodium\VSCodium.exe`},
+	{name: "Cursor", configDir: "Cursor", command: "cursor", macApp: "Cursor", windowsExe: `cursor\Cursor.exe`},
+}
+
+// vscodeRecentList is the recently opened list, entries are folders, workspace files or plain files
+type vscodeRecentList struct {
+	Entries []struct {
+		FolderURI string `json:"folderUri"`
+		Workspace *struct {
+			ConfigPath string `json:"configPath"`
+		} `json:"workspace"`
+	} `json:"entries"`
+	// Workspaces3 is the list of older versions, a folder URI or a workspace object
+	Workspaces3 []json.RawMessage `json:"workspaces3"`
+}
+
+func vscodeStorageDir(variant vscodeVariant) string {
+	return filepath.Join(vscodeConfigRoot(), variant.configDir, "User", "globalStorage")
+}
+
+// readVSCode returns the folders and workspaces of a variant, the first is the most recent.
+// VS Code records no times, the most recent entry is dated by the modification of its store.
+func readVSCode(variant vscodeVariant) []Project {
+	if vscodeConfigRoot() == "" {
+		return nil
+	}
+	dir := vscodeStorageDir(variant)
+	var projects []Project
+	for _, store := range []string{filepath.Join(dir, vscodeStateFile), filepath.Join(dir, vscodeStorageFile)} {
+		info, err := os.Stat(store)
+		if err != nil {
+			continue
+		}
+		var paths []string
+		if filepath.Base(store) == vscodeStateFile {
+			paths, err = readVSCodeState(store)
+		} else {
+			paths, err = readVSCodeStorage(store)
+		}
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to read recent projects of %s", variant.name))
+			continue
+		}
+		launcher := vscodeLauncher(variant)
+		for i, path := range paths {
+			project := Project{Path: path, IDE: variant.name, Launcher: launcher}
+			if strings.HasSuffix(path, vscodeWorkspaceSuffix) {
+				project.Name = strings.TrimSuffix(filepath.Base(path), vscodeWorkspaceSuffix) + " (Workspace)"
+			}
+			if i == 0 {
+				project.OpenedAt = info.ModTime()
+			}
+			projects = append(projects, project)
+		}
+	}
+	return projects
+}
+
+func readVSCodeState(path string) ([]string, error) {
+	dir, err := os.MkdirTemp("", "watools-projects-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	db, err := utils.OpenSQLiteCopy(path, dir)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	var value []byte
+	err = db.QueryRow("SELECT value FROM ItemTable WHERE key = ?", vscodeRecentKey).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return parseVSCodeRecent(value)
+}
+
+func readVSCodeStorage(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var storage struct {
+		OpenedPathsList json.RawMessage `json:"openedPathsList"`
+	}
+	if err := json.Unmarshal(data, &storage); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(storage.OpenedPathsList) == 0 {
+		return nil, nil
+	}
+	return parseVSCodeRecent(storage.OpenedPathsList)
+}
+
+// parseVSCodeRecent returns the local folders and workspace files of a recently opened list, remote entries are skipped
+func parseVSCodeRecent(data []byte) ([]string, error) {
+	var list vscodeRecentList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse recently opened list: %w", err)
+	}
+	var paths []string
+	add := func(uri string) {
+		if path, ok := fileURIPath(uri); ok {
+			paths = append(paths, path)
+		}
+	}
+	for _, entry := range list.Entries {
+		switch {
+		case entry.FolderURI != "":
+			add(entry.FolderURI)
+		case entry.Workspace != nil:
+			add(entry.Workspace.ConfigPath)
+		}
+	}
+	for _, raw := range list.Workspaces3 {
+		var folder string
+		if err := json.Unmarshal(raw, &folder); err == nil {
+			add(folder)
+			continue
+		}
+		var workspace struct {
+			ConfigURIPath string `json:"configURIPath"`
+		}
+		if err := json.Unmarshal(raw, &workspace); err == nil {
+			add(workspace.ConfigURIPath)
+		}
+	}
+	return paths, nil
+}
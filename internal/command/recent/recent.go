@@ -0,0 +1,104 @@
+// Package recent reads the recently used documents recorded by the platform
+package recent
+
+import (
+	"mime"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxDocuments bounds the list, the stores keep far more entries than are useful in the launcher
+const maxDocuments = 200
+
+// Document is a recently used file
+type Document struct {
+	Path     string
+	MimeType string
+	// Application is the application that last opened the document, empty when the store does not record it
+	Application string
+	VisitedAt   time.Time
+}
+
+// Load returns the recent documents of the platform, newest first, unique by path and only those that still exist
+func Load() ([]Document, error) {
+	documents, err := loadDocuments()
+	if err != nil {
+		return nil, err
+	}
+	return Normalize(documents, Exists), nil
+}
+
+// Normalize merges the entries of the same path keeping the latest visit, drops the files exists rejects
+// and returns at most maxDocuments, newest first.
+func Normalize(documents []Document, exists func(path string) bool) []Document {
+	byPath := make(map[string]Document, len(documents))
+	for _, document := range documents {
+		if document.Path == "" {
+			continue
+		}
+		document.Path = filepath.Clean(document.Path)
+		if document.MimeType == "" {
+			document.MimeType = mimeTypeOf(document.Path)
+		}
+		key := pathKey(document.Path)
+		previous, seen := byPath[key]
+		if seen && !document.VisitedAt.After(previous.VisitedAt) {
+			if previous.Application == "" {
+				previous.Application = document.Application
+				byPath[key] = previous
+			}
+			continue
+		}
+		if seen && document.Application == "" {
+			document.Application = previous.Application
+		}
+		byPath[key] = document
+	}
+
+	result := make([]Document, 0, len(byPath))
+	for _, document := range byPath {
+		if exists(document.Path) {
+			result = append(result, document)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].VisitedAt.Equal(result[j].VisitedAt) {
+			return result[i].VisitedAt.After(result[j].VisitedAt)
+		}
+		return result[i].Path < result[j].Path
+	})
+	if len(result) > maxDocuments {
+		result = result[:maxDocuments]
+	}
+	return result
+}
+
+// Exists reports whether path is an existing file or directory
+func Exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func mimeTypeOf(path string) string {
+	mimeType := mime.TypeByExtension(filepath.Ext(path))
+	if mimeType == "" {
+		return ""
+	}
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return mimeType
+	}
+	return mediaType
+}
+
+// pathKey is how paths are compared, Windows and macOS file systems are case-insensitive by default
+func pathKey(path string) string {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		return strings.ToLower(path)
+	}
+	return path
+}
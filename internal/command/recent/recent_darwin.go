@@ -0,0 +1,64 @@
+package recent
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// recentQuery asks Spotlight for the documents opened in the last month, the shared file lists
+// of macOS are archived bookmarks that cannot be read without Foundation
+const recentQuery = "kMDItemLastUsedDate >= $time.today(-30) && kMDItemContentTypeTree != com.apple.application && kMDItemContentTypeTree != public.folder"
+
+const lastUsedAttribute = "kMDItemLastUsedDate"
+
+func loadDocuments() ([]Document, error) {
+	output, err := exec.Command("mdfind", "-attr", lastUsedAttribute, recentQuery).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent documents: %w", err)
+	}
+	var documents []Document
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		// each line is "<path>   kMDItemLastUsedDate = 2024-03-05 08:30:00 +0000"
+		path, value, found := strings.Cut(scanner.Text(), "   "+lastUsedAttribute+" = ")
+		if !found {
+			continue
+		}
+		visitedAt, err := time.Parse("2006-01-02 15:04:05 -0700", strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+		documents = append(documents, Document{Path: path, VisitedAt: visitedAt})
+	}
+	return documents, nil
+}
+
+// sharedFileListDir changes whenever a document is added to the recent items
+func sharedFileListDir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, "Library", "Application Support", "com.apple.sharedfilelist")
+}
+
+// StoreDirs returns the directories holding the recent documents store, applications keep their own lists in a subdirectory
+func StoreDirs() []string {
+	dir := sharedFileListDir()
+	return []string{dir, filepath.Join(dir, "com.apple.LSSharedFileList.ApplicationRecentDocuments")}
+}
+
+// IsStoreFile reports whether path is one of the shared file lists
+func IsStoreFile(path string) bool {
+	switch filepath.Ext(path) {
+	case ".sfl", ".sfl2", ".sfl3":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package recent
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"watools/pkg/xdg"
+)
+
+func loadDocuments() ([]Document, error) {
+	files, err := xdg.ParseRecentlyUsedFile(xdg.RecentlyUsedPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	documents := make([]Document, 0, len(files))
+	for _, file := range files {
+		path, ok := file.LocalPath()
+		if !ok {
+			continue
+		}
+		documents = append(documents, Document{
+			Path:        path,
+			MimeType:    file.MimeType,
+			Application: file.Application,
+			VisitedAt:   file.VisitedAt,
+		})
+	}
+	return documents, nil
+}
+
+// StoreDirs returns the directories holding the recent documents store
+func StoreDirs() []string {
+	return []string{filepath.Dir(xdg.RecentlyUsedPath())}
+}
+
+// IsStoreFile reports whether path is part of the recent documents store
+func IsStoreFile(path string) bool {
+	return filepath.Base(path) == xdg.RecentlyUsedFileName
+}
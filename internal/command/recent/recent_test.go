@@ -0,0 +1,79 @@
+package recent
+
+import (
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+	"time"
+	"unicode/utf16"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	report := filepath.FromSlash("/home/user/report.pdf")
+	documents := []Document{
+		{Path: report, Application: "Evince", VisitedAt: day},
+		{Path: filepath.FromSlash("/home/user/gone.txt"), VisitedAt: day.Add(time.Hour)},
+		{Path: filepath.FromSlash("/home/user/notes.md"), VisitedAt: day.Add(2 * time.Hour)},
+		{Path: report + string(filepath.Separator), VisitedAt: day.Add(3 * time.Hour)},
+		{Path: ""},
+	}
+	exists := func(path string) bool { return filepath.Base(path) != "gone.txt" }
+
+	result := Normalize(documents, exists)
+	if len(result) != 2 {
+		t.Fatalf("expected 2 documents, got %+v", result)
+	}
+	if result[0].Path != report || !result[0].VisitedAt.Equal(day.Add(3*time.Hour)) {
+		t.Fatalf("expected the latest visit of the report first, got %+v", result[0])
+	}
+	if result[0].Application != "Evince" {
+		t.Fatalf("expected the application to be kept from the older entry, got %q", result[0].Application)
+	}
+	if result[0].MimeType != "application/pdf" {
+		t.Fatalf("expected the mime type from the extension, got %q", result[0].MimeType)
+	}
+}
+
+// buildShellLink returns a minimal shortcut with an ID list and a LinkInfo holding a unicode local path
+func buildShellLink(path string) []byte {
+	data := make([]byte, shellLinkHeaderSize)
+	binary.LittleEndian.PutUint32(data, shellLinkHeaderSize)
+	binary.LittleEndian.PutUint32(data[0x14:], linkFlagHasTargetIDList|linkFlagHasLinkInfo)
+	data = binary.LittleEndian.AppendUint16(data, 4)
+	data = append(data, 0, 0, 0, 0)
+
+	var unicodePath []byte
+	for _, unit := range utf16.Encode([]rune(path)) {
+		unicodePath = binary.LittleEndian.AppendUint16(unicodePath, unit)
+	}
+	unicodePath = append(unicodePath, 0, 0)
+	info := make([]byte, linkInfoUnicodeHeader)
+	binary.LittleEndian.PutUint32(info[4:], linkInfoUnicodeHeader)
+	binary.LittleEndian.PutUint32(info[8:], linkInfoVolumeIDAndLocal)
+	binary.LittleEndian.PutUint32(info[16:], uint32(len(info)))
+	info = append(info, 0)
+	binary.LittleEndian.PutUint32(info[24:], uint32(len(info)-1))
+	binary.LittleEndian.PutUint32(info[28:], uint32(len(info)))
+	info = append(info, unicodePath...)
+	binary.LittleEndian.PutUint32(info[32:], uint32(len(info)-2))
+	binary.LittleEndian.PutUint32(info, uint32(len(info)))
+	return append(data, info...)
+}
+
+func TestParseShellLinkTarget(t *testing.T) {
+	t.Parallel()
+
+	target, err := ParseShellLinkTarget(buildShellLink(`C:\Users\ü\Documents\plan.docx`))
+	if err != nil {
+		t.Fatalf("expected shell link to parse: %v", err)
+	}
+	if target != `C:\Users\ü\Documents\plan.docx` {
+		t.Fatalf("unexpected target %q", target)
+	}
+	if _, err := ParseShellLinkTarget([]byte("not a link")); err == nil {
+		t.Fatal("expected garbage to be rejected")
+	}
+}
@@ -0,0 +1,58 @@
+package recent
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"watools/pkg/logger"
+)
+
+// recentDir is where Explorer keeps a shortcut to every recently opened file
+func recentDir() string {
+	return filepath.Join(os.Getenv("APPDATA"), "Microsoft", "Windows", "Recent")
+}
+
+func loadDocuments() ([]Document, error) {
+	entries, err := os.ReadDir(recentDir())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recent items: %w", err)
+	}
+	documents := make([]Document, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !IsStoreFile(entry.Name()) {
+			continue
+		}
+		linkPath := filepath.Join(recentDir(), entry.Name())
+		data, err := os.ReadFile(linkPath)
+		if err != nil {
+			continue
+		}
+		target, err := ParseShellLinkTarget(data)
+		if err != nil {
+			logger.Debug(fmt.Sprintf("Skip recent item %s: %v", linkPath, err))
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		// the shortcut is rewritten whenever the file is opened again
+		documents = append(documents, Document{Path: target, VisitedAt: info.ModTime()})
+	}
+	return documents, nil
+}
+
+// StoreDirs returns the directories holding the recent documents store
+func StoreDirs() []string {
+	return []string{recentDir()}
+}
+
+// IsStoreFile reports whether path is part of the recent documents store
+func IsStoreFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".lnk")
+}
@@ -0,0 +1,100 @@
+package recent
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// Shell link (.lnk) layout, see [MS-SHLLINK]
+const (
+	shellLinkHeaderSize      = 0x4C
+	linkFlagHasTargetIDList  = 0x01
+	linkFlagHasLinkInfo      = 0x02
+	linkInfoVolumeIDAndLocal = 0x01
+	linkInfoNetworkRelative  = 0x02
+	linkInfoUnicodeHeader    = 0x24
+)
+
+// ParseShellLinkTarget returns the path a Windows shortcut points to, read from its LinkInfo structure
+func ParseShellLinkTarget(data []byte) (string, error) {
+	if len(data) < shellLinkHeaderSize || binary.LittleEndian.Uint32(data) != shellLinkHeaderSize {
+		return "", fmt.Errorf("not a shell link")
+	}
+	flags := binary.LittleEndian.Uint32(data[0x14:])
+	offset := shellLinkHeaderSize
+	if flags&linkFlagHasTargetIDList != 0 {
+		if len(data) < offset+2 {
+			return "", fmt.Errorf("truncated shell link")
+		}
+		offset += 2 + int(binary.LittleEndian.Uint16(data[offset:]))
+	}
+	if flags&linkFlagHasLinkInfo == 0 {
+		return "", fmt.Errorf("shell link has no link info")
+	}
+	if len(data) < offset+28 {
+		return "", fmt.Errorf("truncated link info")
+	}
+	info := data[offset:]
+	size := int(binary.LittleEndian.Uint32(info))
+	if size < 28 || size > len(info) {
+		return "", fmt.Errorf("invalid link info size")
+	}
+	info = info[:size]
+	headerSize := binary.LittleEndian.Uint32(info[4:])
+	infoFlags := binary.LittleEndian.Uint32(info[8:])
+	field := func(at int) int { return int(binary.LittleEndian.Uint32(info[at:])) }
+
+	var base, suffix string
+	switch {
+	case infoFlags&linkInfoVolumeIDAndLocal != 0 && headerSize >= linkInfoUnicodeHeader && size >= linkInfoUnicodeHeader:
+		base = utf16String(info, field(28))
+		suffix = utf16String(info, field(32))
+	case infoFlags&linkInfoVolumeIDAndLocal != 0:
+		base = ansiString(info, field(16))
+		suffix = ansiString(info, field(24))
+	case infoFlags&linkInfoNetworkRelative != 0:
+		network := field(20)
+		if network <= 0 || network+12 > len(info) {
+			return "", fmt.Errorf("invalid network link")
+		}
+		base = ansiString(info, network+int(binary.LittleEndian.Uint32(info[network+8:])))
+		suffix = ansiString(info, field(24))
+	}
+	if base == "" {
+		return "", fmt.Errorf("shell link has no local path")
+	}
+	if suffix != "" && !strings.HasSuffix(base, `\`) {
+		base += `\`
+	}
+	return base + suffix, nil
+}
+
+// ansiString reads a NUL terminated string in the system code page, only ASCII is decoded reliably
+func ansiString(data []byte, offset int) string {
+	if offset <= 0 || offset >= len(data) {
+		return ""
+	}
+	end := bytes.IndexByte(data[offset:], 0)
+	if end < 0 {
+		return ""
+	}
+	return string(data[offset : offset+end])
+}
+
+func utf16String(data []byte, offset int) string {
+	if offset <= 0 || offset >= len(data) {
+		return ""
+	}
+	var units []uint16
+	for i := offset; i+1 < len(data); i += 2 {
+		unit := binary.LittleEndian.Uint16(data[i:])
+		if unit == 0 {
+			return string(utf16.Decode(units))
+		}
+		units = append(units, unit)
+	}
+	return ""
+}
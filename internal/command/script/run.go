@@ -0,0 +1,157 @@
+package script
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+)
+
+// OutputTimeout bounds how long the UI waits for a script in output mode
+const OutputTimeout = 60 * time.Second
+
+// maxOutputSize caps the captured stdout and stderr of a script
+const maxOutputSize = 1 << 20
+
+// Run runs a script with its arguments.
+// Silent scripts are started in the background and nil is returned, output scripts are waited for.
+// A non-zero exit code is part of the result, not an error.
+func Run(ctx context.Context, script *models.ScriptCommand, arguments []string) (*models.ScriptResult, error) {
+	if err := validateArguments(script, arguments); err != nil {
+		return nil, err
+	}
+
+	if script.Mode != models.ScriptModeOutput {
+		cmd, err := scriptCommand(context.Background(), script.Path, arguments)
+		if err != nil {
+			return nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start script '%s': %w", script.Path, err)
+		}
+		go func() {
+			if err := cmd.Wait(); err != nil {
+				logger.Error(err, fmt.Sprintf("Script command '%s' failed", script.Path))
+			}
+		}()
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, OutputTimeout)
+	defer cancel()
+	cmd, err := scriptCommand(ctx, script.Path, arguments)
+	if err != nil {
+		return nil, err
+	}
+	stdout := &limitedBuffer{limit: maxOutputSize}
+	stderr := &limitedBuffer{limit: maxOutputSize}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err = cmd.Run()
+	result := &models.ScriptResult{
+		Stdout: stdout.String(),
+		Stderr: stderr.String(),
+	}
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case ctx.Err() != nil:
+		return nil, fmt.Errorf("script '%s' did not finish within %s", script.Path, OutputTimeout)
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	default:
+		return nil, fmt.Errorf("failed to run script '%s': %w", script.Path, err)
+	}
+	return result, nil
+}
+
+func validateArguments(script *models.ScriptCommand, arguments []string) error {
+	if len(arguments) > len(script.Arguments) {
+		return fmt.Errorf("script '%s' takes %d arguments, got %d", script.Name, len(script.Arguments), len(arguments))
+	}
+	for i, argument := range script.Arguments {
+		if argument.Optional {
+			continue
+		}
+		if i >= len(arguments) || arguments[i] == "" {
+			return fmt.Errorf("script '%s' requires argument '%s'", script.Name, argument.Placeholder)
+		}
+	}
+	return nil
+}
+
+// scriptCommand builds the command for a script. Executable files run directly,
+// others through the interpreter named by their shebang or their file extension.
+func scriptCommand(ctx context.Context, path string, arguments []string) (*exec.Cmd, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find script '%s': %w", path, err)
+	}
+
+	var cmd *exec.Cmd
+	if fi.Mode()&0111 != 0 {
+		cmd = exec.CommandContext(ctx, path, arguments...)
+	} else {
+		interpreter := interpreterOf(path)
+		if len(interpreter) == 0 {
+			return nil, fmt.Errorf("script '%s' is not executable and has no shebang", path)
+		}
+		args := append(append(interpreter[1:], path), arguments...)
+		cmd = exec.CommandContext(ctx, interpreter[0], args...)
+	}
+	cmd.Dir = filepath.Dir(path)
+	return cmd, nil
+}
+
+func interpreterOf(path string) []string {
+	file, err := os.Open(path)
+	if err == nil {
+		defer file.Close()
+		scanner := bufio.NewScanner(file)
+		if scanner.Scan() {
+			if shebang, found := strings.CutPrefix(scanner.Text(), "#!"); found {
+				if fields := strings.Fields(shebang); len(fields) > 0 {
+					return fields
+				}
+			}
+		}
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".sh":
+		return []string{"sh"}
+	case ".py":
+		return []string{"python3"}
+	case ".ps1":
+		return []string{"powershell", "-NoProfile", "-ExecutionPolicy", "Bypass", "-File"}
+	case ".bat", ".cmd":
+		return []string{"cmd", "/C"}
+	default:
+		return nil
+	}
+}
+
+// limitedBuffer keeps the first limit bytes written and drops the rest
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.Len(); remaining > 0 {
+		if len(p) > remaining {
+			b.Buffer.Write(p[:remaining])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
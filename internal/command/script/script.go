@@ -0,0 +1,212 @@
+package script
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+
+	"github.com/samber/mo"
+)
+
+// ErrNotScriptCommand is returned for files in the commands directory without a @title header
+var ErrNotScriptCommand = errors.New("file is not a script command")
+
+// maxHeaderLines bounds how far into a file metadata comments are looked for
+const maxHeaderLines = 64
+
+var commentPrefixes = []string{"#", "//", "--", ";", "REM ", "::"}
+
+// IsScriptFile reports whether a file in the commands directory may be a script, editor and hidden files are skipped
+func IsScriptFile(path string) bool {
+	name := filepath.Base(path)
+	return !strings.HasPrefix(name, ".") && !strings.HasSuffix(name, "~") && !strings.HasSuffix(name, ".swp")
+}
+
+// ParseScriptFile reads the metadata comments at the top of a script.
+// Headers look like "# @title Open Project", the Raycast form "# @raycast.title" is accepted as well.
+func ParseScriptFile(path string) (*models.ScriptCommand, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.Mode().IsRegular() {
+		return nil, fmt.Errorf("path is not a regular file: %s", path)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	headers, err := readHeaders(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s': %w", path, err)
+	}
+
+	title := headers.first("title")
+	if title == "" {
+		return nil, fmt.Errorf("'%s' has no @title header: %w", path, ErrNotScriptCommand)
+	}
+	description := headers.first("description")
+	mode, err := parseMode(headers.first("mode"))
+	if err != nil {
+		return nil, fmt.Errorf("'%s': %w", path, err)
+	}
+	var arguments []models.ScriptArgument
+	for _, value := range headers.arguments() {
+		argument, err := parseArgument(value)
+		if err != nil {
+			return nil, fmt.Errorf("'%s': %w", path, err)
+		}
+		arguments = append(arguments, argument)
+	}
+
+	return models.NewScriptCommand(title, mo.TupleToOption(description, description != ""), path,
+		resolveIcon(filepath.Dir(path), headers.first("icon")), mode, arguments, Run), nil
+}
+
+// LoadScripts parses every script command in dir, files that are not script commands are skipped
+func LoadScripts(dir string) ([]*models.ScriptCommand, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var scripts []*models.ScriptCommand
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if entry.IsDir() || !IsScriptFile(path) {
+			continue
+		}
+		script, err := ParseScriptFile(path)
+		if err != nil {
+			logger.Debug(fmt.Sprintf("Skip script command '%s': %v", path, err))
+			continue
+		}
+		scripts = append(scripts, script)
+	}
+	sort.Slice(scripts, func(i, j int) bool {
+		return scripts[i].Path < scripts[j].Path
+	})
+	return scripts, nil
+}
+
+type header struct {
+	key   string
+	value string
+}
+
+type headerList []header
+
+func (h headerList) first(key string) string {
+	for _, item := range h {
+		if item.key == key {
+			return item.value
+		}
+	}
+	return ""
+}
+
+// arguments returns the @argument values in file order, the numbered Raycast keys such as @argument1 count as well
+func (h headerList) arguments() []string {
+	var values []string
+	for _, item := range h {
+		suffix, found := strings.CutPrefix(item.key, "argument")
+		if !found {
+			continue
+		}
+		if _, err := strconv.Atoi(suffix); suffix != "" && err != nil {
+			continue
+		}
+		values = append(values, item.value)
+	}
+	return values
+}
+
+func readHeaders(file *os.File) (headerList, error) {
+	var headers headerList
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 0; lineNumber < maxHeaderLines && scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#!") {
+			continue
+		}
+		comment, isComment := stripComment(line)
+		if !isComment {
+			// metadata must come before the first line of code
+			break
+		}
+		if !strings.HasPrefix(comment, "@") {
+			continue
+		}
+		key, value := comment[1:], ""
+		if index := strings.IndexAny(key, " \t"); index >= 0 {
+			key, value = key[:index], strings.TrimSpace(key[index:])
+		}
+		key = strings.TrimPrefix(strings.ToLower(key), "raycast.")
+		headers = append(headers, header{key: key, value: value})
+	}
+	return headers, scanner.Err()
+}
+
+func stripComment(line string) (string, bool) {
+	for _, prefix := range commentPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, prefix)), true
+		}
+	}
+	return "", false
+}
+
+func parseMode(value string) (models.ScriptMode, error) {
+	switch strings.ToLower(value) {
+	case "", string(models.ScriptModeSilent):
+		return models.ScriptModeSilent, nil
+	// Raycast modes that show output
+	case string(models.ScriptModeOutput), "fulloutput", "compact", "inline":
+		return models.ScriptModeOutput, nil
+	default:
+		return "", fmt.Errorf("unsupported @mode '%s'", value)
+	}
+}
+
+// parseArgument accepts a plain placeholder or a Raycast style JSON object
+func parseArgument(value string) (models.ScriptArgument, error) {
+	if !strings.HasPrefix(value, "{") {
+		if value == "" {
+			return models.ScriptArgument{}, fmt.Errorf("@argument needs a placeholder")
+		}
+		return models.ScriptArgument{Placeholder: value}, nil
+	}
+	var argument struct {
+		Placeholder string `json:"placeholder"`
+		Optional    bool   `json:"optional"`
+	}
+	if err := json.Unmarshal([]byte(value), &argument); err != nil {
+		return models.ScriptArgument{}, fmt.Errorf("invalid @argument '%s': %w", value, err)
+	}
+	return models.ScriptArgument{Placeholder: argument.Placeholder, Optional: argument.Optional}, nil
+}
+
+// resolveIcon makes icon files relative to the script absolute, emoji and icon names are kept as is
+func resolveIcon(scriptDir string, icon string) string {
+	if icon == "" || filepath.IsAbs(icon) || strings.Contains(icon, "://") {
+		return icon
+	}
+	if candidate := filepath.Join(scriptDir, icon); fileExists(candidate) {
+		return candidate
+	}
+	return icon
+}
+
+func fileExists(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.Mode().IsRegular()
+}
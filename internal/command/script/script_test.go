@@ -0,0 +1,193 @@
+package script
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"watools/pkg/models"
+)
+
+func writeScript(t *testing.T, dir string, name string, content string, mode os.FileMode) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestParseScriptFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeScript(t, dir, "icon.png", "png", 0644)
+	path := writeScript(t, dir, "search.sh", `#!/bin/bash
+
+# @title Search Docs
+# @description Search the docs site
+# @icon icon.png
+#	@mode output
+# @argument query
+# @argument {"type": "text", "placeholder": "section", "optional": true}
+# @title Ignored Second Title
+
+echo "$1"
+# @description not a header, code started above
+`, 0755)
+
+	command, err := ParseScriptFile(path)
+	if err != nil {
+		t.Fatalf("ParseScriptFile() error = %v", err)
+	}
+	if command.Name != "Search Docs" {
+		t.Fatalf("Name = %q, want %q", command.Name, "Search Docs")
+	}
+	if command.Description.OrEmpty() != "Search the docs site" {
+		t.Fatalf("Description = %q", command.Description.OrEmpty())
+	}
+	if command.Icon != filepath.Join(dir, "icon.png") {
+		t.Fatalf("Icon = %q, want the icon resolved next to the script", command.Icon)
+	}
+	if command.Mode != models.ScriptModeOutput {
+		t.Fatalf("Mode = %q, want output", command.Mode)
+	}
+	wantArguments := []models.ScriptArgument{{Placeholder: "query"}, {Placeholder: "section", Optional: true}}
+	if len(command.Arguments) != len(wantArguments) {
+		t.Fatalf("Arguments = %+v, want %+v", command.Arguments, wantArguments)
+	}
+	for i, argument := range wantArguments {
+		if command.Arguments[i] != argument {
+			t.Fatalf("Arguments[%d] = %+v, want %+v", i, command.Arguments[i], argument)
+		}
+	}
+	if command.Category != models.CategoryScript || command.TriggerID != "Script-Search Docs-search.sh" {
+		t.Fatalf("unexpected command identity: %s %s", command.Category, command.TriggerID)
+	}
+}
+
+func TestParseScriptFileRaycastHeaders(t *testing.T) {
+	t.Parallel()
+
+	path := writeScript(t, t.TempDir(), "hello.py", `#!/usr/bin/env python3
+# @raycast.schemaVersion 1
+# @raycast.title Hello
+# @raycast.mode fullOutput
+# @raycast.icon 👋
+# @raycast.argument1 { "type": "text", "placeholder": "name" }
+print("hello")
+`, 0755)
+
+	command, err := ParseScriptFile(path)
+	if err != nil {
+		t.Fatalf("ParseScriptFile() error = %v", err)
+	}
+	if command.Name != "Hello" || command.Mode != models.ScriptModeOutput || command.Icon != "👋" {
+		t.Fatalf("unexpected command: %+v", command)
+	}
+	if len(command.Arguments) != 1 || command.Arguments[0].Placeholder != "name" || command.Arguments[0].Optional {
+		t.Fatalf("Arguments = %+v", command.Arguments)
+	}
+}
+
+func TestParseScriptFileRejectsInvalidHeaders(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	untitled := writeScript(t, dir, "untitled.sh", "#!/bin/sh\n# @description no title\necho hi\n", 0755)
+	if _, err := ParseScriptFile(untitled); !errors.Is(err, ErrNotScriptCommand) {
+		t.Fatalf("ParseScriptFile() error = %v, want ErrNotScriptCommand", err)
+	}
+	badMode := writeScript(t, dir, "bad.sh", "#!/bin/sh\n# @title Bad\n# @mode loud\n", 0755)
+	if _, err := ParseScriptFile(badMode); err == nil {
+		t.Fatalf("ParseScriptFile() accepted an unknown mode")
+	}
+}
+
+func TestLoadScriptsSkipsNonScripts(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeScript(t, dir, "b.sh", "#!/bin/sh\n# @title B\n", 0755)
+	writeScript(t, dir, "a.sh", "#!/bin/sh\n# @title A\n", 0755)
+	writeScript(t, dir, "README.md", "# Scripts\n", 0644)
+	writeScript(t, dir, ".a.sh.swp", "# @title Swap\n", 0644)
+	if err := os.Mkdir(filepath.Join(dir, "lib"), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+
+	scripts, err := LoadScripts(dir)
+	if err != nil {
+		t.Fatalf("LoadScripts() error = %v", err)
+	}
+	if len(scripts) != 2 || scripts[0].Name != "A" || scripts[1].Name != "B" {
+		t.Fatalf("LoadScripts() = %v, want A and B", scripts)
+	}
+}
+
+func TestRunOutputMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not supported on windows")
+	}
+	t.Parallel()
+
+	dir := t.TempDir()
+	executable := writeScript(t, dir, "greet.sh", `#!/bin/sh
+# @title Greet
+# @mode output
+# @argument name
+echo "hello $1"
+echo "from $(basename "$PWD")" >&2
+exit 3
+`, 0755)
+	// not executable, started through its shebang
+	plain := writeScript(t, dir, "plain.sh", "#!/bin/sh\n# @title Plain\n# @mode output\necho plain\n", 0644)
+
+	command, err := ParseScriptFile(executable)
+	if err != nil {
+		t.Fatalf("ParseScriptFile() error = %v", err)
+	}
+	result, err := command.Run(context.Background(), []string{"world"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want := models.ScriptResult{Stdout: "hello world\n", Stderr: "from " + filepath.Base(dir) + "\n", ExitCode: 3}
+	if *result != want {
+		t.Fatalf("Run() = %+v, want %+v", *result, want)
+	}
+	if _, err := command.Run(context.Background(), nil); err == nil {
+		t.Fatalf("Run() without the required argument succeeded")
+	}
+	if _, err := command.Run(context.Background(), []string{"a", "b"}); err == nil {
+		t.Fatalf("Run() with too many arguments succeeded")
+	}
+
+	command, err = ParseScriptFile(plain)
+	if err != nil {
+		t.Fatalf("ParseScriptFile() error = %v", err)
+	}
+	result, err = command.Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.Stdout != "plain\n" || result.ExitCode != 0 {
+		t.Fatalf("Run() = %+v", *result)
+	}
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	t.Parallel()
+
+	buffer := &limitedBuffer{limit: 4}
+	for _, chunk := range []string{"ab", "cdef", "gh"} {
+		if n, err := buffer.Write([]byte(chunk)); err != nil || n != len(chunk) {
+			t.Fatalf("Write(%q) = %d, %v", chunk, n, err)
+		}
+	}
+	if buffer.String() != "abcd" {
+		t.Fatalf("buffer = %q, want %q", buffer.String(), "abcd")
+	}
+}
@@ -0,0 +1,97 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+	"watools/pkg/search"
+
+	"github.com/samber/lo"
+)
+
+// commandIndex keeps a search index over the commands of every registered source.
+// Plugins are not a source, their entries are matched by JS in the frontend.
+type commandIndex struct {
+	// mu serializes updates so that a source is never replaced by an older listing
+	mu    sync.Mutex
+	index *search.Index
+}
+
+func newCommandIndex() *commandIndex {
+	return &commandIndex{index: search.NewIndex()}
+}
+
+// update lists a source again and replaces its documents, unchanged commands are not tokenized again
+func (c *commandIndex) update(ctx context.Context, list commandLister, source models.CommandCategory) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	runners, _, err := list(ctx, source)
+	if err != nil {
+		logger.Error(err, fmt.Sprintf("Failed to index command source %s", source))
+		return
+	}
+	c.index.Replace(string(source), lo.Map(runners, func(runner models.CommandRunner, _ int) search.Document {
+		return commandDocument(runner)
+	}))
+	logger.Debug(fmt.Sprintf("Indexed %d commands of %s", len(runners), source))
+}
+
+func (c *commandIndex) build(ctx context.Context, sources []models.CommandCategory, list commandLister) {
+	for _, source := range sources {
+		c.update(ctx, list, source)
+	}
+}
+
+func commandDocument(runner models.CommandRunner) search.Document {
+	metadata := runner.GetMetadata()
+	document := search.Document{
+		ID:          runner.GetTriggerID(),
+		Title:       metadata.Name,
+		Description: metadata.Description.OrEmpty(),
+	}
+	switch command := runner.(type) {
+	case *models.ApplicationCommand:
+		document.Keywords = command.Aliases
+	case *models.QuicklinkCommand:
+		if command.Keyword != "" {
+			document.Keywords = []string{command.Keyword}
+		}
+	case *models.SnippetCommand:
+		document.Keywords = command.Tags
+		if command.Keyword != "" {
+			document.Keywords = append([]string{command.Keyword}, command.Tags...)
+		}
+	case *models.ProcessCommand:
+		document.Keywords = command.Keywords()
+	case *models.SSHHostCommand:
+		if command.HostName != command.Alias {
+			document.Keywords = []string{command.HostName}
+		}
+	}
+	return document
+}
+
+// Search returns the commands of every source matching query, best first.
+// Each result holds the command, the matched "field" and its "text", and "highlights" as rune offsets into the text.
+func (w *WaLaunchApp) Search(query string, limit int) []interface{} {
+	results := w.search.index.Search(query, limit)
+	items := make([]interface{}, 0, len(results))
+	for _, result := range results {
+		runner, err := w.registry.Find(w.ctx, models.CommandCategory(result.Source), result.ID)
+		if err != nil {
+			// the command went away since it was indexed
+			continue
+		}
+		items = append(items, map[string]interface{}{
+			"command":    commandsToMaps([]models.CommandRunner{runner})[0],
+			"source":     result.Source,
+			"score":      result.Score,
+			"field":      result.Field,
+			"text":       result.Text,
+			"highlights": result.Highlights,
+		})
+	}
+	return items
+}
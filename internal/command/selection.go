@@ -0,0 +1,52 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"watools/pkg/db"
+	"watools/pkg/frecency"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+)
+
+// pluginSelectionSource is the ranking source the frontend uses for plugin entries,
+// their trigger IDs start with the package ID followed by ":"
+const pluginSelectionSource = "plugin"
+
+// frecencyWindow is how many of the latest selections are scored
+const frecencyWindow = 2000
+
+// RecordSelection stores that a command was launched and increments the usage count of its application or plugin
+func (w *WaLaunchApp) RecordSelection(event models.SelectionEvent) error {
+	if event.TriggerID == "" || event.Source == "" {
+		return fmt.Errorf("selection needs a trigger id and a source")
+	}
+	event.Query = models.NormalizeQuery(event.Query)
+	if event.SelectedAt.IsZero() {
+		event.SelectedAt = time.Now()
+	}
+
+	applicationID, pluginID := "", ""
+	switch {
+	case strings.EqualFold(event.Source, string(models.CategoryApplication)):
+		runner, err := w.registry.Find(w.ctx, models.CategoryApplication, event.TriggerID)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to find selected application %s", event.TriggerID))
+		} else if application, ok := runner.(*models.ApplicationCommand); ok {
+			applicationID = application.ID
+		}
+	case strings.EqualFold(event.Source, pluginSelectionSource):
+		pluginID, _, _ = strings.Cut(event.TriggerID, ":")
+	}
+	return db.GetWaDB().RecordSelection(w.ctx, event, applicationID, pluginID)
+}
+
+// GetSelectionScores returns the frecency of the recently selected commands for query, best first
+func (w *WaLaunchApp) GetSelectionScores(query string, clipboardType string) ([]frecency.Score, error) {
+	events, err := db.GetWaDB().GetRecentSelectionEvents(w.ctx, frecencyWindow)
+	if err != nil {
+		return nil, err
+	}
+	return frecency.DefaultModel.Scores(events, query, clipboardType, time.Now()), nil
+}
@@ -0,0 +1,217 @@
+package snippet
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"watools/pkg/placeholder"
+)
+
+// alfredSnippet is the content of one JSON file of an Alfred snippet collection
+type alfredSnippet struct {
+	Snippet struct {
+		Snippet        string `json:"snippet"`
+		UID            string `json:"uid"`
+		Name           string `json:"name"`
+		Keyword        string `json:"keyword"`
+		DontAutoExpand bool   `json:"dontautoexpand,omitempty"`
+	} `json:"alfredsnippet"`
+}
+
+const alfredInfoPlist = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>snippetkeywordprefix</key>
+	<string></string>
+	<key>snippetkeywordsuffix</key>
+	<string></string>
+</dict>
+</plist>
+`
+
+// ReadAlfred reads an Alfred snippet collection.
+// The keyword prefix and suffix of the collection are applied to every keyword and the collection name becomes a tag.
+func ReadAlfred(collectionPath string) ([]Snippet, error) {
+	reader, err := zip.OpenReader(collectionPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open alfred snippets %s: %w", collectionPath, err)
+	}
+	defer reader.Close()
+
+	collection := strings.TrimSuffix(filepath.Base(collectionPath), filepath.Ext(collectionPath))
+	var prefix, suffix string
+	var snippets []Snippet
+	for _, file := range reader.File {
+		name := path.Base(file.Name)
+		if file.FileInfo().IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+		data, err := readZipFile(file)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case name == "info.plist":
+			info := readPlistStrings(data)
+			prefix, suffix = info["snippetkeywordprefix"], info["snippetkeywordsuffix"]
+		case strings.EqualFold(path.Ext(name), ".json"):
+			var item alfredSnippet
+			if err := json.Unmarshal(data, &item); err != nil {
+				return nil, fmt.Errorf("failed to parse alfred snippet %s: %w", file.Name, err)
+			}
+			snippets = append(snippets, Snippet{
+				ID:      item.Snippet.UID,
+				Name:    item.Snippet.Name,
+				Keyword: item.Snippet.Keyword,
+				Body:    fromAlfredBody(item.Snippet.Snippet),
+				Tags:    []string{collection},
+			})
+		}
+	}
+	for i := range snippets {
+		if snippets[i].Keyword != "" {
+			snippets[i].Keyword = prefix + snippets[i].Keyword + suffix
+		}
+	}
+	return snippets, nil
+}
+
+// WriteAlfred writes snippets as an Alfred snippet collection, tags have no Alfred equivalent and are left out
+func WriteAlfred(collectionPath string, snippets []Snippet) error {
+	file, err := os.Create(collectionPath)
+	if err != nil {
+		return err
+	}
+	writer := zip.NewWriter(file)
+	if err := writeAlfredEntries(writer, snippets); err != nil {
+		writer.Close()
+		file.Close()
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func writeAlfredEntries(writer *zip.Writer, snippets []Snippet) error {
+	entry, err := writer.Create("info.plist")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(entry, alfredInfoPlist); err != nil {
+		return err
+	}
+	for _, snippet := range snippets {
+		var item alfredSnippet
+		item.Snippet.Snippet = toAlfredBody(snippet.Body)
+		item.Snippet.UID = snippet.ID
+		item.Snippet.Name = snippet.Name
+		item.Snippet.Keyword = snippet.Keyword
+		data, err := json.MarshalIndent(item, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal snippet %s: %w", snippet.Name, err)
+		}
+		entry, err := writer.Create(fmt.Sprintf("%s [%s].json", alfredFileName(snippet.Name), snippet.ID))
+		if err != nil {
+			return err
+		}
+		if _, err := entry.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", file.Name, err)
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// readPlistStrings returns the string values of the top-level dict of a property list
+func readPlistStrings(data []byte) map[string]string {
+	values := make(map[string]string)
+	decoder := xml.NewDecoder(strings.NewReader(string(data)))
+	decoder.Strict = false
+	var key, element string
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return values
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			element = token.Name.Local
+			if element == "string" && key != "" {
+				// an empty <string></string> has no character data
+				values[key] = ""
+			}
+		case xml.CharData:
+			switch element {
+			case "key":
+				key = string(token)
+			case "string":
+				if key != "" {
+					values[key] += string(token)
+				}
+			}
+		case xml.EndElement:
+			if token.Name.Local == "string" {
+				key = ""
+			}
+			element = ""
+		}
+	}
+}
+
+// fromAlfredBody rewrites the Alfred placeholders that have a different name here
+func fromAlfredBody(body string) string {
+	expanded, _ := placeholder.Expand(body, func(p placeholder.Placeholder) (string, bool, error) {
+		switch {
+		case p.Name == "random" && strings.EqualFold(p.Option, "uuid"):
+			return "{uuid}", true, nil
+		case p.Name == "isodate" && p.Option != "":
+			return "{date:" + p.Option + "}", true, nil
+		default:
+			return "", false, nil
+		}
+	})
+	return expanded
+}
+
+// toAlfredBody is the reverse of fromAlfredBody, argument prompts are kept as Alfred has none
+func toAlfredBody(body string) string {
+	expanded, _ := placeholder.Expand(body, func(p placeholder.Placeholder) (string, bool, error) {
+		if p.Name == "uuid" {
+			return "{random:UUID}", true, nil
+		}
+		return "", false, nil
+	})
+	return expanded
+}
+
+// alfredFileName replaces the characters not allowed in file names on any platform
+func alfredFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < ' ' {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if name == "" {
+		return "snippet"
+	}
+	return name
+}
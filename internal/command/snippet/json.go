@@ -0,0 +1,47 @@
+package snippet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// jsonVersion is the version of the JSON document written by WriteJSON
+const jsonVersion = 1
+
+type jsonDocument struct {
+	Version  int       `json:"version"`
+	Snippets []Snippet `json:"snippets"`
+}
+
+// ReadJSON reads a JSON document written by WriteJSON, a bare array of snippets is accepted too
+func ReadJSON(path string) ([]Snippet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snippets []Snippet
+	if err := json.Unmarshal(data, &snippets); err == nil {
+		return snippets, nil
+	}
+	var document jsonDocument
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse snippets from %s: %w", path, err)
+	}
+	if document.Version > jsonVersion {
+		return nil, fmt.Errorf("snippet file %s has unsupported version %d", path, document.Version)
+	}
+	return document.Snippets, nil
+}
+
+// WriteJSON writes snippets as an indented JSON document
+func WriteJSON(path string, snippets []Snippet) error {
+	if snippets == nil {
+		snippets = []Snippet{}
+	}
+	data, err := json.MarshalIndent(jsonDocument{Version: jsonVersion, Snippets: snippets}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snippets: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
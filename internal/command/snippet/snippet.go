@@ -0,0 +1,66 @@
+// Package snippet reads and writes snippet files, as a watools JSON document or as an Alfred snippet collection
+package snippet
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Snippet is a snippet as it is stored in an export file
+type Snippet struct {
+	ID      string   `json:"id,omitempty"`
+	Name    string   `json:"name"`
+	Keyword string   `json:"keyword,omitempty"`
+	Body    string   `json:"body"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// Format is a snippet file format
+type Format string
+
+const (
+	// FormatJSON is the watools JSON document, it keeps tags and placeholders as they are
+	FormatJSON Format = "json"
+	// FormatAlfred is an Alfred snippet collection, a zip of one JSON file per snippet
+	FormatAlfred Format = "alfred"
+)
+
+// AlfredExtension is the extension of Alfred snippet collections
+const AlfredExtension = ".alfredsnippets"
+
+// FormatOf returns the format of a snippet file from its extension
+func FormatOf(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case AlfredExtension:
+		return FormatAlfred, nil
+	default:
+		return "", fmt.Errorf("unsupported snippet file '%s', expected .json or %s", filepath.Base(path), AlfredExtension)
+	}
+}
+
+// Read reads the snippets of a file in the format of its extension
+func Read(path string) ([]Snippet, error) {
+	format, err := FormatOf(path)
+	if err != nil {
+		return nil, err
+	}
+	if format == FormatAlfred {
+		return ReadAlfred(path)
+	}
+	return ReadJSON(path)
+}
+
+// Write writes snippets to a file in the format of its extension
+func Write(path string, snippets []Snippet) error {
+	format, err := FormatOf(path)
+	if err != nil {
+		return err
+	}
+	if format == FormatAlfred {
+		return WriteAlfred(path, snippets)
+	}
+	return WriteJSON(path, snippets)
+}
@@ -0,0 +1,122 @@
+package snippet
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "snippets.json")
+	snippets := []Snippet{
+		{ID: "1", Name: "Sign-off", Keyword: ";sig", Body: "Best,\n{argument:Name}", Tags: []string{"mail"}},
+		{ID: "2", Name: "Now", Body: "{date:yyyy-MM-dd}"},
+	}
+	if err := Write(path, snippets); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	got, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, snippets) {
+		t.Fatalf("Read() = %#v, want %#v", got, snippets)
+	}
+
+	if err := os.WriteFile(path, []byte(`[{"name":"bare","body":"x"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ReadJSON(path)
+	if err != nil || len(got) != 1 || got[0].Name != "bare" {
+		t.Fatalf("ReadJSON() of a bare array = %#v, %v", got, err)
+	}
+	if _, err := Read(filepath.Join(t.TempDir(), "snippets.txt")); err == nil {
+		t.Fatalf("Read() of an unknown extension succeeded")
+	}
+}
+
+func TestReadAlfred(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "Work Mail.alfredsnippets")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer := zip.NewWriter(file)
+	entries := map[string]string{
+		"Thanks [A1].json": `{"alfredsnippet":{"snippet":"Thanks!{cursor} {random:UUID} {isodate:yyyy}","uid":"A1","name":"Thanks","keyword":"ty"}}`,
+		"Plain [B2].json":  `{"alfredsnippet":{"snippet":"{clipboard}","uid":"B2","name":"Plain","keyword":""}}`,
+		"info.plist": `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict>
+	<key>snippetkeywordprefix</key><string>;</string>
+	<key>snippetkeywordsuffix</key><string></string>
+</dict></plist>`,
+	}
+	for name, content := range entries {
+		entry, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := entry.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	byID := make(map[string]Snippet)
+	for _, snippet := range got {
+		byID[snippet.ID] = snippet
+	}
+	want := map[string]Snippet{
+		"A1": {ID: "A1", Name: "Thanks", Keyword: ";ty", Body: "Thanks!{cursor} {uuid} {date:yyyy}", Tags: []string{"Work Mail"}},
+		"B2": {ID: "B2", Name: "Plain", Body: "{clipboard}", Tags: []string{"Work Mail"}},
+	}
+	if !reflect.DeepEqual(byID, want) {
+		t.Fatalf("Read() = %#v, want %#v", byID, want)
+	}
+}
+
+func TestAlfredRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "export.alfredsnippets")
+	snippets := []Snippet{{ID: "C3", Name: "a/b", Keyword: "id", Body: "id: {uuid}"}}
+	if err := Write(path, snippets); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+	}
+	reader.Close()
+	if !reflect.DeepEqual(names, []string{"info.plist", "a-b [C3].json"}) {
+		t.Fatalf("WriteAlfred() wrote %v", names)
+	}
+
+	got, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := []Snippet{{ID: "C3", Name: "a/b", Keyword: "id", Body: "id: {uuid}", Tags: []string{"export"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Read() = %#v, want %#v", got, want)
+	}
+}
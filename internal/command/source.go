@@ -0,0 +1,237 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+
+	"github.com/samber/lo"
+)
+
+// CommandSource provides one category of launcher results.
+// The category returned by Name is stamped on every command of the source and is how the frontend addresses it.
+type CommandSource interface {
+	Name() models.CommandCategory
+	// Start begins watching the backing store, notify must be called whenever the commands change
+	Start(ctx context.Context, notify func()) error
+	Stop() error
+	// List returns the current commands of the source
+	List(ctx context.Context) ([]models.CommandRunner, error)
+	// Refresh rescans the backing store, it reports changes through notify like Start does
+	Refresh(ctx context.Context) error
+	// Trigger runs a command previously returned by List, arguments are already validated against its Accepts
+	Trigger(ctx context.Context, runner models.CommandRunner, arguments models.CommandArguments) error
+}
+
+// ActionSource is a source whose commands list Actions besides their trigger
+type ActionSource interface {
+	// RunAction runs an action of a command previously returned by List or Find, it is already checked against the
+	// Actions of the command and arguments against its Accepts. The result is handed to the frontend and may be nil.
+	RunAction(ctx context.Context, runner models.CommandRunner, action string, arguments models.CommandArguments) (interface{}, error)
+}
+
+// CommandFinder is a source too large to list, such as the file index, that looks commands up by trigger ID instead
+type CommandFinder interface {
+	Find(ctx context.Context, triggerID string) (models.CommandRunner, error)
+}
+
+// Registry keeps the registered command sources and the runners they last listed
+type Registry struct {
+	mu        sync.RWMutex
+	sources   map[models.CommandCategory]CommandSource
+	order     []models.CommandCategory
+	runners   map[models.CommandCategory][]models.CommandRunner
+	listeners []func(models.CommandCategory)
+}
+
+// NewRegistry create an empty command source registry
+func NewRegistry() *Registry {
+	return &Registry{
+		sources: make(map[models.CommandCategory]CommandSource),
+		runners: make(map[models.CommandCategory][]models.CommandRunner),
+	}
+}
+
+// Register adds a source, names must be unique
+func (r *Registry) Register(source CommandSource) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := source.Name()
+	if _, exists := r.sources[name]; exists {
+		return fmt.Errorf("command source '%s' is already registered", name)
+	}
+	r.sources[name] = source
+	r.order = append(r.order, name)
+	return nil
+}
+
+// Sources returns the names of the registered sources in registration order
+func (r *Registry) Sources() []models.CommandCategory {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]models.CommandCategory(nil), r.order...)
+}
+
+func (r *Registry) source(name models.CommandCategory) (CommandSource, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	source, exists := r.sources[name]
+	if !exists {
+		return nil, fmt.Errorf("command source '%s' is not registered", name)
+	}
+	return source, nil
+}
+
+// OnChange registers a listener called with the source name whenever a source reports changed commands
+func (r *Registry) OnChange(listener func(models.CommandCategory)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, listener)
+}
+
+// NotifyChanged tells the listeners that the commands of a source changed
+func (r *Registry) NotifyChanged(name models.CommandCategory) {
+	r.mu.RLock()
+	listeners := make([]func(models.CommandCategory), len(r.listeners))
+	copy(listeners, r.listeners)
+	r.mu.RUnlock()
+	for _, listener := range listeners {
+		listener(name)
+	}
+}
+
+// Start starts every source, a source failing to start is logged and left out of change notification
+func (r *Registry) Start(ctx context.Context) {
+	for _, name := range r.Sources() {
+		source, _ := r.source(name)
+		notify := func() { r.NotifyChanged(name) }
+		if err := source.Start(ctx, notify); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to start command source %s", name))
+		}
+	}
+}
+
+// Stop stops every source
+func (r *Registry) Stop() {
+	for _, name := range r.Sources() {
+		source, _ := r.source(name)
+		if err := source.Stop(); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to stop command source %s", name))
+		}
+	}
+}
+
+// List returns the commands of a source, unique by trigger ID, and remembers them for Trigger
+func (r *Registry) List(ctx context.Context, name models.CommandCategory) ([]models.CommandRunner, error) {
+	source, err := r.source(name)
+	if err != nil {
+		return nil, err
+	}
+	runners, err := source.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	runners = lo.UniqBy(runners, func(runner models.CommandRunner) string {
+		return runner.GetTriggerID()
+	})
+
+	r.mu.Lock()
+	r.runners[name] = runners
+	r.mu.Unlock()
+	return runners, nil
+}
+
+// Refresh asks a source to rescan its backing store
+func (r *Registry) Refresh(ctx context.Context, name models.CommandCategory) error {
+	source, err := r.source(name)
+	if err != nil {
+		return err
+	}
+	return source.Refresh(ctx)
+}
+
+// Find returns the command of a source with the given trigger ID.
+// Commands are looked up in the last listing, the source is listed again when the ID is unknown.
+func (r *Registry) Find(ctx context.Context, name models.CommandCategory, triggerID string) (models.CommandRunner, error) {
+	source, err := r.source(name)
+	if err != nil {
+		return nil, err
+	}
+	if finder, ok := source.(CommandFinder); ok {
+		return finder.Find(ctx, triggerID)
+	}
+	runner, found := r.findRunner(name, triggerID)
+	if !found {
+		if _, err := r.List(ctx, name); err != nil {
+			return nil, err
+		}
+		runner, found = r.findRunner(name, triggerID)
+	}
+	if !found {
+		return nil, fmt.Errorf("not find runner: %s", triggerID)
+	}
+	return runner, nil
+}
+
+// Trigger runs the command of a source with the given trigger ID.
+// Arguments of a kind the command does not accept are rejected before it runs.
+func (r *Registry) Trigger(ctx context.Context, name models.CommandCategory, triggerID string, arguments models.CommandArguments) error {
+	source, err := r.source(name)
+	if err != nil {
+		return err
+	}
+	runner, err := r.Find(ctx, name, triggerID)
+	if err != nil {
+		return err
+	}
+	if err := models.ValidateArguments(runner.GetMetadata(), arguments); err != nil {
+		return err
+	}
+	return source.Trigger(ctx, runner, arguments)
+}
+
+// RunAction runs one of the Actions of the command of a source with the given trigger ID and returns its result.
+// Arguments are checked like for Trigger.
+func (r *Registry) RunAction(ctx context.Context, name models.CommandCategory, triggerID string, action string, arguments models.CommandArguments) (interface{}, error) {
+	source, err := r.source(name)
+	if err != nil {
+		return nil, err
+	}
+	actionSource, ok := source.(ActionSource)
+	if !ok {
+		return nil, fmt.Errorf("command source '%s' has no actions", name)
+	}
+	runner, err := r.Find(ctx, name, triggerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := models.ValidateAction(runner.GetMetadata(), action); err != nil {
+		return nil, err
+	}
+	if err := models.ValidateArguments(runner.GetMetadata(), arguments); err != nil {
+		return nil, err
+	}
+	return actionSource.RunAction(ctx, runner, action, arguments)
+}
+
+func (r *Registry) findRunner(name models.CommandCategory, triggerID string) (models.CommandRunner, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return lo.Find(r.runners[name], func(runner models.CommandRunner) bool {
+		return runner.GetTriggerID() == triggerID
+	})
+}
+
+// commandsToMaps converts commands to the plain maps handed to the frontend
+func commandsToMaps[T any](commands []T) []interface{} {
+	return lo.Map(commands, func(command T, _ int) interface{} {
+		var m map[string]interface{}
+		data, _ := json.Marshal(command)
+		_ = json.Unmarshal(data, &m)
+		return m
+	})
+}
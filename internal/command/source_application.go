@@ -0,0 +1,305 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"watools/internal/command/application"
+	"watools/internal/command/watcher"
+	"watools/pkg/db"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+	"watools/pkg/utils"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+// applicationSource lists installed applications from the database, kept in sync with the disk by a scan and the app watcher
+type applicationSource struct {
+	mu           sync.Mutex
+	notify       func()
+	watchManager watcher.AppWatchManager
+}
+
+func newApplicationSource() *applicationSource {
+	return &applicationSource{}
+}
+
+func (s *applicationSource) Name() models.CommandCategory {
+	return models.CategoryApplication
+}
+
+func (s *applicationSource) Start(ctx context.Context, notify func()) error {
+	s.notify = notify
+	s.initAppWatcher(ctx)
+	go func() {
+		time.Sleep(30 * time.Second)
+		s.updateApplications(ctx)
+	}()
+	return nil
+}
+
+func (s *applicationSource) Stop() error {
+	if s.watchManager == nil {
+		return nil
+	}
+	if err := s.watchManager.Stop(); err != nil {
+		return fmt.Errorf("failed to stop app watch manager: %w", err)
+	}
+	return nil
+}
+
+func (s *applicationSource) List(ctx context.Context) ([]models.CommandRunner, error) {
+	apps := lo.UniqBy(s.getApplicationCommands(ctx), func(app *models.ApplicationCommand) string {
+		if app.ID != "" {
+			return app.ID
+		}
+		return app.Path
+	})
+	return lo.Map(apps, func(app *models.ApplicationCommand, _ int) models.CommandRunner { return app }), nil
+}
+
+func (s *applicationSource) Refresh(ctx context.Context) error {
+	s.updateApplications(ctx)
+	return nil
+}
+
+func (s *applicationSource) Trigger(_ context.Context, runner models.CommandRunner, arguments models.CommandArguments) error {
+	return runner.OnTrigger(arguments)
+}
+
+func (s *applicationSource) notifyChanged() {
+	if s.notify != nil {
+		s.notify()
+	}
+}
+
+func (s *applicationSource) updateApplications(ctx context.Context) {
+	dbInstance := db.GetWaDB()
+	commands := dbInstance.GetCommands(ctx)
+	exclusions, err := dbInstance.GetCommandExclusions(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get command exclusions")
+	}
+	seen := make(map[string]struct{})
+	var updateCommands []*models.ApplicationCommand
+	var insertCommands []*models.ApplicationCommand
+	var removeCommands []*models.ApplicationCommand
+	var unchangedCommands []*models.ApplicationCommand
+	actionsByParent := lo.GroupBy(lo.Filter(commands, func(command *models.ApplicationCommand, _ int) bool {
+		return command.ParentID != ""
+	}), func(command *models.ApplicationCommand) string {
+		return command.ParentID
+	})
+	for _, command := range commands {
+		if command.ParentID != "" {
+			// actions are synced together with their application
+			continue
+		}
+		seen[command.Path] = struct{}{}
+		if utils.MatchAnyPathGlob(exclusions, command.Path) {
+			logger.Info(fmt.Sprintf("Command is excluded %s", command.Path))
+			removeCommands = append(removeCommands, command)
+			continue
+		}
+		id := command.ID
+		fi, err := os.Stat(command.Path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				logger.Error(err, fmt.Sprintf("Command is not exists %s", command.Path))
+				removeCommands = append(removeCommands, command)
+			} else {
+				logger.Error(err, fmt.Sprintf("Failed to stat command %s", command.Path))
+			}
+			continue
+		}
+		if fi.ModTime().Format(time.DateTime) == command.DirUpdatedAt.Format(time.DateTime) {
+			unchangedCommands = append(unchangedCommands, command)
+			continue
+		}
+		logger.Info(fmt.Sprintf("Update dir updated for command: %s, %s", command.Name, command.Path))
+		parsedCommand, err := application.ParseApplication(command.Path)
+		if err != nil {
+			logger.Error(err, "Failed to parse application")
+			removeCommands = append(removeCommands, command)
+			continue
+		}
+		parsedCommand.ID = id
+		updateCommands = append(updateCommands, parsedCommand)
+	}
+	logger.Info(fmt.Sprintf("Update commands result: updated %d / total %d", len(updateCommands), len(commands)))
+	err = dbInstance.BatchUpdateCommands(ctx, updateCommands)
+	if err != nil {
+		logger.Error(err, "Failed to batch update updated commands to db")
+	}
+	s.syncApplicationActions(ctx, updateCommands)
+	// applications stored before actions were supported, or whose actions changed without a new mod time
+	changedActions := 0
+	for _, command := range unchangedCommands {
+		actions := application.ParseApplicationActions(command)
+		if sameApplicationActions(actions, actionsByParent[command.ID]) {
+			continue
+		}
+		if err := dbInstance.ReplaceCommandActions(ctx, command.ID, actions); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to sync actions of command %s", command.Path))
+			continue
+		}
+		changedActions++
+	}
+	logger.Info(fmt.Sprintf("Delete commands result: removed %d / total %d", len(removeCommands), len(commands)))
+	err = dbInstance.DeleteCommands(ctx, lo.Map(removeCommands, func(command *models.ApplicationCommand, _ int) string {
+		return command.ID
+	}))
+	if err != nil {
+		logger.Error(err, "Failed to delete commands")
+	}
+
+	logger.Info("Checking commands from disk")
+	appPathInfos := application.GetAppPathInfos()
+	for _, appPathInfo := range appPathInfos {
+		if _, exists := seen[appPathInfo.Path]; exists {
+			continue
+		}
+		if utils.MatchAnyPathGlob(exclusions, appPathInfo.Path) {
+			continue
+		}
+		logger.Info(fmt.Sprintf("Adding command from path: %s", appPathInfo.Path))
+		command, err := application.ParseApplication(appPathInfo.Path)
+		if err != nil {
+			logger.Error(err, "Failed to parse application")
+			continue
+		}
+		insertCommands = append(insertCommands, command)
+	}
+	logger.Info(fmt.Sprintf("Scan disk commands result: added %d / total %d", len(insertCommands), len(appPathInfos)))
+	err = dbInstance.BatchInsertCommands(ctx, insertCommands)
+	if err != nil {
+		logger.Error(err, "Failed to batch insert new commands to db")
+	} else {
+		s.syncApplicationActions(ctx, insertCommands)
+	}
+	if len(insertCommands)+len(updateCommands)+len(removeCommands)+changedActions > 0 {
+		s.notifyChanged()
+	}
+}
+
+// syncApplicationActions stores the actions declared by each application as its child commands
+func (s *applicationSource) syncApplicationActions(ctx context.Context, commands []*models.ApplicationCommand) {
+	dbInstance := db.GetWaDB()
+	for _, command := range commands {
+		actions := application.ParseApplicationActions(command)
+		if err := dbInstance.ReplaceCommandActions(ctx, command.ID, actions); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to sync actions of command %s", command.Path))
+		}
+	}
+}
+
+// sameApplicationActions reports whether the parsed actions match the stored child commands
+func sameApplicationActions(actions []*models.ApplicationCommand, stored []*models.ApplicationCommand) bool {
+	if len(actions) != len(stored) {
+		return false
+	}
+	storedNames := lo.SliceToMap(stored, func(command *models.ApplicationCommand) (string, string) {
+		return command.ActionID, command.Name
+	})
+	return lo.EveryBy(actions, func(action *models.ApplicationCommand) bool {
+		name, exists := storedNames[action.ActionID]
+		return exists && name == action.Name
+	})
+}
+
+func (s *applicationSource) getApplicationCommands(ctx context.Context) []*models.ApplicationCommand {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dbInstance := db.GetWaDB()
+	commands := dbInstance.GetCommands(ctx)
+	if len(commands) == 0 {
+		commands, err := application.GetApplications()
+		if err != nil {
+			logger.Error(err, "Failed to get application")
+			return []*models.ApplicationCommand{}
+		}
+		exclusions, err := dbInstance.GetCommandExclusions(ctx)
+		if err != nil {
+			logger.Error(err, "Failed to get command exclusions")
+		}
+		commands = lo.Filter(commands, func(command *models.ApplicationCommand, _ int) bool {
+			return !utils.MatchAnyPathGlob(exclusions, command.Path)
+		})
+		err = dbInstance.BatchInsertCommands(ctx, commands)
+		if err != nil {
+			logger.Error(err, "Failed to batch insert commands")
+		} else {
+			s.syncApplicationActions(ctx, commands)
+		}
+	}
+	for _, command := range commands {
+		if command.IconPath.IsNone() {
+			command.IconPath = mo.Some(application.GetDefaultIconPath())
+		}
+	}
+	if err := dbInstance.FillCommandAliases(ctx, commands); err != nil {
+		logger.Error(err, "Failed to get application aliases")
+	}
+	return commands
+}
+
+// normalizeAlias trims and lowercases an alias, aliases are matched case-insensitively
+func normalizeAlias(alias string) (string, error) {
+	alias = strings.ToLower(strings.TrimSpace(alias))
+	if alias == "" {
+		return "", fmt.Errorf("alias is required")
+	}
+	if strings.ContainsAny(alias, "\t\n") {
+		return "", fmt.Errorf("alias '%s' must not contain tabs or newlines", alias)
+	}
+	return alias, nil
+}
+
+func (s *applicationSource) addAlias(ctx context.Context, id string, alias string) error {
+	alias, err := normalizeAlias(alias)
+	if err != nil {
+		return err
+	}
+	if err := db.GetWaDB().AddCommandAlias(ctx, id, alias); err != nil {
+		return fmt.Errorf("failed to add alias: %w", err)
+	}
+	s.notifyChanged()
+	return nil
+}
+
+func (s *applicationSource) removeAlias(ctx context.Context, id string, alias string) error {
+	alias, err := normalizeAlias(alias)
+	if err != nil {
+		return err
+	}
+	if err := db.GetWaDB().RemoveCommandAlias(ctx, id, alias); err != nil {
+		return fmt.Errorf("failed to remove alias: %w", err)
+	}
+	s.notifyChanged()
+	return nil
+}
+
+func (s *applicationSource) initAppWatcher(ctx context.Context) {
+	eventHandler := watcher.NewDefaultAppEventHandler(ctx, s.notifyChanged)
+
+	watchManager, err := watcher.NewAppWatchManager(eventHandler, ctx)
+	if err != nil {
+		logger.Error(err, "Failed to create app watch manager")
+		return
+	}
+
+	s.watchManager = watchManager
+
+	if err := s.watchManager.Start(); err != nil {
+		logger.Error(err, "Failed to start app watch manager")
+		s.watchManager = nil
+		return
+	}
+
+	logger.Info("App watcher initialized successfully")
+}
@@ -0,0 +1,192 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+	"watools/internal/command/bookmark"
+	"watools/internal/command/watcher"
+	"watools/pkg/db"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+// bookmarkImportInterval limits how often a profile is imported, Firefox writes places.sqlite on every visit
+const bookmarkImportInterval = 30 * time.Second
+
+// bookmarkSource lists the bookmarks imported from the browser profiles.
+// Profiles are imported into the database on start and again when their bookmark file changes.
+type bookmarkSource struct {
+	ctx    context.Context
+	notify func()
+
+	mu         sync.Mutex
+	stores     []bookmark.Store
+	importedAt map[string]time.Time
+	pending    map[string]*time.Timer
+	dirWatcher *watcher.DirWatcher
+}
+
+func newBookmarkSource() *bookmarkSource {
+	return &bookmarkSource{
+		importedAt: make(map[string]time.Time),
+		pending:    make(map[string]*time.Timer),
+	}
+}
+
+func (s *bookmarkSource) Name() models.CommandCategory {
+	return models.CategoryBookmark
+}
+
+func (s *bookmarkSource) Start(ctx context.Context, notify func()) error {
+	s.ctx = ctx
+	s.notify = notify
+	go func() {
+		if s.importAll() {
+			s.notify()
+		}
+	}()
+	return nil
+}
+
+func (s *bookmarkSource) Stop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, timer := range s.pending {
+		timer.Stop()
+	}
+	if s.dirWatcher == nil {
+		return nil
+	}
+	return s.dirWatcher.Stop()
+}
+
+func (s *bookmarkSource) List(ctx context.Context) ([]models.CommandRunner, error) {
+	bookmarks, err := db.GetWaDB().GetBookmarks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(bookmarks, func(bookmark *models.BookmarkCommand, _ int) models.CommandRunner { return bookmark }), nil
+}
+
+// Refresh looks for new profiles and imports every profile again
+func (s *bookmarkSource) Refresh(_ context.Context) error {
+	s.importAll()
+	if s.notify != nil {
+		s.notify()
+	}
+	return nil
+}
+
+func (s *bookmarkSource) Trigger(_ context.Context, runner models.CommandRunner, arguments models.CommandArguments) error {
+	return runner.OnTrigger(arguments)
+}
+
+// importAll imports every profile found, drops the bookmarks of profiles that are gone and watches the profiles
+func (s *bookmarkSource) importAll() bool {
+	stores := bookmark.FindStores()
+	changed := false
+	for _, store := range stores {
+		if s.importStore(store) {
+			changed = true
+		}
+	}
+
+	dbInstance := db.GetWaDB()
+	sources, err := dbInstance.GetBookmarkSources(s.ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get bookmark sources")
+	}
+	for _, source := range sources {
+		if lo.ContainsBy(stores, func(store bookmark.Store) bool { return store.Path == source }) {
+			continue
+		}
+		if err := dbInstance.DeleteBookmarksBySource(s.ctx, source); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to delete bookmarks of %s", source))
+			continue
+		}
+		changed = true
+	}
+
+	s.watch(stores)
+	return changed
+}
+
+// importStore replaces the bookmarks of one profile, it reports whether the database was changed
+func (s *bookmarkSource) importStore(store bookmark.Store) bool {
+	bookmarks, err := bookmark.Read(store)
+	if err != nil {
+		logger.Error(err, fmt.Sprintf("Failed to read %s bookmarks of profile %s", store.Browser, store.Profile))
+		return false
+	}
+	commands := lo.Map(bookmarks, func(item bookmark.Bookmark, _ int) *models.BookmarkCommand {
+		favicon := mo.None[string]()
+		if item.Favicon != "" {
+			favicon = mo.Some(item.Favicon)
+		}
+		return models.NewBookmarkCommand(store.Path, store.Browser, store.Profile, item.Title, item.URL, item.FolderPath, favicon)
+	})
+	if err := db.GetWaDB().ReplaceBookmarks(s.ctx, store.Path, commands); err != nil {
+		logger.Error(err, fmt.Sprintf("Failed to save %s bookmarks of profile %s", store.Browser, store.Profile))
+		return false
+	}
+	s.mu.Lock()
+	s.importedAt[store.Path] = time.Now()
+	s.mu.Unlock()
+	logger.Info(fmt.Sprintf("Imported %d %s bookmarks of profile %s", len(commands), store.Browser, store.Profile))
+	return true
+}
+
+// watch replaces the watcher with one over the directories of stores
+func (s *bookmarkSource) watch(stores []bookmark.Store) {
+	dirs := lo.Uniq(lo.Map(stores, func(store bookmark.Store, _ int) string { return filepath.Dir(store.Path) }))
+	dirWatcher, err := watcher.NewDirWatcher(dirs, bookmark.IsStoreFile, time.Second, s.onStoreChanged)
+	if err != nil {
+		logger.Error(err, "Failed to watch bookmark files")
+		return
+	}
+	if err := dirWatcher.Start(); err != nil {
+		logger.Error(err, "Failed to watch bookmark files")
+		return
+	}
+
+	s.mu.Lock()
+	previous := s.dirWatcher
+	s.stores = stores
+	s.dirWatcher = dirWatcher
+	s.mu.Unlock()
+	if previous != nil {
+		if err := previous.Stop(); err != nil {
+			logger.Error(err, "Failed to stop bookmark watcher")
+		}
+	}
+}
+
+// onStoreChanged imports the changed profiles, at most once per bookmarkImportInterval each
+func (s *bookmarkSource) onStoreChanged(paths []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, path := range paths {
+		store, ok := bookmark.StoreOf(s.stores, path)
+		if !ok {
+			continue
+		}
+		if _, scheduled := s.pending[store.Path]; scheduled {
+			continue
+		}
+		delay := max(time.Until(s.importedAt[store.Path].Add(bookmarkImportInterval)), 0)
+		s.pending[store.Path] = time.AfterFunc(delay, func() {
+			s.mu.Lock()
+			delete(s.pending, store.Path)
+			s.mu.Unlock()
+			if s.importStore(store) {
+				s.notify()
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"watools/pkg/db"
+	"watools/pkg/models"
+)
+
+// fileSource runs the actions of the indexed files.
+// The index is too large to list, files are found by SearchFilesApi and looked up by trigger ID here,
+// paths that are not in the index are refused.
+type fileSource struct {
+	reveal         func(path string)
+	writeClipboard func(text string) error
+}
+
+func newFileSource() *fileSource {
+	return &fileSource{}
+}
+
+func (s *fileSource) Name() models.CommandCategory {
+	return models.CategoryFile
+}
+
+func (s *fileSource) Start(_ context.Context, _ func()) error {
+	return nil
+}
+
+func (s *fileSource) Stop() error {
+	return nil
+}
+
+// List is empty, indexed files are searched apart from the commands
+func (s *fileSource) List(_ context.Context) ([]models.CommandRunner, error) {
+	return []models.CommandRunner{}, nil
+}
+
+// Refresh does nothing, the index is rebuilt by RebuildFileIndexApi
+func (s *fileSource) Refresh(_ context.Context) error {
+	return nil
+}
+
+// Find returns the command of an indexed file from its trigger ID
+func (s *fileSource) Find(ctx context.Context, triggerID string) (models.CommandRunner, error) {
+	path, ok := strings.CutPrefix(triggerID, models.FileTriggerID(""))
+	if !ok || path == "" {
+		return nil, fmt.Errorf("not find runner: %s", triggerID)
+	}
+	path = filepath.Clean(path)
+	indexed, err := db.GetWaDB().GetIndexedFile(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	file, ok := indexed.Get()
+	if !ok {
+		return nil, fmt.Errorf("'%s' is not in the file index", path)
+	}
+	return models.NewFileCommand(file, s.runAction), nil
+}
+
+func (s *fileSource) Trigger(_ context.Context, runner models.CommandRunner, arguments models.CommandArguments) error {
+	return runner.OnTrigger(arguments)
+}
+
+// RunAction runs an action on an indexed file
+func (s *fileSource) RunAction(_ context.Context, runner models.CommandRunner, action string, _ models.CommandArguments) (interface{}, error) {
+	command, ok := runner.(*models.FileCommand)
+	if !ok {
+		return nil, fmt.Errorf("command '%s' is not a file", runner.GetTriggerID())
+	}
+	return nil, command.RunAction(models.FileAction(action))
+}
+
+func (s *fileSource) runAction(command *models.FileCommand, action models.FileAction) error {
+	switch action {
+	case models.FileActionOpen:
+		return models.OpenFile(command.Path)
+	case models.FileActionReveal:
+		if s.reveal == nil {
+			return fmt.Errorf("revealing files is not supported")
+		}
+		s.reveal(command.Path)
+		return nil
+	case models.FileActionCopyPath:
+		if s.writeClipboard == nil {
+			return fmt.Errorf("clipboard is not available")
+		}
+		return s.writeClipboard(command.Path)
+	default:
+		return fmt.Errorf("unknown file action '%s'", action)
+	}
+}
@@ -0,0 +1,376 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"watools/internal/command/gitrepo"
+	"watools/internal/command/project"
+	"watools/pkg/db"
+	"watools/pkg/gitignore"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+)
+
+const (
+	// gitRepoInitializedKey marks in metadata that the default roots and ignores were stored
+	gitRepoInitializedKey = "git_repo.initialized"
+	// gitRepoDefaultDepth is how deep roots are searched when no depth is given
+	gitRepoDefaultDepth = 3
+	gitRepoMaxDepth     = 10
+	// gitRepoScanDelay keeps the first scan away from the launcher startup, the cached repositories are listed meanwhile
+	gitRepoScanDelay = 10 * time.Second
+	// gitRepoPollInterval is how often HEAD and the index of every repository are checked for a new branch or commit
+	gitRepoPollInterval = 10 * time.Second
+	// gitRepoStatusTTL bounds how long unstaged changes are trusted, editing a file does not touch HEAD or the index
+	gitRepoStatusTTL = time.Minute
+)
+
+// defaultGitRepoRoots are created below the home directory on first start when they exist
+var defaultGitRepoRoots = []string{"code", "src", "projects", "dev", "workspace", "repos"}
+
+var defaultGitRepoIgnores = []string{"node_modules", "vendor", ".cache"}
+
+type gitRepoStatus struct {
+	stamp  string
+	status gitrepo.Status
+	readAt time.Time
+}
+
+// gitRepoSource lists the repositories found below the configured roots.
+// Scans are cached in the database and run in the background. The branch and unstaged changes are read from the
+// repository files by the poll goroutine, again when HEAD or the index change or the TTL passed, and List only
+// serves the last read.
+type gitRepoSource struct {
+	ctx            context.Context
+	cancel         context.CancelFunc
+	notify         func()
+	reveal         func(path string)
+	writeClipboard func(text string) error
+
+	scanMu   sync.Mutex
+	statusMu sync.Mutex
+	mu       sync.Mutex
+	repos    []models.GitRepo
+	loaded   bool
+	statuses map[string]gitRepoStatus
+}
+
+func newGitRepoSource() *gitRepoSource {
+	return &gitRepoSource{statuses: make(map[string]gitRepoStatus)}
+}
+
+func (s *gitRepoSource) Name() models.CommandCategory {
+	return models.CategoryGitRepo
+}
+
+func (s *gitRepoSource) Start(ctx context.Context, notify func()) error {
+	s.notify = notify
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	go func() {
+		if err := s.initDefaults(); err != nil {
+			logger.Error(err, "Failed to store the default git repo roots")
+		}
+		if err := s.reload(s.ctx); err != nil {
+			logger.Error(err, "Failed to load git repos")
+		}
+		if s.refreshStatuses() {
+			s.notify()
+		}
+		select {
+		case <-time.After(gitRepoScanDelay):
+			s.scan()
+		case <-s.ctx.Done():
+			return
+		}
+		ticker := time.NewTicker(gitRepoPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+				if s.refreshStatuses() {
+					s.notify()
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *gitRepoSource) Stop() error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	return nil
+}
+
+// List returns the cached repositories that still exist with the branch and unstaged changes last read,
+// repositories whose status was not read yet are listed without them
+func (s *gitRepoSource) List(ctx context.Context) ([]models.CommandRunner, error) {
+	s.mu.Lock()
+	loaded := s.loaded
+	s.mu.Unlock()
+	if !loaded {
+		if err := s.reload(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	runners := make([]models.CommandRunner, 0, len(s.repos))
+	for _, repo := range s.repos {
+		if _, ok := gitrepo.GitDir(repo.Path); !ok {
+			continue
+		}
+		status := s.statuses[repo.Path].status
+		runners = append(runners, models.NewGitRepoCommand(repo, gitrepo.BrowserURL(repo.RemoteURL),
+			status.Branch, status.Detached, status.Unstaged, s.runAction))
+	}
+	return runners, nil
+}
+
+// Refresh scans the roots again in the background
+func (s *gitRepoSource) Refresh(_ context.Context) error {
+	go s.scan()
+	return nil
+}
+
+func (s *gitRepoSource) Trigger(_ context.Context, runner models.CommandRunner, arguments models.CommandArguments) error {
+	return runner.OnTrigger(arguments)
+}
+
+// RunAction runs an action on a listed repository
+func (s *gitRepoSource) RunAction(_ context.Context, runner models.CommandRunner, action string, _ models.CommandArguments) (interface{}, error) {
+	command, ok := runner.(*models.GitRepoCommand)
+	if !ok {
+		return nil, fmt.Errorf("command '%s' is not a git repo", runner.GetTriggerID())
+	}
+	return nil, command.RunAction(models.GitRepoAction(action))
+}
+
+func (s *gitRepoSource) runAction(command *models.GitRepoCommand, action models.GitRepoAction) error {
+	switch action {
+	case models.GitRepoActionOpenEditor:
+		editor := project.Editor(command.Path, project.Load())
+		if editor == "" {
+			return fmt.Errorf("no editor found to open '%s'", command.Path)
+		}
+		return command.OpenWith(editor)
+	case models.GitRepoActionOpenTerminal:
+		return command.OpenTerminal()
+	case models.GitRepoActionReveal:
+		if s.reveal == nil {
+			return fmt.Errorf("revealing folders is not supported")
+		}
+		s.reveal(command.Path)
+		return nil
+	case models.GitRepoActionCopyPath:
+		if s.writeClipboard == nil {
+			return fmt.Errorf("clipboard is not available")
+		}
+		return s.writeClipboard(command.Path)
+	case models.GitRepoActionOpenRemote:
+		return command.OpenRemote()
+	default:
+		return fmt.Errorf("unknown git repo action '%s'", action)
+	}
+}
+
+// refreshStatuses reads the branch and unstaged changes of every repository again whose HEAD or index changed or
+// whose TTL passed, it reports whether a status changed
+func (s *gitRepoSource) refreshStatuses() bool {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+	s.mu.Lock()
+	repos := s.repos
+	s.mu.Unlock()
+
+	changed := false
+	for _, repo := range repos {
+		if s.ctx.Err() != nil {
+			return changed
+		}
+		stamp := gitrepo.Stamp(repo.Path)
+		if stamp == "" {
+			continue
+		}
+		s.mu.Lock()
+		cached, ok := s.statuses[repo.Path]
+		s.mu.Unlock()
+		if ok && cached.stamp == stamp && time.Since(cached.readAt) < gitRepoStatusTTL {
+			continue
+		}
+		status, err := gitrepo.ReadStatus(repo.Path)
+		if err != nil {
+			logger.Debug(fmt.Sprintf("Failed to read the status of %s: %v", repo.Path, err))
+		}
+		changed = changed || !ok || status != cached.status
+		s.mu.Lock()
+		s.statuses[repo.Path] = gitRepoStatus{stamp: stamp, status: status, readAt: time.Now()}
+		s.mu.Unlock()
+	}
+	return changed
+}
+
+func (s *gitRepoSource) reload(ctx context.Context) error {
+	repos, err := db.GetWaDB().GetGitRepos(ctx)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.repos = repos
+	s.loaded = true
+	// forget the statuses of repositories that are gone
+	statuses := make(map[string]gitRepoStatus, len(repos))
+	for _, repo := range repos {
+		if status, ok := s.statuses[repo.Path]; ok {
+			statuses[repo.Path] = status
+		}
+	}
+	s.statuses = statuses
+	return nil
+}
+
+// scan searches every root for repositories and replaces the cached ones, roots that are missing keep their cache
+func (s *gitRepoSource) scan() {
+	s.scanMu.Lock()
+	defer s.scanMu.Unlock()
+
+	dbInstance := db.GetWaDB()
+	roots, err := dbInstance.GetGitRepoRoots(s.ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get git repo roots")
+		return
+	}
+	ignores, err := dbInstance.GetGitRepoIgnores(s.ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get git repo ignores")
+		return
+	}
+	started := time.Now()
+	count := 0
+	for _, root := range roots {
+		if info, err := os.Stat(root.Path); err != nil || !info.IsDir() {
+			continue
+		}
+		matcher := gitignore.Matcher{}.With(gitignore.ParseLines(root.Path, ignores))
+		found, err := gitrepo.Scan(s.ctx, root.Path, root.MaxDepth, matcher)
+		if err != nil {
+			if s.ctx.Err() != nil {
+				return
+			}
+			logger.Error(err, fmt.Sprintf("Failed to scan %s for git repos", root.Path))
+			continue
+		}
+		repos := make([]models.GitRepo, 0, len(found))
+		for _, repo := range found {
+			repos = append(repos, models.GitRepo{Path: repo.Path, Root: root.Path, Name: repo.Name, RemoteURL: repo.RemoteURL})
+		}
+		if err := dbInstance.ReplaceGitRepos(s.ctx, root.Path, repos); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to save the git repos of %s", root.Path))
+			continue
+		}
+		count += len(repos)
+	}
+	logger.Info(fmt.Sprintf("Found %d git repos in %s", count, time.Since(started)))
+
+	if err := s.reload(s.ctx); err != nil {
+		logger.Error(err, "Failed to load git repos")
+		return
+	}
+	s.refreshStatuses()
+	if s.notify != nil {
+		s.notify()
+	}
+}
+
+func (s *gitRepoSource) initDefaults() error {
+	dbInstance := db.GetWaDB()
+	initialized, err := dbInstance.GetMetadata(s.ctx, gitRepoInitializedKey)
+	if err != nil || initialized.IsPresent() {
+		return err
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get home dir: %w", err)
+	}
+	for _, name := range defaultGitRepoRoots {
+		root := filepath.Join(homeDir, name)
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			continue
+		}
+		if err := dbInstance.AddGitRepoRoot(s.ctx, root, gitRepoDefaultDepth); err != nil {
+			return err
+		}
+	}
+	for _, pattern := range defaultGitRepoIgnores {
+		if err := dbInstance.AddGitRepoIgnore(s.ctx, pattern); err != nil {
+			return err
+		}
+	}
+	return dbInstance.SetMetadata(s.ctx, gitRepoInitializedKey, "true")
+}
+
+// addRoot searches an existing directory for repositories, maxDepth is gitRepoDefaultDepth when it is 0
+func (s *gitRepoSource) addRoot(ctx context.Context, path string, maxDepth int) error {
+	root, err := gitrepo.NormalizeRoot(path)
+	if err != nil {
+		return err
+	}
+	if maxDepth == 0 {
+		maxDepth = gitRepoDefaultDepth
+	}
+	if maxDepth < 1 || maxDepth > gitRepoMaxDepth {
+		return fmt.Errorf("depth must be between 1 and %d", gitRepoMaxDepth)
+	}
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		return fmt.Errorf("'%s' is not a directory", root)
+	}
+	if err := db.GetWaDB().AddGitRepoRoot(ctx, root, maxDepth); err != nil {
+		return fmt.Errorf("failed to add root: %w", err)
+	}
+	go s.scan()
+	return nil
+}
+
+// removeRoot stops searching a root and forgets the repositories found below it
+func (s *gitRepoSource) removeRoot(ctx context.Context, path string) error {
+	root, err := gitrepo.NormalizeRoot(path)
+	if err != nil {
+		return err
+	}
+	if err := db.GetWaDB().RemoveGitRepoRoot(ctx, root); err != nil {
+		return fmt.Errorf("failed to remove root: %w", err)
+	}
+	go s.scan()
+	return nil
+}
+
+// addIgnore skips directories matching a .gitignore pattern below every root
+func (s *gitRepoSource) addIgnore(ctx context.Context, pattern string) error {
+	pattern = strings.TrimSpace(pattern)
+	if !gitignore.ValidatePattern(pattern) {
+		return fmt.Errorf("'%s' is not an ignore pattern", pattern)
+	}
+	if err := db.GetWaDB().AddGitRepoIgnore(ctx, pattern); err != nil {
+		return fmt.Errorf("failed to add ignore pattern: %w", err)
+	}
+	go s.scan()
+	return nil
+}
+
+func (s *gitRepoSource) removeIgnore(ctx context.Context, pattern string) error {
+	if err := db.GetWaDB().RemoveGitRepoIgnore(ctx, strings.TrimSpace(pattern)); err != nil {
+		return fmt.Errorf("failed to remove ignore pattern: %w", err)
+	}
+	go s.scan()
+	return nil
+}
@@ -0,0 +1,73 @@
+package command
+
+import (
+	"context"
+	"sync"
+	"watools/internal/command/operator"
+	"watools/pkg/models"
+
+	"github.com/samber/lo"
+)
+
+// operationSource lists the built-in system operations of the current platform.
+// Detecting them runs external tools on Linux, so they are detected once and again on Refresh.
+type operationSource struct {
+	mu         sync.Mutex
+	operations []*models.OperationCommand
+	loaded     bool
+	notify     func()
+}
+
+func newOperationSource() *operationSource {
+	return &operationSource{}
+}
+
+func (s *operationSource) Name() models.CommandCategory {
+	return models.CategoryOperation
+}
+
+func (s *operationSource) Start(_ context.Context, notify func()) error {
+	s.notify = notify
+	return nil
+}
+
+func (s *operationSource) Stop() error {
+	return nil
+}
+
+func (s *operationSource) List(_ context.Context) ([]models.CommandRunner, error) {
+	s.mu.Lock()
+	loaded := s.loaded
+	s.mu.Unlock()
+	if !loaded {
+		s.reload()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return lo.Map(s.operations, func(operation *models.OperationCommand, _ int) models.CommandRunner {
+		return operation
+	}), nil
+}
+
+// Refresh detects the operations again, such as after a tool was installed or the session changed
+func (s *operationSource) Refresh(_ context.Context) error {
+	s.reload()
+	if s.notify != nil {
+		s.notify()
+	}
+	return nil
+}
+
+func (s *operationSource) Trigger(_ context.Context, runner models.CommandRunner, arguments models.CommandArguments) error {
+	return runner.OnTrigger(arguments)
+}
+
+func (s *operationSource) reload() {
+	operations := operator.GetOperations()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.operations = operations
+	s.loaded = true
+}
@@ -0,0 +1,178 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"watools/internal/command/process"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+
+	"github.com/samber/lo"
+)
+
+// processPollInterval is how often the processes are sampled, it is also the window the CPU usage is measured over
+const processPollInterval = 5 * time.Second
+
+// processSource lists the running processes, busiest first.
+// The list is sampled periodically, notify is only called when processes start, exit or change their ports,
+// so that the search index is not rebuilt for every CPU sample.
+type processSource struct {
+	sampler        *process.Sampler
+	writeClipboard func(text string) error
+
+	mu        sync.Mutex
+	processes []*models.ProcessCommand
+	loaded    bool
+	signature string
+	notify    func()
+	cancel    context.CancelFunc
+}
+
+func newProcessSource() *processSource {
+	return &processSource{sampler: process.NewSampler()}
+}
+
+func (s *processSource) Name() models.CommandCategory {
+	return models.CategoryProcess
+}
+
+func (s *processSource) Start(ctx context.Context, notify func()) error {
+	s.notify = notify
+	ctx, s.cancel = context.WithCancel(ctx)
+	go func() {
+		ticker := time.NewTicker(processPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if s.reload() {
+					s.notify()
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *processSource) Stop() error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	return nil
+}
+
+func (s *processSource) List(_ context.Context) ([]models.CommandRunner, error) {
+	s.mu.Lock()
+	loaded := s.loaded
+	s.mu.Unlock()
+	if !loaded {
+		s.reload()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return lo.Map(s.processes, func(command *models.ProcessCommand, _ int) models.CommandRunner { return command }), nil
+}
+
+func (s *processSource) Refresh(_ context.Context) error {
+	s.reload()
+	if s.notify != nil {
+		s.notify()
+	}
+	return nil
+}
+
+// Trigger copies the PID of the process, ending it needs one of the signal actions
+func (s *processSource) Trigger(_ context.Context, runner models.CommandRunner, arguments models.CommandArguments) error {
+	return runner.OnTrigger(arguments)
+}
+
+// RunAction copies the PID of a listed process or signals it
+func (s *processSource) RunAction(_ context.Context, runner models.CommandRunner, action string, _ models.CommandArguments) (interface{}, error) {
+	command, ok := runner.(*models.ProcessCommand)
+	if !ok {
+		return nil, fmt.Errorf("command '%s' is not a process", runner.GetTriggerID())
+	}
+	if err := command.RunAction(models.ProcessAction(action)); err != nil {
+		return nil, err
+	}
+	if lo.Contains(models.ProcessSignals, models.ProcessAction(action)) {
+		s.refreshSoon()
+	}
+	return nil, nil
+}
+
+func (s *processSource) copyPID(pid int) error {
+	if s.writeClipboard == nil {
+		return fmt.Errorf("clipboard is not available")
+	}
+	return s.writeClipboard(strconv.Itoa(pid))
+}
+
+// refreshSoon gives a signalled process a moment to exit before the list is sampled again
+func (s *processSource) refreshSoon() {
+	time.AfterFunc(500*time.Millisecond, func() {
+		if s.reload() && s.notify != nil {
+			s.notify()
+		}
+	})
+}
+
+// reload samples the processes, it reports whether a process started, exited or changed its ports
+func (s *processSource) reload() bool {
+	processes, err := s.sampler.List()
+	if err != nil {
+		logger.Error(err, "Failed to list processes")
+	}
+	sort.SliceStable(processes, func(i, j int) bool {
+		if processes[i].CPUPercent != processes[j].CPUPercent {
+			return processes[i].CPUPercent > processes[j].CPUPercent
+		}
+		return processes[i].MemoryBytes > processes[j].MemoryBytes
+	})
+	commands := make([]*models.ProcessCommand, 0, len(processes))
+	for _, p := range processes {
+		var signals []models.ProcessAction
+		if !process.IsProtected(p.PID) {
+			signals = models.ProcessSignals
+		}
+		pid, startedAt := p.PID, p.StartedAt
+		commands = append(commands, models.NewProcessCommand(p.PID, p.Name, p.CommandLine, p.CPUPercent, p.MemoryBytes, p.Ports, signals,
+			func(action models.ProcessAction) error {
+				if action == models.ProcessActionCopyPID {
+					return s.copyPID(pid)
+				}
+				return process.Signal(pid, startedAt, action)
+			}))
+	}
+	signature := processSignature(processes)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	changed := !s.loaded || signature != s.signature
+	s.processes = commands
+	s.signature = signature
+	s.loaded = true
+	return changed
+}
+
+// processSignature identifies the running processes and their ports in PID order, so CPU changes leave it unchanged
+func processSignature(processes []process.Process) string {
+	byPID := slices.Clone(processes)
+	sort.Slice(byPID, func(i, j int) bool {
+		return byPID[i].PID < byPID[j].PID
+	})
+	var signature strings.Builder
+	for _, p := range byPID {
+		fmt.Fprintf(&signature, "%d %s %v\n", p.PID, p.Name, p.Ports)
+	}
+	return signature.String()
+}
@@ -0,0 +1,26 @@
+package command
+
+import (
+	"testing"
+	"watools/internal/command/process"
+)
+
+func TestProcessSignatureIgnoresCPUOrder(t *testing.T) {
+	t.Parallel()
+
+	busy := process.Process{PID: 20, Name: "node", CPUPercent: 90, Ports: []int{3000}}
+	idle := process.Process{PID: 10, Name: "bash", CPUPercent: 1}
+	signature := processSignature([]process.Process{busy, idle})
+
+	busy.CPUPercent, idle.CPUPercent = 0, 50
+	if processSignature([]process.Process{idle, busy}) != signature {
+		t.Fatal("expected a CPU change to keep the signature")
+	}
+	busy.Ports = []int{3000, 3001}
+	if processSignature([]process.Process{idle, busy}) == signature {
+		t.Fatal("expected a port change to change the signature")
+	}
+	if processSignature([]process.Process{idle}) == signature {
+		t.Fatal("expected an exited process to change the signature")
+	}
+}
@@ -0,0 +1,95 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"watools/internal/command/project"
+	"watools/internal/command/watcher"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+
+	"github.com/samber/lo"
+)
+
+// projectSource lists the recent projects of VS Code and the JetBrains IDEs and reloads them when a store changes
+type projectSource struct {
+	mu         sync.Mutex
+	projects   []*models.ProjectCommand
+	loaded     bool
+	notify     func()
+	dirWatcher *watcher.DirWatcher
+}
+
+func newProjectSource() *projectSource {
+	return &projectSource{}
+}
+
+func (s *projectSource) Name() models.CommandCategory {
+	return models.CategoryProject
+}
+
+func (s *projectSource) Start(_ context.Context, notify func()) error {
+	s.notify = notify
+	dirWatcher, err := watcher.NewDirWatcher(project.StoreDirs(), project.IsStoreFile, time.Second, func(paths []string) {
+		logger.Debug(fmt.Sprintf("Recent projects changed: %v", paths))
+		s.reload()
+		s.notify()
+	})
+	if err != nil {
+		return err
+	}
+	if err := dirWatcher.Start(); err != nil {
+		return err
+	}
+	s.dirWatcher = dirWatcher
+	return nil
+}
+
+func (s *projectSource) Stop() error {
+	if s.dirWatcher == nil {
+		return nil
+	}
+	return s.dirWatcher.Stop()
+}
+
+// List returns the loaded projects, folders deleted since a store last changed are left out
+func (s *projectSource) List(_ context.Context) ([]models.CommandRunner, error) {
+	s.mu.Lock()
+	loaded := s.loaded
+	s.mu.Unlock()
+	if !loaded {
+		s.reload()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return lo.FilterMap(s.projects, func(command *models.ProjectCommand, _ int) (models.CommandRunner, bool) {
+		return command, project.Exists(command.Path)
+	}), nil
+}
+
+// Refresh reads the stores again, IDE versions installed since the start are only watched after a restart
+func (s *projectSource) Refresh(_ context.Context) error {
+	s.reload()
+	if s.notify != nil {
+		s.notify()
+	}
+	return nil
+}
+
+func (s *projectSource) Trigger(_ context.Context, runner models.CommandRunner, arguments models.CommandArguments) error {
+	return runner.OnTrigger(arguments)
+}
+
+func (s *projectSource) reload() {
+	projects := project.Load()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.projects = lo.Map(projects, func(item project.Project, _ int) *models.ProjectCommand {
+		return models.NewProjectCommand(item.Path, item.Name, item.IDE, item.Launcher, item.OpenedAt)
+	})
+	s.loaded = true
+}
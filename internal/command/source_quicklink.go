@@ -0,0 +1,132 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"watools/pkg/db"
+	"watools/pkg/models"
+	"watools/pkg/placeholder"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+// quicklinkSource lists the quicklinks stored in the database
+type quicklinkSource struct {
+	notify        func()
+	clipboardText func() (string, error)
+}
+
+func newQuicklinkSource() *quicklinkSource {
+	return &quicklinkSource{}
+}
+
+func (s *quicklinkSource) Name() models.CommandCategory {
+	return models.CategoryQuicklink
+}
+
+func (s *quicklinkSource) Start(_ context.Context, notify func()) error {
+	s.notify = notify
+	return nil
+}
+
+func (s *quicklinkSource) Stop() error {
+	return nil
+}
+
+func (s *quicklinkSource) List(ctx context.Context) ([]models.CommandRunner, error) {
+	quicklinks, err := db.GetWaDB().GetQuicklinks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(quicklinks, func(quicklink *models.QuicklinkCommand, _ int) models.CommandRunner { return quicklink }), nil
+}
+
+// Refresh only announces a change, quicklinks are read from the database on every listing
+func (s *quicklinkSource) Refresh(_ context.Context) error {
+	s.notifyChanged()
+	return nil
+}
+
+// Trigger opens the quicklink, {clipboard} reads the live clipboard when no snapshot is passed
+func (s *quicklinkSource) Trigger(_ context.Context, runner models.CommandRunner, arguments models.CommandArguments) error {
+	quicklink, ok := runner.(*models.QuicklinkCommand)
+	if !ok || arguments.Clipboard.IsPresent() {
+		return runner.OnTrigger(arguments)
+	}
+	return quicklink.Open(arguments.Text, s.clipboardText)
+}
+
+func (s *quicklinkSource) notifyChanged() {
+	if s.notify != nil {
+		s.notify()
+	}
+}
+
+// validateQuicklink checks the fields of a quicklink and that its keyword is not used by another one
+func validateQuicklink(ctx context.Context, quicklink *models.QuicklinkCommand) error {
+	if strings.TrimSpace(quicklink.Name) == "" {
+		return fmt.Errorf("quicklink name is required")
+	}
+	if strings.ContainsAny(quicklink.Keyword, " \t\n") {
+		return fmt.Errorf("quicklink keyword '%s' must not contain spaces", quicklink.Keyword)
+	}
+	_, err := placeholder.ExpandURL(quicklink.URL, placeholder.URLValues{
+		Argument:  "test",
+		Clipboard: func() (string, error) { return "", nil },
+		Now:       time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("invalid quicklink url: %w", err)
+	}
+	if quicklink.Keyword == "" {
+		return nil
+	}
+	existing, err := db.GetWaDB().GetQuicklinks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if other.ID != quicklink.ID && strings.EqualFold(other.Keyword, quicklink.Keyword) {
+			return fmt.Errorf("keyword '%s' is already used by quicklink '%s'", quicklink.Keyword, other.Name)
+		}
+	}
+	return nil
+}
+
+func (s *quicklinkSource) create(ctx context.Context, name string, keyword string, url string, icon string, targetApp string) (*models.QuicklinkCommand, error) {
+	quicklink := models.NewQuicklinkCommand(strings.TrimSpace(name), strings.TrimSpace(keyword), strings.TrimSpace(url), icon, targetApp, mo.None[string]())
+	if err := validateQuicklink(ctx, quicklink); err != nil {
+		return nil, err
+	}
+	if err := db.GetWaDB().CreateQuicklink(ctx, quicklink); err != nil {
+		return nil, fmt.Errorf("failed to create quicklink: %w", err)
+	}
+	s.notifyChanged()
+	return quicklink, nil
+}
+
+func (s *quicklinkSource) update(ctx context.Context, id string, name string, keyword string, url string, icon string, targetApp string) (*models.QuicklinkCommand, error) {
+	if _, err := db.GetWaDB().GetQuicklink(ctx, id); err != nil {
+		return nil, err
+	}
+	quicklink := models.NewQuicklinkCommand(strings.TrimSpace(name), strings.TrimSpace(keyword), strings.TrimSpace(url), icon, targetApp, mo.Some(id))
+	if err := validateQuicklink(ctx, quicklink); err != nil {
+		return nil, err
+	}
+	if err := db.GetWaDB().UpdateQuicklink(ctx, quicklink); err != nil {
+		return nil, fmt.Errorf("failed to update quicklink: %w", err)
+	}
+	s.notifyChanged()
+	return quicklink, nil
+}
+
+func (s *quicklinkSource) delete(ctx context.Context, id string) error {
+	if err := db.GetWaDB().DeleteQuicklink(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete quicklink: %w", err)
+	}
+	s.notifyChanged()
+	return nil
+}
@@ -0,0 +1,97 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"watools/internal/command/recent"
+	"watools/internal/command/watcher"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+
+	"github.com/samber/lo"
+)
+
+// recentDocumentSource lists the recent documents of the platform and reloads them when the store changes
+type recentDocumentSource struct {
+	mu         sync.Mutex
+	documents  []*models.RecentDocumentCommand
+	loaded     bool
+	notify     func()
+	dirWatcher *watcher.DirWatcher
+}
+
+func newRecentDocumentSource() *recentDocumentSource {
+	return &recentDocumentSource{}
+}
+
+func (s *recentDocumentSource) Name() models.CommandCategory {
+	return models.CategoryRecentDocument
+}
+
+func (s *recentDocumentSource) Start(_ context.Context, notify func()) error {
+	s.notify = notify
+	dirWatcher, err := watcher.NewDirWatcher(recent.StoreDirs(), recent.IsStoreFile, 300*time.Millisecond, func(paths []string) {
+		logger.Debug(fmt.Sprintf("Recent documents changed: %v", paths))
+		s.reload()
+		s.notify()
+	})
+	if err != nil {
+		return err
+	}
+	if err := dirWatcher.Start(); err != nil {
+		return err
+	}
+	s.dirWatcher = dirWatcher
+	return nil
+}
+
+func (s *recentDocumentSource) Stop() error {
+	if s.dirWatcher == nil {
+		return nil
+	}
+	return s.dirWatcher.Stop()
+}
+
+// List returns the loaded documents, files deleted since the store last changed are left out
+func (s *recentDocumentSource) List(_ context.Context) ([]models.CommandRunner, error) {
+	s.mu.Lock()
+	loaded := s.loaded
+	s.mu.Unlock()
+	if !loaded {
+		s.reload()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return lo.FilterMap(s.documents, func(command *models.RecentDocumentCommand, _ int) (models.CommandRunner, bool) {
+		return command, recent.Exists(command.Path)
+	}), nil
+}
+
+func (s *recentDocumentSource) Refresh(_ context.Context) error {
+	s.reload()
+	if s.notify != nil {
+		s.notify()
+	}
+	return nil
+}
+
+func (s *recentDocumentSource) Trigger(_ context.Context, runner models.CommandRunner, arguments models.CommandArguments) error {
+	return runner.OnTrigger(arguments)
+}
+
+func (s *recentDocumentSource) reload() {
+	documents, err := recent.Load()
+	if err != nil {
+		logger.Error(err, "Failed to load recent documents")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.documents = lo.Map(documents, func(document recent.Document, _ int) *models.RecentDocumentCommand {
+		return models.NewRecentDocumentCommand(document.Path, document.MimeType, document.Application, document.VisitedAt)
+	})
+	s.loaded = true
+}
@@ -0,0 +1,113 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+	"watools/config"
+	"watools/internal/command/script"
+	"watools/internal/command/watcher"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+
+	"github.com/samber/lo"
+)
+
+// scriptSource lists the script commands of the commands directory and reloads them when the directory changes
+type scriptSource struct {
+	dir func() string
+
+	mu         sync.Mutex
+	scripts    []*models.ScriptCommand
+	loaded     bool
+	notify     func()
+	dirWatcher *watcher.DirWatcher
+}
+
+func newScriptSource() *scriptSource {
+	return &scriptSource{dir: config.ScriptCommandsDir}
+}
+
+func (s *scriptSource) Name() models.CommandCategory {
+	return models.CategoryScript
+}
+
+func (s *scriptSource) Start(_ context.Context, notify func()) error {
+	s.notify = notify
+	dir := s.dir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create commands dir: %w", err)
+	}
+	dirWatcher, err := watcher.NewDirWatcher([]string{dir}, script.IsScriptFile, 300*time.Millisecond, func(paths []string) {
+		logger.Info(fmt.Sprintf("Script commands changed: %v", paths))
+		s.reload()
+		s.notify()
+	})
+	if err != nil {
+		return err
+	}
+	if err := dirWatcher.Start(); err != nil {
+		return err
+	}
+	s.dirWatcher = dirWatcher
+	return nil
+}
+
+func (s *scriptSource) Stop() error {
+	if s.dirWatcher == nil {
+		return nil
+	}
+	return s.dirWatcher.Stop()
+}
+
+func (s *scriptSource) List(_ context.Context) ([]models.CommandRunner, error) {
+	s.mu.Lock()
+	loaded := s.loaded
+	s.mu.Unlock()
+	if !loaded {
+		s.reload()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return lo.Map(s.scripts, func(command *models.ScriptCommand, _ int) models.CommandRunner { return command }), nil
+}
+
+func (s *scriptSource) Refresh(_ context.Context) error {
+	s.reload()
+	if s.notify != nil {
+		s.notify()
+	}
+	return nil
+}
+
+func (s *scriptSource) Trigger(_ context.Context, runner models.CommandRunner, arguments models.CommandArguments) error {
+	return runner.OnTrigger(arguments)
+}
+
+// RunAction runs a listed script with every argument and returns its output, nil in silent mode
+func (s *scriptSource) RunAction(ctx context.Context, runner models.CommandRunner, _ string, arguments models.CommandArguments) (interface{}, error) {
+	scriptCommand, ok := runner.(*models.ScriptCommand)
+	if !ok {
+		return nil, fmt.Errorf("command '%s' is not a script", runner.GetTriggerID())
+	}
+	result, err := scriptCommand.Run(ctx, arguments.TextValues())
+	if err != nil || result == nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *scriptSource) reload() {
+	scripts, err := script.LoadScripts(s.dir())
+	if err != nil && !os.IsNotExist(err) {
+		logger.Error(err, "Failed to load script commands")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripts = scripts
+	s.loaded = true
+}
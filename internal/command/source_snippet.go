@@ -0,0 +1,220 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"watools/internal/command/snippet"
+	"watools/pkg/db"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+// snippetSource lists the snippets stored in the database, triggering one copies it to the clipboard
+type snippetSource struct {
+	notify         func()
+	clipboardText  func() (string, error)
+	writeClipboard func(text string) error
+}
+
+func newSnippetSource() *snippetSource {
+	return &snippetSource{}
+}
+
+func (s *snippetSource) Name() models.CommandCategory {
+	return models.CategorySnippet
+}
+
+func (s *snippetSource) Start(_ context.Context, notify func()) error {
+	s.notify = notify
+	return nil
+}
+
+func (s *snippetSource) Stop() error {
+	return nil
+}
+
+func (s *snippetSource) List(ctx context.Context) ([]models.CommandRunner, error) {
+	snippets, err := s.snippets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(snippets, func(snippetCommand *models.SnippetCommand, _ int) models.CommandRunner { return snippetCommand }), nil
+}
+
+// Refresh only announces a change, snippets are read from the database on every listing
+func (s *snippetSource) Refresh(_ context.Context) error {
+	s.notifyChanged()
+	return nil
+}
+
+// Trigger copies the snippet, {clipboard} reads the live clipboard when no snapshot is passed
+func (s *snippetSource) Trigger(_ context.Context, runner models.CommandRunner, arguments models.CommandArguments) error {
+	snippetCommand, ok := runner.(*models.SnippetCommand)
+	if !ok || arguments.Clipboard.IsPresent() {
+		return runner.OnTrigger(arguments)
+	}
+	_, err := snippetCommand.Copy(arguments.TextValues(), s.clipboardText)
+	return err
+}
+
+// RunAction copies a listed snippet with the values of every prompt and returns the text and the {cursor} offset
+func (s *snippetSource) RunAction(_ context.Context, runner models.CommandRunner, _ string, arguments models.CommandArguments) (interface{}, error) {
+	snippetCommand, ok := runner.(*models.SnippetCommand)
+	if !ok {
+		return nil, fmt.Errorf("command '%s' is not a snippet", runner.GetTriggerID())
+	}
+	clipboard := s.clipboardText
+	if snapshot, ok := arguments.Clipboard.Get(); ok {
+		clipboard = func() (string, error) { return snapshot.Text, nil }
+	}
+	return snippetCommand.Copy(arguments.TextValues(), clipboard)
+}
+
+func (s *snippetSource) notifyChanged() {
+	if s.notify != nil {
+		s.notify()
+	}
+}
+
+// snippets returns the stored snippets ready to be copied
+func (s *snippetSource) snippets(ctx context.Context) ([]*models.SnippetCommand, error) {
+	snippets, err := db.GetWaDB().GetSnippets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, snippetCommand := range snippets {
+		snippetCommand.SetClipboardWriter(s.writeClipboard)
+	}
+	return snippets, nil
+}
+
+// validateSnippet checks the fields of a snippet and that its keyword is not used by another one
+func validateSnippet(snippetCommand *models.SnippetCommand, existing []*models.SnippetCommand) error {
+	if strings.TrimSpace(snippetCommand.Name) == "" {
+		return fmt.Errorf("snippet name is required")
+	}
+	if snippetCommand.Body == "" {
+		return fmt.Errorf("snippet body is required")
+	}
+	if strings.ContainsAny(snippetCommand.Keyword, " \t\n") {
+		return fmt.Errorf("snippet keyword '%s' must not contain spaces", snippetCommand.Keyword)
+	}
+	if snippetCommand.Keyword == "" {
+		return nil
+	}
+	for _, other := range existing {
+		if other.ID != snippetCommand.ID && strings.EqualFold(other.Keyword, snippetCommand.Keyword) {
+			return fmt.Errorf("keyword '%s' is already used by snippet '%s'", snippetCommand.Keyword, other.Name)
+		}
+	}
+	return nil
+}
+
+func (s *snippetSource) create(ctx context.Context, name string, keyword string, body string, tags []string) (*models.SnippetCommand, error) {
+	snippetCommand := models.NewSnippetCommand(strings.TrimSpace(name), strings.TrimSpace(keyword), body, tags, mo.None[string]())
+	existing, err := db.GetWaDB().GetSnippets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSnippet(snippetCommand, existing); err != nil {
+		return nil, err
+	}
+	if err := db.GetWaDB().CreateSnippet(ctx, snippetCommand); err != nil {
+		return nil, fmt.Errorf("failed to create snippet: %w", err)
+	}
+	s.notifyChanged()
+	return snippetCommand, nil
+}
+
+func (s *snippetSource) update(ctx context.Context, id string, name string, keyword string, body string, tags []string) (*models.SnippetCommand, error) {
+	if _, err := db.GetWaDB().GetSnippet(ctx, id); err != nil {
+		return nil, err
+	}
+	snippetCommand := models.NewSnippetCommand(strings.TrimSpace(name), strings.TrimSpace(keyword), body, tags, mo.Some(id))
+	existing, err := db.GetWaDB().GetSnippets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSnippet(snippetCommand, existing); err != nil {
+		return nil, err
+	}
+	if err := db.GetWaDB().UpdateSnippet(ctx, snippetCommand); err != nil {
+		return nil, fmt.Errorf("failed to update snippet: %w", err)
+	}
+	s.notifyChanged()
+	return snippetCommand, nil
+}
+
+func (s *snippetSource) delete(ctx context.Context, id string) error {
+	if err := db.GetWaDB().DeleteSnippet(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete snippet: %w", err)
+	}
+	s.notifyChanged()
+	return nil
+}
+
+// importFile stores the snippets of a file, snippets with a known id are replaced.
+// A keyword already used by another snippet is dropped so the snippet is still imported.
+func (s *snippetSource) importFile(ctx context.Context, path string) (int, error) {
+	items, err := snippet.Read(path)
+	if err != nil {
+		return 0, err
+	}
+	existing, err := db.GetWaDB().GetSnippets(ctx)
+	if err != nil {
+		return 0, err
+	}
+	known := append([]*models.SnippetCommand(nil), existing...)
+	var imported []*models.SnippetCommand
+	for _, item := range items {
+		name := strings.TrimSpace(item.Name)
+		if name == "" {
+			name = strings.TrimSpace(item.Keyword)
+		}
+		id := mo.None[string]()
+		if item.ID != "" {
+			id = mo.Some(item.ID)
+		}
+		snippetCommand := models.NewSnippetCommand(name, strings.TrimSpace(item.Keyword), item.Body, item.Tags, id)
+		if err := validateSnippet(snippetCommand, known); err != nil && snippetCommand.Keyword != "" {
+			logger.Info(fmt.Sprintf("Importing snippet '%s' without its keyword: %s", name, err))
+			snippetCommand = models.NewSnippetCommand(name, "", item.Body, item.Tags, id)
+		}
+		if err := validateSnippet(snippetCommand, known); err != nil {
+			logger.Error(err, fmt.Sprintf("Skipping snippet '%s' from %s", name, path))
+			continue
+		}
+		imported = append(imported, snippetCommand)
+		known = append(known, snippetCommand)
+	}
+	if err := db.GetWaDB().SaveSnippets(ctx, imported); err != nil {
+		return 0, err
+	}
+	s.notifyChanged()
+	return len(imported), nil
+}
+
+// exportFile writes every snippet to path in the format of its extension
+func (s *snippetSource) exportFile(ctx context.Context, path string) error {
+	snippets, err := db.GetWaDB().GetSnippets(ctx)
+	if err != nil {
+		return err
+	}
+	items := lo.Map(snippets, func(snippetCommand *models.SnippetCommand, _ int) snippet.Snippet {
+		return snippet.Snippet{
+			ID:      snippetCommand.ID,
+			Name:    snippetCommand.Name,
+			Keyword: snippetCommand.Keyword,
+			Body:    snippetCommand.Body,
+			Tags:    snippetCommand.Tags,
+		}
+	})
+	if err := snippet.Write(path, items); err != nil {
+		return fmt.Errorf("failed to export snippets: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"watools/internal/command/sshhost"
+	"watools/internal/command/watcher"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+
+	"github.com/samber/lo"
+)
+
+// sshHostSource lists the hosts of ~/.ssh/config and ~/.ssh/known_hosts.
+// The ssh directory and the directories Include directives read from are watched, a change reloads the hosts.
+type sshHostSource struct {
+	mu          sync.Mutex
+	hosts       []*models.SSHHostCommand
+	loaded      bool
+	notify      func()
+	started     bool
+	watchedDirs []string
+	dirWatcher  *watcher.DirWatcher
+}
+
+func newSSHHostSource() *sshHostSource {
+	return &sshHostSource{}
+}
+
+func (s *sshHostSource) Name() models.CommandCategory {
+	return models.CategorySSHHost
+}
+
+func (s *sshHostSource) Start(_ context.Context, notify func()) error {
+	s.mu.Lock()
+	s.notify = notify
+	s.started = true
+	s.mu.Unlock()
+	s.reload()
+	return nil
+}
+
+func (s *sshHostSource) Stop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.started = false
+	if s.dirWatcher == nil {
+		return nil
+	}
+	return s.dirWatcher.Stop()
+}
+
+func (s *sshHostSource) List(_ context.Context) ([]models.CommandRunner, error) {
+	s.mu.Lock()
+	loaded := s.loaded
+	s.mu.Unlock()
+	if !loaded {
+		s.reload()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return lo.Map(s.hosts, func(command *models.SSHHostCommand, _ int) models.CommandRunner { return command }), nil
+}
+
+func (s *sshHostSource) Refresh(_ context.Context) error {
+	s.reload()
+	if s.notify != nil {
+		s.notify()
+	}
+	return nil
+}
+
+func (s *sshHostSource) Trigger(_ context.Context, runner models.CommandRunner, arguments models.CommandArguments) error {
+	return runner.OnTrigger(arguments)
+}
+
+func (s *sshHostSource) reload() {
+	hosts, configDirs, err := sshhost.Load()
+	if err != nil {
+		logger.Error(err, "Failed to load ssh hosts")
+	}
+	commands := lo.Map(hosts, func(host sshhost.Host, _ int) *models.SSHHostCommand {
+		return models.NewSSHHostCommand(host.Alias, host.HostName, host.User, host.Port, host.Destination(), host.Configured, host.Known)
+	})
+	dirs := lo.Uniq(append([]string{sshhost.Dir()}, configDirs...))
+	slices.Sort(dirs)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hosts = commands
+	s.loaded = true
+	if s.started && !slices.Equal(dirs, s.watchedDirs) {
+		s.watch(dirs)
+	}
+}
+
+// watch replaces the watcher with one over dirs, the caller must hold s.mu
+func (s *sshHostSource) watch(dirs []string) {
+	if s.dirWatcher != nil {
+		if err := s.dirWatcher.Stop(); err != nil {
+			logger.Error(err, "Failed to stop ssh config watcher")
+		}
+		s.dirWatcher = nil
+	}
+	dirWatcher, err := watcher.NewDirWatcher(dirs, isSSHConfigChange, 300*time.Millisecond, func(paths []string) {
+		logger.Debug(fmt.Sprintf("SSH config changed: %v", paths))
+		s.reload()
+		s.notify()
+	})
+	if err == nil {
+		err = dirWatcher.Start()
+	}
+	if err != nil {
+		logger.Error(err, "Failed to watch ssh config")
+		return
+	}
+	s.dirWatcher = dirWatcher
+	s.watchedDirs = dirs
+}
+
+// isSSHConfigChange skips editor backups, any other file may be matched by an Include pattern
+func isSSHConfigChange(path string) bool {
+	name := filepath.Base(path)
+	return !strings.HasSuffix(name, "~") && !strings.HasSuffix(name, ".swp") && !strings.HasSuffix(name, ".tmp")
+}
@@ -0,0 +1,227 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"watools/pkg/models"
+)
+
+type fakeSource struct {
+	name      models.CommandCategory
+	commands  []*models.OperationCommand
+	listCount int
+	triggered []string
+	notify    func()
+}
+
+func (s *fakeSource) Name() models.CommandCategory {
+	return s.name
+}
+
+func (s *fakeSource) Start(_ context.Context, notify func()) error {
+	s.notify = notify
+	return nil
+}
+
+func (s *fakeSource) Stop() error {
+	return nil
+}
+
+func (s *fakeSource) List(_ context.Context) ([]models.CommandRunner, error) {
+	s.listCount++
+	runners := make([]models.CommandRunner, 0, len(s.commands))
+	for _, command := range s.commands {
+		runners = append(runners, command)
+	}
+	return runners, nil
+}
+
+func (s *fakeSource) Refresh(_ context.Context) error {
+	s.notify()
+	return nil
+}
+
+func (s *fakeSource) Trigger(_ context.Context, runner models.CommandRunner, arguments models.CommandArguments) error {
+	s.triggered = append(s.triggered, runner.GetTriggerID())
+	return runner.OnTrigger(arguments)
+}
+
+// fakeActionSource runs the actions of its commands by returning the action name
+type fakeActionSource struct {
+	fakeSource
+	actions []string
+}
+
+func (s *fakeActionSource) RunAction(_ context.Context, runner models.CommandRunner, action string, _ models.CommandArguments) (interface{}, error) {
+	s.actions = append(s.actions, runner.GetTriggerID()+" "+action)
+	return action, nil
+}
+
+func newFakeOperation(name string) *models.OperationCommand {
+	return models.NewOperationCommand(name, "", "", func() error { return nil })
+}
+
+func TestRegistryRejectsDuplicateSource(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	if err := registry.Register(&fakeSource{name: "Fake"}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := registry.Register(&fakeSource{name: "Fake"}); err == nil {
+		t.Fatalf("Register() of a duplicate source succeeded")
+	}
+	if got := registry.Sources(); len(got) != 1 || got[0] != "Fake" {
+		t.Fatalf("Sources() = %v, want [Fake]", got)
+	}
+}
+
+func TestRegistryListDeduplicatesTriggerIDs(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	source := &fakeSource{name: "Fake", commands: []*models.OperationCommand{
+		newFakeOperation("A"), newFakeOperation("A"), newFakeOperation("B"),
+	}}
+	if err := registry.Register(source); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	runners, err := registry.List(context.Background(), "Fake")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(runners) != 2 {
+		t.Fatalf("List() returned %d runners, want 2", len(runners))
+	}
+	if _, err := registry.List(context.Background(), "Missing"); err == nil {
+		t.Fatalf("List() of an unknown source succeeded")
+	}
+}
+
+func TestRegistryTrigger(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	failed := models.NewOperationCommand("Fail", "", "", func() error { return errors.New("boom") })
+	source := &fakeSource{name: "Fake", commands: []*models.OperationCommand{newFakeOperation("A"), failed}}
+	if err := registry.Register(source); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	ctx := context.Background()
+	// the source is listed on demand when it was not listed before
+	if err := registry.Trigger(ctx, "Fake", "Operation-A", models.NoArguments); err != nil {
+		t.Fatalf("Trigger() error = %v", err)
+	}
+	if err := registry.Trigger(ctx, "Fake", "Operation-A", models.NoArguments); err != nil {
+		t.Fatalf("Trigger() error = %v", err)
+	}
+	if source.listCount != 1 {
+		t.Fatalf("source listed %d times, want 1", source.listCount)
+	}
+	if err := registry.Trigger(ctx, "Fake", "Operation-Fail", models.NoArguments); err == nil {
+		t.Fatalf("Trigger() did not return the runner error")
+	}
+	if err := registry.Trigger(ctx, "Fake", "Operation-Missing", models.NoArguments); err == nil {
+		t.Fatalf("Trigger() of an unknown command succeeded")
+	}
+	if len(source.triggered) != 3 {
+		t.Fatalf("triggered = %v, want 3 triggers", source.triggered)
+	}
+}
+
+func TestRegistryTriggerValidatesArguments(t *testing.T) {
+	t.Parallel()
+
+	var received models.CommandArguments
+	withText := models.NewOperationCommandWithArguments("Echo", "", "", []models.ArgumentKind{models.ArgumentText}, func(arguments models.CommandArguments) error {
+		received = arguments
+		return nil
+	})
+	registry := NewRegistry()
+	source := &fakeSource{name: "Fake", commands: []*models.OperationCommand{withText, newFakeOperation("A")}}
+	if err := registry.Register(source); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if err := registry.Trigger(ctx, "Fake", "Operation-Echo", models.CommandArguments{Text: "hello"}); err != nil {
+		t.Fatalf("Trigger() error = %v", err)
+	}
+	if received.Text != "hello" {
+		t.Fatalf("runner received text %q, want %q", received.Text, "hello")
+	}
+	if err := registry.Trigger(ctx, "Fake", "Operation-Echo", models.CommandArguments{Files: []string{"/tmp/a.txt"}}); err == nil {
+		t.Fatalf("Trigger() with files of a text-only command succeeded")
+	}
+	if err := registry.Trigger(ctx, "Fake", "Operation-A", models.CommandArguments{Text: "hello"}); err == nil {
+		t.Fatalf("Trigger() with text of a command without arguments succeeded")
+	}
+	if len(source.triggered) != 1 {
+		t.Fatalf("triggered = %v, want only the valid trigger", source.triggered)
+	}
+}
+
+func TestRegistryRunAction(t *testing.T) {
+	t.Parallel()
+
+	withAction := newFakeOperation("A")
+	withAction.Actions = []string{"copy"}
+	registry := NewRegistry()
+	source := &fakeActionSource{fakeSource: fakeSource{name: "Fake", commands: []*models.OperationCommand{withAction}}}
+	if err := registry.Register(source); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := registry.Register(&fakeSource{name: "Plain", commands: []*models.OperationCommand{newFakeOperation("A")}}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	ctx := context.Background()
+	result, err := registry.RunAction(ctx, "Fake", "Operation-A", "copy", models.NoArguments)
+	if err != nil {
+		t.Fatalf("RunAction() error = %v", err)
+	}
+	if result != "copy" {
+		t.Fatalf("RunAction() = %v, want copy", result)
+	}
+	if _, err := registry.RunAction(ctx, "Fake", "Operation-A", "delete", models.NoArguments); err == nil {
+		t.Fatalf("RunAction() of an action the command does not list succeeded")
+	}
+	if _, err := registry.RunAction(ctx, "Fake", "Operation-A", "copy", models.CommandArguments{Text: "hello"}); err == nil {
+		t.Fatalf("RunAction() with text of a command without arguments succeeded")
+	}
+	if _, err := registry.RunAction(ctx, "Plain", "Operation-A", "copy", models.NoArguments); err == nil {
+		t.Fatalf("RunAction() on a source without actions succeeded")
+	}
+	if len(source.actions) != 1 || len(source.triggered) != 0 {
+		t.Fatalf("actions = %v, triggered = %v, want one action and no trigger", source.actions, source.triggered)
+	}
+}
+
+func TestRegistryNotifiesChanges(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	first := &fakeSource{name: "First"}
+	second := &fakeSource{name: "Second"}
+	for _, source := range []*fakeSource{first, second} {
+		if err := registry.Register(source); err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+	}
+	var changed []models.CommandCategory
+	registry.OnChange(func(source models.CommandCategory) {
+		changed = append(changed, source)
+	})
+	registry.Start(context.Background())
+
+	if err := registry.Refresh(context.Background(), "Second"); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	first.notify()
+	if len(changed) != 2 || changed[0] != "Second" || changed[1] != "First" {
+		t.Fatalf("changed = %v, want [Second First]", changed)
+	}
+}
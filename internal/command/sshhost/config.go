@@ -0,0 +1,222 @@
+// Package sshhost lists the hosts of the OpenSSH client configuration and known_hosts
+package sshhost
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxIncludeDepth matches the recursion limit of OpenSSH
+const maxIncludeDepth = 16
+
+// ConfigHost is a Host alias of an ssh_config with the settings of the blocks naming it
+type ConfigHost struct {
+	Alias    string
+	HostName string
+	User     string
+	Port     int
+}
+
+// configParser reads a config with its includes, the first value found for a setting wins like in OpenSSH
+type configParser struct {
+	sshDir string
+	hosts  []*ConfigHost
+	byName map[string]*ConfigHost
+	// files are the config files read, including those that failed to parse
+	files []string
+	// dirs hold the files read and the files Include patterns could match later
+	dirs map[string]bool
+}
+
+// ParseConfigFile reads the ssh_config at path, relative Include paths are resolved against sshDir.
+// It returns the concrete host aliases and the directories to watch for changes of the configuration.
+func ParseConfigFile(path string, sshDir string) ([]ConfigHost, []string, error) {
+	parser := &configParser{sshDir: sshDir, byName: make(map[string]*ConfigHost), dirs: make(map[string]bool)}
+	err := parser.parseFile(path, 0)
+	dirs := make([]string, 0, len(parser.dirs))
+	for dir := range parser.dirs {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	if err != nil {
+		return nil, dirs, err
+	}
+	hosts := make([]ConfigHost, 0, len(parser.hosts))
+	for _, host := range parser.hosts {
+		hosts = append(hosts, *host)
+	}
+	return hosts, dirs, nil
+}
+
+func (p *configParser) parseFile(path string, depth int) error {
+	if depth > maxIncludeDepth {
+		return fmt.Errorf("includes nested too deeply at %s", path)
+	}
+	for _, file := range p.files {
+		if file == path {
+			return nil
+		}
+	}
+	p.files = append(p.files, path)
+	p.dirs[filepath.Dir(path)] = true
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return p.parse(f, depth)
+}
+
+func (p *configParser) parse(r io.Reader, depth int) error {
+	// current holds the hosts the settings apply to, nil outside of a Host block
+	var current []*ConfigHost
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		keyword, args := splitLine(scanner.Text())
+		if keyword == "" || len(args) == 0 {
+			continue
+		}
+		switch keyword {
+		case "host":
+			current = p.hostsOf(args)
+		case "match":
+			// Match blocks depend on the connection, their settings are not attributed to aliases
+			current = nil
+		case "include":
+			for _, pattern := range args {
+				p.include(pattern, depth)
+			}
+		case "hostname":
+			for _, host := range current {
+				if host.HostName == "" {
+					host.HostName = strings.ReplaceAll(strings.ReplaceAll(args[0], "%h", host.Alias), "%%", "%")
+				}
+			}
+		case "user":
+			for _, host := range current {
+				if host.User == "" {
+					host.User = args[0]
+				}
+			}
+		case "port":
+			port, err := strconv.Atoi(args[0])
+			if err != nil {
+				continue
+			}
+			for _, host := range current {
+				if host.Port == 0 {
+					host.Port = port
+				}
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// hostsOf returns the hosts of the concrete patterns of a Host line, wildcards and negations are skipped
+func (p *configParser) hostsOf(patterns []string) []*ConfigHost {
+	var hosts []*ConfigHost
+	for _, pattern := range patterns {
+		if strings.ContainsAny(pattern, "*?!") || !IsValidHostName(pattern) {
+			continue
+		}
+		host, ok := p.byName[pattern]
+		if !ok {
+			host = &ConfigHost{Alias: pattern}
+			p.byName[pattern] = host
+			p.hosts = append(p.hosts, host)
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// include reads the files matching an Include pattern in lexical order like OpenSSH, missing files are ignored
+func (p *configParser) include(pattern string, depth int) {
+	if strings.HasPrefix(pattern, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return
+		}
+		pattern = filepath.Join(homeDir, pattern[2:])
+	} else if !filepath.IsAbs(pattern) {
+		pattern = filepath.Join(p.sshDir, pattern)
+	}
+	if dir := filepath.Dir(pattern); !strings.ContainsAny(dir, "*?[") {
+		p.dirs[dir] = true
+	}
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return
+	}
+	sort.Strings(matches)
+	for _, match := range matches {
+		if info, err := os.Stat(match); err != nil || info.IsDir() {
+			continue
+		}
+		_ = p.parseFile(match, depth+1)
+	}
+}
+
+// splitLine returns the lower-cased keyword of a config line and its arguments, quotes group arguments with spaces
+func splitLine(line string) (string, []string) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", nil
+	}
+	end := strings.IndexAny(line, " \t=")
+	if end < 0 {
+		return strings.ToLower(line), nil
+	}
+	keyword := strings.ToLower(line[:end])
+	rest := strings.TrimLeft(line[end:], " \t")
+	rest = strings.TrimLeft(strings.TrimPrefix(rest, "="), " \t")
+
+	var args []string
+	var arg strings.Builder
+	quoted, started := false, false
+	for _, r := range rest {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			started = true
+		case (r == ' ' || r == '\t') && !quoted:
+			if started {
+				args = append(args, arg.String())
+				arg.Reset()
+				started = false
+			}
+		case r == '#' && !quoted && !started:
+			return keyword, args
+		default:
+			arg.WriteRune(r)
+			started = true
+		}
+	}
+	if started {
+		args = append(args, arg.String())
+	}
+	return keyword, args
+}
+
+// IsValidHostName rejects names that ssh would read as an option or that a shell would interpret
+func IsValidHostName(name string) bool {
+	if name == "" || strings.HasPrefix(name, "-") {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("._-:@", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+package sshhost
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// KnownHosts holds the host names of a known_hosts file, hashed names can only be checked against a candidate
+type KnownHosts struct {
+	// Names are the unhashed names in the order they appear, "[host]:port" for ports other than 22
+	Names  []string
+	names  map[string]bool
+	hashed []hashedName
+}
+
+type hashedName struct {
+	salt []byte
+	hash []byte
+}
+
+// ParseKnownHosts reads a known_hosts file, wildcard patterns and revoked or CA keys are skipped
+func ParseKnownHosts(r io.Reader) (*KnownHosts, error) {
+	known := &KnownHosts{names: make(map[string]bool)}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "@") {
+			continue
+		}
+		for _, name := range strings.Split(fields[0], ",") {
+			if hashed, ok := parseHashedName(name); ok {
+				known.hashed = append(known.hashed, hashed)
+				continue
+			}
+			if strings.ContainsAny(name, "*?!") || known.names[name] {
+				continue
+			}
+			known.names[name] = true
+			known.Names = append(known.Names, name)
+		}
+	}
+	return known, scanner.Err()
+}
+
+// parseHashedName reads "|1|base64(salt)|base64(hmac-sha1(salt, name))"
+func parseHashedName(name string) (hashedName, bool) {
+	parts := strings.Split(name, "|")
+	if len(parts) != 4 || parts[0] != "" || parts[1] != "1" {
+		return hashedName{}, false
+	}
+	salt, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return hashedName{}, false
+	}
+	hash, err := base64.StdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return hashedName{}, false
+	}
+	return hashedName{salt: salt, hash: hash}, true
+}
+
+// Contains reports whether the file has a key for host on port, port 0 means the default port
+func (k *KnownHosts) Contains(host string, port int) bool {
+	name := KnownHostName(host, port)
+	if k.names[name] {
+		return true
+	}
+	for _, hashed := range k.hashed {
+		mac := hmac.New(sha1.New, hashed.salt)
+		mac.Write([]byte(name))
+		if hmac.Equal(mac.Sum(nil), hashed.hash) {
+			return true
+		}
+	}
+	return false
+}
+
+// KnownHostName formats host the way known_hosts records it
+func KnownHostName(host string, port int) string {
+	if port == 0 || port == 22 {
+		return host
+	}
+	return "[" + host + "]:" + strconv.Itoa(port)
+}
+
+// SplitKnownHostName reads "[host]:port" or "host", the port is 0 for the default port
+func SplitKnownHostName(name string) (string, int) {
+	if !strings.HasPrefix(name, "[") {
+		return name, 0
+	}
+	host, portText, err := net.SplitHostPort(name)
+	if err != nil {
+		return strings.Trim(name, "[]"), 0
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil || port == 22 {
+		return host, 0
+	}
+	return host, port
+}
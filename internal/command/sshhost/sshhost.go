@@ -0,0 +1,92 @@
+package sshhost
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Host is a host the user can connect to
+type Host struct {
+	// Alias is the name passed to ssh, the Host alias for configured hosts
+	Alias    string
+	HostName string
+	User     string
+	// Port is 0 when ssh picks it, from the config or the default
+	Port int
+	// Configured is false for hosts only found in known_hosts
+	Configured bool
+	// Known is set when known_hosts has a key for the host
+	Known bool
+}
+
+// Dir returns the ssh directory of the user
+func Dir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".ssh")
+}
+
+// Load reads the hosts of ~/.ssh/config and ~/.ssh/known_hosts, it also returns the directories to watch for changes
+func Load() ([]Host, []string, error) {
+	dir := Dir()
+	configHosts, dirs, err := ParseConfigFile(filepath.Join(dir, "config"), dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, dirs, err
+	}
+	known := &KnownHosts{}
+	if f, err := os.Open(filepath.Join(dir, "known_hosts")); err == nil {
+		known, err = ParseKnownHosts(f)
+		f.Close()
+		if err != nil {
+			return nil, dirs, err
+		}
+	}
+	return Merge(configHosts, known), dirs, nil
+}
+
+// Merge lists the configured hosts first, then the known_hosts names that no configured host connects to
+func Merge(configHosts []ConfigHost, known *KnownHosts) []Host {
+	hosts := make([]Host, 0, len(configHosts)+len(known.Names))
+	covered := make(map[string]bool)
+	for _, configHost := range configHosts {
+		hostName := configHost.HostName
+		if hostName == "" {
+			hostName = configHost.Alias
+		}
+		hosts = append(hosts, Host{
+			Alias:      configHost.Alias,
+			HostName:   hostName,
+			User:       configHost.User,
+			Port:       configHost.Port,
+			Configured: true,
+			Known:      known.Contains(hostName, configHost.Port),
+		})
+		covered[KnownHostName(configHost.Alias, configHost.Port)] = true
+		covered[KnownHostName(hostName, configHost.Port)] = true
+	}
+	for _, name := range known.Names {
+		host, port := SplitKnownHostName(name)
+		if covered[KnownHostName(host, port)] || !IsValidHostName(host) {
+			continue
+		}
+		covered[KnownHostName(host, port)] = true
+		hosts = append(hosts, Host{Alias: host, HostName: host, Port: port, Known: true})
+	}
+	return hosts
+}
+
+// Destination returns the name shown for a host, such as "deploy@example.com:2222"
+func (h Host) Destination() string {
+	destination := h.HostName
+	if h.User != "" {
+		destination = h.User + "@" + destination
+	}
+	if h.Port != 0 {
+		destination += ":" + strconv.Itoa(h.Port)
+	}
+	return destination
+}
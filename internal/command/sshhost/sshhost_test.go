@@ -0,0 +1,116 @@
+package sshhost
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseConfigFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "config.d"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"config": `# personal
+Include config.d/*
+Host web web-staging
+    HostName %h.example.com
+    User deploy
+
+Host *.internal !bastion.internal
+    User admin
+
+Host db
+    Port 2222
+Match host db exec "true"
+    User ignored
+Host *
+    User everyone
+`,
+		"config.d/10-work": `Host bastion
+  HostName=10.0.0.1
+  Port 2200
+Host web
+  User ops
+Include config
+`,
+		"config.d/20-lab": `Host "lab box" lab
+  HostName lab.example.com
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hosts, dirs, err := ParseConfigFile(filepath.Join(dir, "config"), dir)
+	if err != nil {
+		t.Fatalf("expected the config to parse: %v", err)
+	}
+	want := []ConfigHost{
+		{Alias: "bastion", HostName: "10.0.0.1", Port: 2200},
+		{Alias: "web", HostName: "web.example.com", User: "ops"},
+		{Alias: "lab", HostName: "lab.example.com"},
+		{Alias: "web-staging", HostName: "web-staging.example.com", User: "deploy"},
+		{Alias: "db", Port: 2222},
+	}
+	if !reflect.DeepEqual(hosts, want) {
+		t.Fatalf("unexpected hosts %+v", hosts)
+	}
+	if !reflect.DeepEqual(dirs, []string{dir, filepath.Join(dir, "config.d")}) {
+		t.Fatalf("expected the ssh dir and the include dir to be watched, got %v", dirs)
+	}
+}
+
+func hashName(salt []byte, name string) string {
+	mac := hmac.New(sha1.New, salt)
+	mac.Write([]byte(name))
+	return "|1|" + base64.StdEncoding.EncodeToString(salt) + "|" + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestMergeWithKnownHosts(t *testing.T) {
+	t.Parallel()
+
+	data := strings.Join([]string{
+		"# comment",
+		"github.com,140.82.121.4 ssh-ed25519 AAAA",
+		hashName([]byte("0123456789abcdefghij"), "web.example.com") + " ssh-ed25519 AAAA",
+		"[git.example.com]:2222 ssh-ed25519 AAAA",
+		"[db.example.com]:2222 ssh-ed25519 AAAA",
+		"*.example.org ssh-ed25519 AAAA",
+		"@revoked old.example.com ssh-rsa AAAA",
+		"-oProxyCommand=evil ssh-ed25519 AAAA",
+	}, "\n")
+	known, err := ParseKnownHosts(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("expected known_hosts to parse: %v", err)
+	}
+	configHosts := []ConfigHost{
+		{Alias: "web", HostName: "web.example.com", User: "deploy"},
+		{Alias: "db", HostName: "db.example.com", Port: 2222},
+	}
+
+	hosts := Merge(configHosts, known)
+	want := []Host{
+		{Alias: "web", HostName: "web.example.com", User: "deploy", Configured: true, Known: true},
+		{Alias: "db", HostName: "db.example.com", Port: 2222, Configured: true, Known: true},
+		{Alias: "github.com", HostName: "github.com", Known: true},
+		{Alias: "140.82.121.4", HostName: "140.82.121.4", Known: true},
+		{Alias: "git.example.com", HostName: "git.example.com", Port: 2222, Known: true},
+	}
+	if !reflect.DeepEqual(hosts, want) {
+		t.Fatalf("unexpected hosts %+v", hosts)
+	}
+	if hosts[4].Destination() != "git.example.com:2222" || hosts[0].Destination() != "deploy@web.example.com" {
+		t.Fatalf("unexpected destinations %q %q", hosts[4].Destination(), hosts[0].Destination())
+	}
+}
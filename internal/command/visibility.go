@@ -0,0 +1,200 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"watools/pkg/db"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+	"watools/pkg/utils"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+// commandLister lists the commands of a source with the pin orders of the pinned ones by trigger ID
+type commandLister func(ctx context.Context, source models.CommandCategory) ([]models.CommandRunner, map[string]int, error)
+
+// commandPath returns the file a command is backed by, exclusions are matched against it
+func commandPath(runner models.CommandRunner) string {
+	switch command := runner.(type) {
+	case *models.ApplicationCommand:
+		return command.Path
+	case *models.ScriptCommand:
+		return command.Path
+	case *models.RecentDocumentCommand:
+		return command.Path
+	case *models.ProjectCommand:
+		return command.Path
+	case *models.GitRepoCommand:
+		return command.Path
+	}
+	return ""
+}
+
+// applyVisibility drops hidden and excluded commands and returns the pin orders of the others by trigger ID.
+// Pinned commands come first in ascending pin order, the others keep the order of the source.
+// The runners are shared with the source caches and are not changed.
+func applyVisibility(runners []models.CommandRunner, visibilities map[string]models.CommandVisibility, exclusions []string) ([]models.CommandRunner, map[string]int) {
+	visible := make([]models.CommandRunner, 0, len(runners))
+	pinOrders := make(map[string]int)
+	for _, runner := range runners {
+		if path := commandPath(runner); path != "" && utils.MatchAnyPathGlob(exclusions, path) {
+			continue
+		}
+		visibility := visibilities[models.VisibilityKey(runner)]
+		if visibility.Hidden {
+			continue
+		}
+		if order, ok := visibility.PinOrder.Get(); ok {
+			pinOrders[runner.GetTriggerID()] = order
+		}
+		visible = append(visible, runner)
+	}
+	sort.SliceStable(visible, func(i, j int) bool {
+		a, aPinned := pinOrders[visible[i].GetTriggerID()]
+		b, bPinned := pinOrders[visible[j].GetTriggerID()]
+		if aPinned != bPinned {
+			return aPinned
+		}
+		return aPinned && a < b
+	})
+	return visible, pinOrders
+}
+
+// listVisible lists the commands of a source as the user wants them shown
+func (w *WaLaunchApp) listVisible(ctx context.Context, source models.CommandCategory) ([]models.CommandRunner, map[string]int, error) {
+	runners, err := w.registry.List(ctx, source)
+	if err != nil {
+		return nil, nil, err
+	}
+	dbInstance := db.GetWaDB()
+	visibilities, err := dbInstance.GetCommandVisibilities(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	exclusions, err := dbInstance.GetCommandExclusions(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	bySource := lo.SliceToMap(lo.Filter(visibilities, func(visibility models.CommandVisibility, _ int) bool {
+		return visibility.Source == source
+	}), func(visibility models.CommandVisibility) (string, models.CommandVisibility) {
+		return visibility.Key, visibility
+	})
+	visible, pinOrders := applyVisibility(runners, bySource, exclusions)
+	return visible, pinOrders, nil
+}
+
+// updateVisibility changes the stored visibility of a command and tells the listeners of its source
+func (w *WaLaunchApp) updateVisibility(source string, triggerID string, change func(visibility *models.CommandVisibility)) error {
+	category := models.CommandCategory(source)
+	runner, err := w.registry.Find(w.ctx, category, triggerID)
+	if err != nil {
+		return err
+	}
+	dbInstance := db.GetWaDB()
+	visibilities, err := dbInstance.GetCommandVisibilities(w.ctx)
+	if err != nil {
+		return err
+	}
+	key := models.VisibilityKey(runner)
+	visibility, found := lo.Find(visibilities, func(visibility models.CommandVisibility) bool {
+		return visibility.Source == category && visibility.Key == key
+	})
+	if !found {
+		visibility = models.CommandVisibility{Source: category, Key: key}
+	}
+	change(&visibility)
+	if err := dbInstance.SetCommandVisibility(w.ctx, visibility); err != nil {
+		return fmt.Errorf("failed to save visibility of %s: %w", triggerID, err)
+	}
+	w.registry.NotifyChanged(category)
+	return nil
+}
+
+// SetCommandHidden hides a command from the launcher and search, or shows it again
+func (w *WaLaunchApp) SetCommandHidden(source string, triggerID string, hidden bool) error {
+	return w.updateVisibility(source, triggerID, func(visibility *models.CommandVisibility) {
+		visibility.Hidden = hidden
+	})
+}
+
+// PinCommand lists a command before the unpinned ones, pinned commands are sorted by order
+func (w *WaLaunchApp) PinCommand(source string, triggerID string, order int) error {
+	return w.updateVisibility(source, triggerID, func(visibility *models.CommandVisibility) {
+		visibility.PinOrder = mo.Some(order)
+	})
+}
+
+func (w *WaLaunchApp) UnpinCommand(source string, triggerID string) error {
+	return w.updateVisibility(source, triggerID, func(visibility *models.CommandVisibility) {
+		visibility.PinOrder = mo.None[int]()
+	})
+}
+
+// GetCommandVisibilities returns the stored visibilities.
+// The "command" of an entry is set when the command still exists, so hidden commands can be shown again.
+func (w *WaLaunchApp) GetCommandVisibilities() ([]interface{}, error) {
+	visibilities, err := db.GetWaDB().GetCommandVisibilities(w.ctx)
+	if err != nil {
+		return nil, err
+	}
+	runners := make(map[models.CommandCategory]map[string]models.CommandRunner)
+	items := make([]interface{}, 0, len(visibilities))
+	for _, visibility := range visibilities {
+		if _, listed := runners[visibility.Source]; !listed {
+			sourceRunners, err := w.registry.List(w.ctx, visibility.Source)
+			if err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to list command source %s", visibility.Source))
+			}
+			runners[visibility.Source] = lo.SliceToMap(sourceRunners, func(runner models.CommandRunner) (string, models.CommandRunner) {
+				return models.VisibilityKey(runner), runner
+			})
+		}
+		item := commandsToMaps([]models.CommandVisibility{visibility})[0].(map[string]interface{})
+		if runner, exists := runners[visibility.Source][visibility.Key]; exists {
+			item["command"] = commandsToMaps([]models.CommandRunner{runner})[0]
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (w *WaLaunchApp) GetCommandExclusions() ([]string, error) {
+	return db.GetWaDB().GetCommandExclusions(w.ctx)
+}
+
+// AddCommandExclusion excludes the applications and scripts whose path matches pattern.
+// Excluded applications are removed from the database and are not added back by the scan or the watcher.
+func (w *WaLaunchApp) AddCommandExclusion(pattern string) error {
+	pattern = strings.TrimSpace(pattern)
+	if err := utils.ValidatePathGlob(pattern); err != nil {
+		return err
+	}
+	if err := db.GetWaDB().AddCommandExclusion(w.ctx, pattern); err != nil {
+		return fmt.Errorf("failed to add exclusion: %w", err)
+	}
+	w.onExclusionsChanged()
+	return nil
+}
+
+func (w *WaLaunchApp) RemoveCommandExclusion(pattern string) error {
+	if err := db.GetWaDB().RemoveCommandExclusion(w.ctx, strings.TrimSpace(pattern)); err != nil {
+		return fmt.Errorf("failed to remove exclusion: %w", err)
+	}
+	w.onExclusionsChanged()
+	return nil
+}
+
+// onExclusionsChanged rescans the applications so that excluded ones are removed and included ones added back
+func (w *WaLaunchApp) onExclusionsChanged() {
+	for _, source := range w.registry.Sources() {
+		if source != models.CategoryApplication {
+			w.registry.NotifyChanged(source)
+		}
+	}
+	go w.applications.updateApplications(w.ctx)
+}
@@ -0,0 +1,44 @@
+package command
+
+import (
+	"testing"
+	"watools/pkg/models"
+
+	"github.com/samber/mo"
+)
+
+func TestApplyVisibility(t *testing.T) {
+	first := newFakeOperation("First")
+	second := newFakeOperation("Second")
+	third := newFakeOperation("Third")
+	hidden := newFakeOperation("Hidden")
+	app := &models.ApplicationCommand{Command: models.Command{Name: "Excluded", TriggerID: "excluded"}, Path: "/opt/excluded/app.desktop"}
+	action := &models.ApplicationCommand{Command: models.Command{Name: "Pinned action", TriggerID: "action"}, Path: "/usr/share/applications/editor.desktop", ActionID: "new-window"}
+
+	visible, pinOrders := applyVisibility(
+		[]models.CommandRunner{first, second, hidden, third, app, action},
+		map[string]models.CommandVisibility{
+			models.VisibilityKey(second):                        {PinOrder: mo.Some(2)},
+			models.VisibilityKey(hidden):                        {Hidden: true},
+			"/usr/share/applications/editor.desktop#new-window": {PinOrder: mo.Some(1)},
+		},
+		[]string{"/opt/**"},
+	)
+
+	names := make([]string, 0, len(visible))
+	for _, runner := range visible {
+		names = append(names, runner.GetMetadata().Name)
+	}
+	expected := []string{"Pinned action", "Second", "First", "Third"}
+	if len(names) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, names)
+		}
+	}
+	if len(pinOrders) != 2 || pinOrders[action.TriggerID] != 1 || pinOrders[second.TriggerID] != 2 {
+		t.Fatalf("expected the pin orders of the pinned commands, got %v", pinOrders)
+	}
+}
@@ -9,8 +9,6 @@ import (
 	"watools/pkg/db"
 	"watools/pkg/logger"
 	"watools/pkg/models"
-
-	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 // appWatchManager app watch manager darwin implementation
@@ -303,31 +301,37 @@ func (awm *appWatchManager) GetConfig() *WatcherConfig {
 
 // defaultAppEventHandler default app event handler darwin implementation
 type defaultAppEventHandler struct {
-	db  *db.WaDB
-	ctx context.Context
+	db        *db.WaDB
+	ctx       context.Context
+	onChanged func()
 }
 
-// NewDefaultAppEventHandler create default event handler
-func NewDefaultAppEventHandler(ctx context.Context) DefaultAppEventHandler {
+// NewDefaultAppEventHandler create default event handler, onChanged is called whenever the stored applications change
+func NewDefaultAppEventHandler(ctx context.Context, onChanged func()) DefaultAppEventHandler {
 	return &defaultAppEventHandler{
-		db:  db.GetWaDB(),
-		ctx: ctx,
+		db:        db.GetWaDB(),
+		ctx:       ctx,
+		onChanged: onChanged,
 	}
 }
 
 // OnAppAdded handle app added
 func (h *defaultAppEventHandler) OnAppAdded(command *models.ApplicationCommand) error {
+	if h.db.IsPathExcluded(h.ctx, command.Path) {
+		logger.Info(fmt.Sprintf("Skip excluded application: %s", command.Path))
+		return nil
+	}
 	// check if already exists
 	existingCommands := h.db.GetCommands(h.ctx)
 	for _, existing := range existingCommands {
-		if existing.Path == command.Path {
+		if existing.Path == command.Path && existing.ParentID == "" {
 			// if exists, update instead of insert (no event emission here to avoid duplicate)
 			return h.onAppModifiedInternal(command)
 		}
 	}
 
 	// insert new app
-	err := h.db.BatchInsertCommands(h.ctx, []*models.ApplicationCommand{command})
+	err := h.insertCommand(command)
 	if err == nil {
 		h.emitApplicationChanged()
 	}
@@ -339,7 +343,7 @@ func (h *defaultAppEventHandler) OnAppRemoved(path string) error {
 	// find and delete command by path
 	commands := h.db.GetCommands(h.ctx)
 	for _, command := range commands {
-		if command.Path == path {
+		if command.Path == path && command.ParentID == "" {
 			err := h.db.DeleteCommands(h.ctx, []string{command.ID})
 			if err == nil {
 				h.emitApplicationChanged()
@@ -364,17 +368,35 @@ func (h *defaultAppEventHandler) onAppModifiedInternal(command *models.Applicati
 	// find existing command and update
 	commands := h.db.GetCommands(h.ctx)
 	for _, existing := range commands {
-		if existing.Path == command.Path {
+		if existing.Path == command.Path && existing.ParentID == "" {
 			command.ID = existing.ID
-			return h.db.BatchUpdateCommands(h.ctx, []*models.ApplicationCommand{command})
+			return h.updateCommand(command)
 		}
 	}
 
 	// if not found, treat as new app (no event emission here to avoid duplicate)
-	return h.db.BatchInsertCommands(h.ctx, []*models.ApplicationCommand{command})
+	return h.insertCommand(command)
+}
+
+// insertCommand insert a new app together with its actions
+func (h *defaultAppEventHandler) insertCommand(command *models.ApplicationCommand) error {
+	if err := h.db.BatchInsertCommands(h.ctx, []*models.ApplicationCommand{command}); err != nil {
+		return err
+	}
+	return h.db.ReplaceCommandActions(h.ctx, command.ID, application.ParseApplicationActions(command))
 }
 
-// emitApplicationChanged emit application changed event to frontend
+// updateCommand update an existing app and replace its actions
+func (h *defaultAppEventHandler) updateCommand(command *models.ApplicationCommand) error {
+	if err := h.db.BatchUpdateCommands(h.ctx, []*models.ApplicationCommand{command}); err != nil {
+		return err
+	}
+	return h.db.ReplaceCommandActions(h.ctx, command.ID, application.ParseApplicationActions(command))
+}
+
+// emitApplicationChanged notify the owner of the handler that the applications changed
 func (h *defaultAppEventHandler) emitApplicationChanged() {
-	runtime.EventsEmit(h.ctx, "watools.applicationChanged")
+	if h.onChanged != nil {
+		h.onChanged()
+	}
 }
@@ -0,0 +1,225 @@
+package watcher
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"watools/pkg/logger"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DirWatcher reports changes of the direct entries of a few directories.
+// Events are debounced, onChange receives every path that changed during the delay.
+// Directories that do not exist yet are picked up once they are created.
+type DirWatcher struct {
+	watcher  *fsnotify.Watcher
+	dirs     []string
+	filter   func(path string) bool
+	delay    time.Duration
+	onChange func(paths []string)
+
+	mu          sync.Mutex
+	pendingDirs map[string]string
+	changed     map[string]struct{}
+	timer       *time.Timer
+	running     bool
+	done        chan struct{}
+}
+
+// NewDirWatcher create a watcher for dirs, filter may be nil to report every entry
+func NewDirWatcher(dirs []string, filter func(path string) bool, delay time.Duration, onChange func(paths []string)) (*DirWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fsnotify watcher: %w", err)
+	}
+	cleaned := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		cleaned = append(cleaned, filepath.Clean(dir))
+	}
+	return &DirWatcher{
+		watcher:     watcher,
+		dirs:        cleaned,
+		filter:      filter,
+		delay:       delay,
+		onChange:    onChange,
+		pendingDirs: make(map[string]string),
+		changed:     make(map[string]struct{}),
+		done:        make(chan struct{}),
+	}, nil
+}
+
+// Start start watching
+func (dw *DirWatcher) Start() error {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	if dw.running {
+		return fmt.Errorf("watcher is already running")
+	}
+	for _, dir := range dw.dirs {
+		if err := dw.watchOrDefer(dir); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to watch directory: %s", dir))
+		}
+	}
+	dw.running = true
+
+	go dw.handleEvents()
+	return nil
+}
+
+// Stop stop watching, pending changes are dropped
+func (dw *DirWatcher) Stop() error {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	if !dw.running {
+		return nil
+	}
+	dw.running = false
+	if dw.timer != nil {
+		dw.timer.Stop()
+	}
+	close(dw.done)
+	if err := dw.watcher.Close(); err != nil {
+		return fmt.Errorf("failed to close fsnotify watcher: %w", err)
+	}
+	return nil
+}
+
+// watchOrDefer watches dir, or its nearest existing ancestor when dir does not exist yet, caller must hold dw.mu
+func (dw *DirWatcher) watchOrDefer(dir string) error {
+	if fi, err := os.Stat(dir); err == nil && fi.IsDir() {
+		delete(dw.pendingDirs, dir)
+		return dw.watcher.Add(dir)
+	}
+
+	ancestor := filepath.Dir(dir)
+	for {
+		if fi, err := os.Stat(ancestor); err == nil && fi.IsDir() {
+			break
+		}
+		parent := filepath.Dir(ancestor)
+		if parent == ancestor {
+			return fmt.Errorf("no existing ancestor for %s", dir)
+		}
+		ancestor = parent
+	}
+	if err := dw.watcher.Add(ancestor); err != nil {
+		return fmt.Errorf("failed to add watch for %s: %w", ancestor, err)
+	}
+	dw.pendingDirs[dir] = ancestor
+	return nil
+}
+
+func (dw *DirWatcher) handleEvents() {
+	for {
+		select {
+		case <-dw.done:
+			return
+
+		case event, ok := <-dw.watcher.Events:
+			if !ok {
+				return
+			}
+			dw.handleEvent(event)
+
+		case err, ok := <-dw.watcher.Errors:
+			if !ok {
+				return
+			}
+			logger.Error(err, "DirWatcher error")
+		}
+	}
+}
+
+func (dw *DirWatcher) handleEvent(event fsnotify.Event) {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	if !dw.running {
+		return
+	}
+
+	if event.Has(fsnotify.Create) {
+		for dir := range dw.pendingDirs {
+			if dir != event.Name && !strings.HasPrefix(dir, event.Name+string(os.PathSeparator)) {
+				continue
+			}
+			if err := dw.watchOrDefer(dir); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to watch created directory: %s", dir))
+				continue
+			}
+			if _, stillPending := dw.pendingDirs[dir]; !stillPending {
+				dw.addCreatedEntries(dir)
+			}
+		}
+	}
+	if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+		for _, dir := range dw.dirs {
+			if dir == event.Name {
+				if err := dw.watchOrDefer(dir); err != nil {
+					logger.Error(err, fmt.Sprintf("Failed to wait for removed directory: %s", dir))
+				}
+			}
+		}
+	}
+
+	if dw.isWatchedEntry(event.Name) {
+		dw.markChanged(event.Name)
+	}
+}
+
+// addCreatedEntries reports the entries of a directory that appeared with their content, caller must hold dw.mu
+func (dw *DirWatcher) addCreatedEntries(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if dw.isWatchedEntry(path) {
+			dw.markChanged(path)
+		}
+	}
+}
+
+func (dw *DirWatcher) isWatchedEntry(path string) bool {
+	parent := filepath.Dir(path)
+	for _, dir := range dw.dirs {
+		if dir == parent {
+			return dw.filter == nil || dw.filter(path)
+		}
+	}
+	return false
+}
+
+// markChanged records a change and restarts the debounce timer, caller must hold dw.mu
+func (dw *DirWatcher) markChanged(path string) {
+	dw.changed[path] = struct{}{}
+	if dw.timer != nil {
+		dw.timer.Stop()
+	}
+	dw.timer = time.AfterFunc(dw.delay, dw.flush)
+}
+
+func (dw *DirWatcher) flush() {
+	dw.mu.Lock()
+	if !dw.running || len(dw.changed) == 0 {
+		dw.mu.Unlock()
+		return
+	}
+	paths := make([]string, 0, len(dw.changed))
+	for path := range dw.changed {
+		paths = append(paths, path)
+	}
+	dw.changed = make(map[string]struct{})
+	dw.mu.Unlock()
+
+	sort.Strings(paths)
+	dw.onChange(paths)
+}
@@ -0,0 +1,83 @@
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func startDirWatcher(t *testing.T, dirs []string, filter func(path string) bool) <-chan []string {
+	t.Helper()
+
+	changes := make(chan []string, 10)
+	dw, err := NewDirWatcher(dirs, filter, 50*time.Millisecond, func(paths []string) {
+		changes <- paths
+	})
+	if err != nil {
+		t.Fatalf("failed to create watcher: %v", err)
+	}
+	if err := dw.Start(); err != nil {
+		t.Fatalf("failed to start watcher: %v", err)
+	}
+	t.Cleanup(func() { _ = dw.Stop() })
+	return changes
+}
+
+func waitForChange(t *testing.T, changes <-chan []string) []string {
+	t.Helper()
+
+	select {
+	case paths := <-changes:
+		return paths
+	case <-time.After(3 * time.Second):
+		t.Fatalf("no change reported")
+		return nil
+	}
+}
+
+func TestDirWatcherReportsFilteredEntries(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	changes := startDirWatcher(t, []string{dir}, func(path string) bool {
+		return !strings.HasPrefix(filepath.Base(path), ".")
+	})
+
+	hiddenPath := filepath.Join(dir, ".swap")
+	scriptPath := filepath.Join(dir, "script.sh")
+	for _, path := range []string{hiddenPath, scriptPath} {
+		if err := os.WriteFile(path, []byte("echo hi\n"), 0755); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+
+	paths := waitForChange(t, changes)
+	if !slices.Equal(paths, []string{scriptPath}) {
+		t.Fatalf("expected only %s to be reported, got %v", scriptPath, paths)
+	}
+}
+
+func TestDirWatcherWatchesDirCreatedLater(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "config", "commands")
+	changes := startDirWatcher(t, []string{dir}, nil)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	// give the watcher time to pick up the new directory before writing into it
+	time.Sleep(200 * time.Millisecond)
+	path := filepath.Join(dir, "script.sh")
+	if err := os.WriteFile(path, []byte("echo hi\n"), 0755); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+
+	paths := waitForChange(t, changes)
+	if !slices.Contains(paths, path) {
+		t.Fatalf("expected %s to be reported, got %v", path, paths)
+	}
+}
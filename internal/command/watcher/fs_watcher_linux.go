@@ -0,0 +1,384 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"watools/pkg/logger"
+	"watools/pkg/xdg"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// FSWatcher file system event watcher
+type FSWatcher struct {
+	watcher   *fsnotify.Watcher
+	ctx       context.Context
+	cancel    context.CancelFunc
+	eventCh   chan AppChangeEvent
+	watchDirs []string
+	// pendingDirs are watch dirs that do not exist yet, their nearest existing ancestor is watched instead
+	pendingDirs map[string]string
+	mu          sync.RWMutex
+	running     bool
+}
+
+// NewFSWatcher create new file system watcher
+func NewFSWatcher() (*FSWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fsnotify watcher: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &FSWatcher{
+		watcher:     watcher,
+		ctx:         ctx,
+		cancel:      cancel,
+		eventCh:     make(chan AppChangeEvent, 100),
+		watchDirs:   getDefaultWatchDirs(),
+		pendingDirs: make(map[string]string),
+		running:     false,
+	}, nil
+}
+
+// getDefaultWatchDirs get default watch directories
+func getDefaultWatchDirs() []string {
+	return xdg.ApplicationDirs()
+}
+
+// Start start watcher
+func (fw *FSWatcher) Start() error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	if fw.running {
+		return fmt.Errorf("watcher is already running")
+	}
+
+	for _, dir := range fw.watchDirs {
+		if err := fw.watchOrDefer(dir); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to watch directory: %s", dir))
+		}
+	}
+
+	fw.running = true
+
+	go fw.handleEvents()
+
+	logger.Info(fmt.Sprintf("FSWatcher started, watching %d directories, %d pending", len(fw.watchDirs)-len(fw.pendingDirs), len(fw.pendingDirs)))
+	return nil
+}
+
+// Stop stop watcher
+func (fw *FSWatcher) Stop() error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	if !fw.running {
+		return nil
+	}
+
+	fw.cancel()
+	fw.running = false
+
+	if err := fw.watcher.Close(); err != nil {
+		return fmt.Errorf("failed to close fsnotify watcher: %w", err)
+	}
+
+	close(fw.eventCh)
+	logger.Info("FSWatcher stopped")
+	return nil
+}
+
+// EventChannel get event channel
+func (fw *FSWatcher) EventChannel() <-chan AppChangeEvent {
+	return fw.eventCh
+}
+
+// addWatchDir add watch directory
+func (fw *FSWatcher) addWatchDir(dir string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return fmt.Errorf("directory does not exist: %s", dir)
+	}
+
+	if err := fw.watcher.Add(dir); err != nil {
+		return fmt.Errorf("failed to add watch for %s: %w", dir, err)
+	}
+
+	logger.Info(fmt.Sprintf("Added watch for directory: %s", dir))
+	return nil
+}
+
+func (fw *FSWatcher) addWatchDirRecursive(dir string) error {
+	if err := fw.addWatchDir(dir); err != nil {
+		return err
+	}
+
+	return filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path == dir {
+			return nil
+		}
+		if err := fw.addWatchDir(path); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to watch subdirectory: %s", path))
+		}
+		return nil
+	})
+}
+
+// watchOrDefer watches dir recursively, or its nearest existing ancestor when dir does not exist yet
+func (fw *FSWatcher) watchOrDefer(dir string) error {
+	if fi, err := os.Stat(dir); err == nil && fi.IsDir() {
+		delete(fw.pendingDirs, dir)
+		return fw.addWatchDirRecursive(dir)
+	}
+
+	ancestor := filepath.Dir(dir)
+	for {
+		if fi, err := os.Stat(ancestor); err == nil && fi.IsDir() {
+			break
+		}
+		parent := filepath.Dir(ancestor)
+		if parent == ancestor {
+			return fmt.Errorf("no existing ancestor for %s", dir)
+		}
+		ancestor = parent
+	}
+	if err := fw.watcher.Add(ancestor); err != nil {
+		return fmt.Errorf("failed to add watch for %s: %w", ancestor, err)
+	}
+	fw.pendingDirs[dir] = ancestor
+	logger.Info(fmt.Sprintf("Directory %s does not exist yet, watching %s for its creation", dir, ancestor))
+	return nil
+}
+
+// isWatchRoot check if path is one of the configured watch dirs
+func (fw *FSWatcher) isWatchRoot(path string) bool {
+	for _, dir := range fw.watchDirs {
+		if dir == path {
+			return true
+		}
+	}
+	return false
+}
+
+// isInsideWatchDir check if path is inside one of the configured watch dirs
+func (fw *FSWatcher) isInsideWatchDir(path string) bool {
+	for _, dir := range fw.watchDirs {
+		if strings.HasPrefix(path, dir+string(os.PathSeparator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleDirChange handle creation and removal of directories that are watched or awaited
+func (fw *FSWatcher) handleDirChange(event fsnotify.Event) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	if !fw.running {
+		return
+	}
+
+	if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+		if fw.isWatchRoot(event.Name) {
+			if err := fw.watchOrDefer(event.Name); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to wait for removed directory: %s", event.Name))
+			}
+		}
+		return
+	}
+
+	if !event.Has(fsnotify.Create) {
+		return
+	}
+	fi, err := os.Stat(event.Name)
+	if err != nil || !fi.IsDir() {
+		return
+	}
+
+	var createdDirs []string
+	for dir := range fw.pendingDirs {
+		if dir != event.Name && !strings.HasPrefix(dir, event.Name+string(os.PathSeparator)) {
+			continue
+		}
+		if err := fw.watchOrDefer(dir); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to watch created directory: %s", dir))
+			continue
+		}
+		if _, stillPending := fw.pendingDirs[dir]; !stillPending {
+			createdDirs = append(createdDirs, dir)
+		}
+	}
+	if len(createdDirs) == 0 && fw.isInsideWatchDir(event.Name) {
+		if err := fw.addWatchDirRecursive(event.Name); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to watch subdirectory: %s", event.Name))
+			return
+		}
+		createdDirs = append(createdDirs, event.Name)
+	}
+
+	// desktop files may have been written before the watch was in place
+	for _, dir := range createdDirs {
+		_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !fw.isAppEvent(path) {
+				return nil
+			}
+			fw.sendEvent(AppChangeEvent{Type: AppAdded, Path: path})
+			return nil
+		})
+	}
+}
+
+// handleEvents handle file system events
+func (fw *FSWatcher) handleEvents() {
+	debounceMap := make(map[string]*time.Timer)
+	debounceOps := make(map[string]fsnotify.Op)
+	debounceMu := sync.Mutex{}
+
+	for {
+		select {
+		case <-fw.ctx.Done():
+			return
+
+		case event, ok := <-fw.watcher.Events:
+			if !ok {
+				return
+			}
+
+			if !fw.isAppEvent(event.Name) {
+				fw.handleDirChange(event)
+				continue
+			}
+
+			// collect every operation seen during the debounce window so that
+			// remove + create and rename-over-write sequences are classified as one change
+			debounceMu.Lock()
+			if timer, exists := debounceMap[event.Name]; exists {
+				timer.Stop()
+			}
+			debounceOps[event.Name] |= event.Op
+			path := event.Name
+			debounceMap[path] = time.AfterFunc(500*time.Millisecond, func() {
+				debounceMu.Lock()
+				op := debounceOps[path]
+				delete(debounceOps, path)
+				delete(debounceMap, path)
+				debounceMu.Unlock()
+				fw.processEvent(path, op)
+			})
+			debounceMu.Unlock()
+
+		case err, ok := <-fw.watcher.Errors:
+			if !ok {
+				return
+			}
+			logger.Error(err, "FSWatcher error")
+		}
+	}
+}
+
+// isAppEvent check if event is desktop entry related.
+// Package manager temp files such as foo.desktop.dpkg-new or .foo.desktop.XXXXXX are ignored,
+// their final rename onto foo.desktop is reported as a create of the target.
+func (fw *FSWatcher) isAppEvent(path string) bool {
+	name := filepath.Base(path)
+	return strings.HasSuffix(name, ".desktop") && !strings.HasPrefix(name, ".")
+}
+
+// classifyChange map the accumulated operations of one debounce window to an app change type
+func classifyChange(op fsnotify.Op, exists bool) AppChangeType {
+	switch {
+	case !exists:
+		return AppRemoved
+	case op.Has(fsnotify.Remove) || op.Has(fsnotify.Rename):
+		// the file was replaced in place
+		return AppModified
+	case op.Has(fsnotify.Create):
+		return AppAdded
+	default:
+		return AppModified
+	}
+}
+
+// processEvent process specific event
+func (fw *FSWatcher) processEvent(path string, op fsnotify.Op) {
+	if !op.Has(fsnotify.Create) && !op.Has(fsnotify.Remove) && !op.Has(fsnotify.Rename) &&
+		!op.Has(fsnotify.Write) && !op.Has(fsnotify.Chmod) {
+		return
+	}
+
+	_, err := os.Stat(path)
+	changeType := classifyChange(op, err == nil)
+
+	fw.mu.RLock()
+	defer fw.mu.RUnlock()
+	if !fw.running {
+		return
+	}
+	fw.sendEvent(AppChangeEvent{Type: changeType, Path: path})
+}
+
+// sendEvent send event without blocking, caller must hold fw.mu
+func (fw *FSWatcher) sendEvent(event AppChangeEvent) {
+	select {
+	case fw.eventCh <- event:
+		logger.Info(fmt.Sprintf("App %s detected: %s",
+			map[AppChangeType]string{
+				AppAdded:    "added",
+				AppRemoved:  "removed",
+				AppModified: "modified",
+			}[event.Type], event.Path))
+
+	case <-fw.ctx.Done():
+		return
+
+	default:
+		logger.Error(nil, "FSWatcher event channel is full, dropping event")
+	}
+}
+
+// IsRunning check if running
+func (fw *FSWatcher) IsRunning() bool {
+	fw.mu.RLock()
+	defer fw.mu.RUnlock()
+	return fw.running
+}
+
+// AddWatchDir dynamically add watch directory
+func (fw *FSWatcher) AddWatchDir(dir string) error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	dir = filepath.Clean(dir)
+	if !fw.isWatchRoot(dir) {
+		fw.watchDirs = append(fw.watchDirs, dir)
+	}
+	if !fw.running {
+		return nil
+	}
+
+	return fw.watchOrDefer(dir)
+}
+
+// GetWatchDirs get watch directories list
+func (fw *FSWatcher) GetWatchDirs() []string {
+	fw.mu.RLock()
+	defer fw.mu.RUnlock()
+
+	dirs := make([]string, len(fw.watchDirs))
+	copy(dirs, fw.watchDirs)
+	return dirs
+}
@@ -0,0 +1,150 @@
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+	"watools/pkg/xdg"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestIsAppEventIgnoresTempFiles(t *testing.T) {
+	t.Parallel()
+
+	fw := &FSWatcher{}
+	testCases := map[string]bool{
+		"/usr/share/applications/firefox.desktop":          true,
+		"/usr/share/applications/firefox.desktop.dpkg-new": false,
+		"/usr/share/applications/.firefox.desktop.X1Y2Z3":  false,
+		"/usr/share/applications/.hidden.desktop":          false,
+		"/usr/share/applications/mimeinfo.cache":           false,
+	}
+	for path, want := range testCases {
+		if got := fw.isAppEvent(path); got != want {
+			t.Fatalf("isAppEvent(%s): expected %v, got %v", path, want, got)
+		}
+	}
+}
+
+func TestClassifyChange(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		op     fsnotify.Op
+		exists bool
+		want   AppChangeType
+	}{
+		{name: "new file", op: fsnotify.Create | fsnotify.Write, exists: true, want: AppAdded},
+		{name: "edited file", op: fsnotify.Write, exists: true, want: AppModified},
+		{name: "replaced file", op: fsnotify.Remove | fsnotify.Create, exists: true, want: AppModified},
+		{name: "removed file", op: fsnotify.Remove, exists: false, want: AppRemoved},
+		{name: "renamed away", op: fsnotify.Rename, exists: false, want: AppRemoved},
+	}
+	for _, testCase := range testCases {
+		if got := classifyChange(testCase.op, testCase.exists); got != testCase.want {
+			t.Fatalf("%s: expected %v, got %v", testCase.name, testCase.want, got)
+		}
+	}
+}
+
+func TestFSWatcherWatchesApplicationDirCreatedLater(t *testing.T) {
+	rootDir := t.TempDir()
+	dataHome := filepath.Join(rootDir, "home", ".local", "share")
+	if err := os.MkdirAll(filepath.Dir(dataHome), 0755); err != nil {
+		t.Fatalf("failed to create home dir: %v", err)
+	}
+	t.Setenv("XDG_DATA_HOME", dataHome)
+	t.Setenv("XDG_DATA_DIRS", filepath.Join(rootDir, "system"))
+	isolateSandboxDirs(t, rootDir)
+
+	fw, err := NewFSWatcher()
+	if err != nil {
+		t.Fatalf("failed to create watcher: %v", err)
+	}
+	if err := fw.Start(); err != nil {
+		t.Fatalf("failed to start watcher: %v", err)
+	}
+	defer fw.Stop()
+
+	appDir := filepath.Join(dataHome, "applications")
+	if err := os.MkdirAll(appDir, 0755); err != nil {
+		t.Fatalf("failed to create applications dir: %v", err)
+	}
+	// give the watcher time to pick up the new directory before writing into it
+	time.Sleep(200 * time.Millisecond)
+	desktopPath := filepath.Join(appDir, "app.desktop")
+	if err := os.WriteFile(desktopPath, []byte("[Desktop Entry]\nType=Application\nName=App\nExec=app\n"), 0644); err != nil {
+		t.Fatalf("failed to write desktop file: %v", err)
+	}
+
+	select {
+	case event := <-fw.EventChannel():
+		if event.Path != desktopPath || event.Type != AppAdded {
+			t.Fatalf("unexpected event %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected desktop file creation to be reported")
+	}
+}
+
+func isolateSandboxDirs(t *testing.T, rootDir string) {
+	t.Helper()
+	xdg.SetSystemSandboxDataDir(xdg.OriginFlatpak, filepath.Join(rootDir, "flatpak", "exports", "share"))
+	xdg.SetSystemSandboxDataDir(xdg.OriginSnap, filepath.Join(rootDir, "snapd", "desktop"))
+	t.Cleanup(func() {
+		xdg.SetSystemSandboxDataDir(xdg.OriginFlatpak, xdg.FlatpakSystemDataDir)
+		xdg.SetSystemSandboxDataDir(xdg.OriginSnap, xdg.SnapDataDir)
+	})
+}
+
+func TestFSWatcherWatchesSandboxExports(t *testing.T) {
+	rootDir := t.TempDir()
+	dataHome := filepath.Join(rootDir, "home", ".local", "share")
+	t.Setenv("XDG_DATA_HOME", dataHome)
+	t.Setenv("XDG_DATA_DIRS", filepath.Join(rootDir, "system"))
+	isolateSandboxDirs(t, rootDir)
+
+	fw, err := NewFSWatcher()
+	if err != nil {
+		t.Fatalf("failed to create watcher: %v", err)
+	}
+	want := []string{
+		filepath.Join(dataHome, "flatpak", "exports", "share", "applications"),
+		filepath.Join(rootDir, "flatpak", "exports", "share", "applications"),
+		filepath.Join(rootDir, "snapd", "desktop", "applications"),
+	}
+	watchDirs := fw.GetWatchDirs()
+	for _, dir := range want {
+		if !slices.Contains(watchDirs, dir) {
+			t.Fatalf("expected %s to be watched, got %v", dir, watchDirs)
+		}
+	}
+	if err := fw.Start(); err != nil {
+		t.Fatalf("failed to start watcher: %v", err)
+	}
+	defer fw.Stop()
+
+	// flatpak creates the exports tree on the first installation
+	appDir := want[1]
+	if err := os.MkdirAll(appDir, 0755); err != nil {
+		t.Fatalf("failed to create exports dir: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	desktopPath := filepath.Join(appDir, "org.example.App.desktop")
+	if err := os.WriteFile(desktopPath, []byte("[Desktop Entry]\nType=Application\nName=App\nExec=app\nX-Flatpak=org.example.App\n"), 0644); err != nil {
+		t.Fatalf("failed to write desktop file: %v", err)
+	}
+
+	select {
+	case event := <-fw.EventChannel():
+		if event.Path != desktopPath || event.Type != AppAdded {
+			t.Fatalf("unexpected event %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected exported desktop file to be reported")
+	}
+}
@@ -9,9 +9,13 @@ import (
 	"watools/internal/api"
 	"watools/internal/app"
 	"watools/internal/command"
+	"watools/internal/files"
+	"watools/internal/history"
 	"watools/internal/plugin"
 	"watools/pkg/logger"
 	"watools/pkg/models"
+
+	"github.com/samber/mo"
 )
 
 type WaAppCoordinator struct {
@@ -19,6 +23,8 @@ type WaAppCoordinator struct {
 	waApp       *app.WaApp
 	waLaunchApp *command.WaLaunchApp
 	waPluginApp *plugin.WaPlugin
+	waFiles     *files.WaFiles
+	waHistory   *history.WaHistory
 	waApi       *api.WaApi
 }
 
@@ -33,6 +39,8 @@ func GetWaAppCoordinator() *WaAppCoordinator {
 			waApp:       app.GetWaApp(),
 			waLaunchApp: command.GetWaLaunch(),
 			waPluginApp: plugin.GetWaPlugin(),
+			waFiles:     files.GetWaFiles(),
+			waHistory:   history.GetWaHistory(),
 			waApi:       api.GetWaApi(),
 		}
 	})
@@ -44,15 +52,32 @@ func (w *WaAppCoordinator) Startup(ctx context.Context) {
 
 	config.InitWithWailsContext(ctx)
 
+	w.waLaunchApp.SetClipboardTextReader(w.waApp.GetClipboardText)
+	w.waLaunchApp.SetClipboardTextWriter(w.waApp.SetClipboardText)
+	w.waLaunchApp.SetRevealer(w.waApi.OpenFolderWithPath)
+	w.waHistory.SetClipboard(history.Clipboard{
+		Watch:        w.waApp.WatchClipboard,
+		Read:         w.readClipboardForHistory,
+		IsConcealed:  w.waApp.IsClipboardConcealed,
+		FrontmostApp: w.waApp.GetFrontmostApp,
+		WriteText:    w.waApp.SetClipboardText,
+		WriteImage:   w.waApi.CopyImageFileToClipboard,
+		WriteFiles:   w.waApp.SetClipboardFiles,
+	})
+
 	w.waApp.OnStartup(ctx)
 	w.waLaunchApp.OnStartup(ctx)
 	w.waPluginApp.OnStartup(ctx)
+	w.waFiles.OnStartup(ctx)
+	w.waHistory.OnStartup(ctx)
 }
 
 func (w *WaAppCoordinator) Shutdown(ctx context.Context) {
 	w.waApp.Shutdown(ctx)
 	w.waLaunchApp.Shutdown(ctx)
 	w.waPluginApp.OnShutdown(ctx)
+	w.waFiles.Shutdown(ctx)
+	w.waHistory.Shutdown(ctx)
 }
 
 // region app
@@ -85,70 +110,478 @@ func (w *WaAppCoordinator) GetOperatorCommandsApi() []interface{} {
 	return w.waLaunchApp.GetOperationCommands()
 }
 
-func (w *WaAppCoordinator) TriggerCommandApi(uniqueTriggerID string, triggerCategory string) {
-	category, err := models.ParseCommandCategory(triggerCategory)
-	if err != nil {
-		logger.Error(err)
+// GetCommandSourcesApi returns the names of the registered command sources
+func (w *WaAppCoordinator) GetCommandSourcesApi() []string {
+	return w.waLaunchApp.GetCommandSources()
+}
+
+// GetCommandsApi returns the commands of one command source
+func (w *WaAppCoordinator) GetCommandsApi(source string) ([]interface{}, error) {
+	return w.waLaunchApp.GetCommands(source)
+}
+
+// SearchApi returns at most limit commands of every source matching query, ranked best first.
+// Results hold the "command", the matched "field" and "text", and "highlights" as rune offsets into the text.
+// Plugin entries are not searched, they are matched by their own match() in the frontend.
+func (w *WaAppCoordinator) SearchApi(query string, limit int) []interface{} {
+	return w.waLaunchApp.Search(query, limit)
+}
+
+// RefreshCommandsApi rescans a command source, "watools.commandsChanged" is emitted when its commands changed
+func (w *WaAppCoordinator) RefreshCommandsApi(source string) error {
+	return w.waLaunchApp.RefreshCommands(source)
+}
+
+// TriggerCommandApi runs a command, triggerCategory is the name of the source that listed it.
+// argumentMap may hold "text", "files" and a "clipboard" snapshot with "text", "imageBase64" and "files",
+// only the kinds listed in the "accepts" field of the command are allowed.
+func (w *WaAppCoordinator) TriggerCommandApi(uniqueTriggerID string, triggerCategory string, argumentMap map[string]interface{}) error {
+	return w.waLaunchApp.TriggerCommand(uniqueTriggerID, triggerCategory, argumentsFromMap(argumentMap))
+}
+
+// RunCommandActionApi runs one of the "actions" listed by a command, such as "kill" on a process, "openTerminal"
+// on a git repo, "reveal" on a file or "run" on a script. argumentMap is read like for TriggerCommandApi and may
+// also hold "values", the answers to several prompts. The result depends on the action: the output of a script,
+// the text and {cursor} offset of a copied snippet, nil otherwise.
+// Files found by SearchFilesApi are run with the "File" category and their "triggerId".
+func (w *WaAppCoordinator) RunCommandActionApi(uniqueTriggerID string, triggerCategory string, action string, argumentMap map[string]interface{}) (interface{}, error) {
+	return w.waLaunchApp.RunCommandAction(uniqueTriggerID, triggerCategory, action, argumentsFromMap(argumentMap))
+}
+
+func argumentsFromMap(argumentMap map[string]interface{}) models.CommandArguments {
+	arguments := models.CommandArguments{}
+	arguments.Text, _ = argumentMap["text"].(string)
+	arguments.Values = stringsFromInterface(argumentMap["values"])
+	arguments.Files = stringsFromInterface(argumentMap["files"])
+	if clipboardMap, ok := argumentMap["clipboard"].(map[string]interface{}); ok {
+		snapshot := models.ClipboardSnapshot{Files: stringsFromInterface(clipboardMap["files"])}
+		snapshot.Text, _ = clipboardMap["text"].(string)
+		snapshot.ImageBase64, _ = clipboardMap["imageBase64"].(string)
+		arguments.Clipboard = mo.Some(snapshot)
 	}
-	w.waLaunchApp.TriggerCommand(uniqueTriggerID, category)
+	return arguments
 }
 
-func (w *WaAppCoordinator) UpdateApplicationUsageApi(usageUpdates []map[string]interface{}) error {
-	updates := make([]models.ApplicationUsageUpdate, len(usageUpdates))
-	for i, update := range usageUpdates {
-		id, _ := update["id"].(string)
-		lastUsedAtStr, _ := update["lastUsedAt"].(string)
-		usedCount, _ := update["usedCount"].(float64)
+// RecordSelectionApi stores a launch with "triggerId", "source", "query" and "clipboardType".
+// It is the only writer of the usage count of applications and plugins, which it increments on the server.
+func (w *WaAppCoordinator) RecordSelectionApi(requestMap map[string]interface{}) error {
+	event := models.SelectionEvent{}
+	event.TriggerID, _ = requestMap["triggerId"].(string)
+	event.Source, _ = requestMap["source"].(string)
+	event.Query, _ = requestMap["query"].(string)
+	event.ClipboardType, _ = requestMap["clipboardType"].(string)
+	return w.waLaunchApp.RecordSelection(event)
+}
 
-		lastUsedAt, err := time.Parse(time.RFC3339, lastUsedAtStr)
-		if err != nil {
-			logger.Error(err, "Failed to parse lastUsedAt")
-			continue
+// GetSelectionScoresApi returns the frecency scores of recently selected commands, boosted for the given query
+func (w *WaAppCoordinator) GetSelectionScoresApi(query string, clipboardType string) ([]map[string]interface{}, error) {
+	scores, err := w.waLaunchApp.GetSelectionScores(query, clipboardType)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]map[string]interface{}, len(scores))
+	for i, score := range scores {
+		result[i] = map[string]interface{}{
+			"triggerId":      score.TriggerID,
+			"source":         score.Source,
+			"score":          score.Score,
+			"frecency":       score.Frecency,
+			"queryBoost":     score.QueryBoost,
+			"count":          score.Count,
+			"lastSelectedAt": score.LastSelectedAt.Format(time.RFC3339),
 		}
+	}
+	return result, nil
+}
 
-		updates[i] = models.ApplicationUsageUpdate{
-			ID:         id,
-			LastUsedAt: lastUsedAt,
-			UsedCount:  int(usedCount),
+// stringsFromInterface reads a JSON string array, other values are skipped
+func stringsFromInterface(value interface{}) []string {
+	items, _ := value.([]interface{})
+	var result []string
+	for _, item := range items {
+		if str, ok := item.(string); ok && str != "" {
+			result = append(result, str)
 		}
 	}
+	return result
+}
 
-	return w.waLaunchApp.UpdateApplicationUsage(updates)
+// GetApplicationAliasesApi lists the aliases of an application, they are also in the "aliases" field of its command
+func (w *WaAppCoordinator) GetApplicationAliasesApi(applicationID string) ([]string, error) {
+	return w.waLaunchApp.GetApplicationAliases(applicationID)
+}
+
+func (w *WaAppCoordinator) AddApplicationAliasApi(applicationID string, alias string) error {
+	return w.waLaunchApp.AddApplicationAlias(applicationID, alias)
+}
+
+func (w *WaAppCoordinator) RemoveApplicationAliasApi(applicationID string, alias string) error {
+	return w.waLaunchApp.RemoveApplicationAlias(applicationID, alias)
+}
+
+// SetCommandHiddenApi hides a command from GetCommandsApi and SearchApi, or shows it again
+func (w *WaAppCoordinator) SetCommandHiddenApi(source string, uniqueTriggerID string, hidden bool) error {
+	return w.waLaunchApp.SetCommandHidden(source, uniqueTriggerID, hidden)
+}
+
+// PinCommandApi lists a command before the unpinned ones of its source, pinned commands are sorted by order
+func (w *WaAppCoordinator) PinCommandApi(source string, uniqueTriggerID string, order int) error {
+	return w.waLaunchApp.PinCommand(source, uniqueTriggerID, order)
+}
+
+func (w *WaAppCoordinator) UnpinCommandApi(source string, uniqueTriggerID string) error {
+	return w.waLaunchApp.UnpinCommand(source, uniqueTriggerID)
+}
+
+func (w *WaAppCoordinator) GetCommandVisibilitiesApi() ([]interface{}, error) {
+	return w.waLaunchApp.GetCommandVisibilities()
+}
+
+func (w *WaAppCoordinator) GetCommandExclusionsApi() ([]string, error) {
+	return w.waLaunchApp.GetCommandExclusions()
+}
+
+// AddCommandExclusionApi excludes applications and scripts by path glob, e.g. "~/.local/share/applications/wine/**"
+func (w *WaAppCoordinator) AddCommandExclusionApi(pattern string) error {
+	return w.waLaunchApp.AddCommandExclusion(pattern)
+}
+
+func (w *WaAppCoordinator) RemoveCommandExclusionApi(pattern string) error {
+	return w.waLaunchApp.RemoveCommandExclusion(pattern)
 }
 
 // end region command
 
-// region plugin
+// region quicklink
 
-func (w *WaAppCoordinator) GetPluginsApi() []map[string]interface{} {
-	return w.waPluginApp.GetPlugins()
+// GetQuicklinksApi returns every quicklink
+func (w *WaAppCoordinator) GetQuicklinksApi() ([]interface{}, error) {
+	return w.waLaunchApp.GetQuicklinks()
 }
 
-func (w *WaAppCoordinator) GetPluginJsEntryUrlApi(packageID string) string {
-	return w.waPluginApp.GetJsEntryUrl(packageID)
+// CreateQuicklinkApi stores a new quicklink from name, keyword, url, icon and targetApp
+func (w *WaAppCoordinator) CreateQuicklinkApi(requestMap map[string]interface{}) (map[string]interface{}, error) {
+	name, _ := requestMap["name"].(string)
+	keyword, _ := requestMap["keyword"].(string)
+	url, _ := requestMap["url"].(string)
+	icon, _ := requestMap["icon"].(string)
+	targetApp, _ := requestMap["targetApp"].(string)
+
+	quicklink, err := w.waLaunchApp.CreateQuicklink(name, keyword, url, icon, targetApp)
+	if err != nil {
+		return nil, err
+	}
+	return quicklinkToMap(quicklink), nil
+}
+
+// UpdateQuicklinkApi replaces the fields of the quicklink with the given id
+func (w *WaAppCoordinator) UpdateQuicklinkApi(requestMap map[string]interface{}) (map[string]interface{}, error) {
+	id, _ := requestMap["id"].(string)
+	name, _ := requestMap["name"].(string)
+	keyword, _ := requestMap["keyword"].(string)
+	url, _ := requestMap["url"].(string)
+	icon, _ := requestMap["icon"].(string)
+	targetApp, _ := requestMap["targetApp"].(string)
+
+	if id == "" {
+		return nil, fmt.Errorf("id is required")
+	}
+	quicklink, err := w.waLaunchApp.UpdateQuicklink(id, name, keyword, url, icon, targetApp)
+	if err != nil {
+		return nil, err
+	}
+	return quicklinkToMap(quicklink), nil
+}
+
+// DeleteQuicklinkApi removes a quicklink
+func (w *WaAppCoordinator) DeleteQuicklinkApi(id string) error {
+	return w.waLaunchApp.DeleteQuicklink(id)
+}
+
+func quicklinkToMap(quicklink *models.QuicklinkCommand) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          quicklink.ID,
+		"triggerId":   quicklink.TriggerID,
+		"name":        quicklink.Name,
+		"keyword":     quicklink.Keyword,
+		"url":         quicklink.URL,
+		"icon":        quicklink.Icon,
+		"targetApp":   quicklink.TargetApp,
+		"hasArgument": quicklink.HasArgument,
+	}
+}
+
+// end region quicklink
+
+// region snippet
+
+// GetSnippetsApi returns every snippet
+func (w *WaAppCoordinator) GetSnippetsApi() ([]interface{}, error) {
+	return w.waLaunchApp.GetSnippets()
+}
+
+// CreateSnippetApi stores a new snippet from name, keyword, body and tags
+func (w *WaAppCoordinator) CreateSnippetApi(requestMap map[string]interface{}) (map[string]interface{}, error) {
+	name, _ := requestMap["name"].(string)
+	keyword, _ := requestMap["keyword"].(string)
+	body, _ := requestMap["body"].(string)
+
+	snippet, err := w.waLaunchApp.CreateSnippet(name, keyword, body, requestTags(requestMap))
+	if err != nil {
+		return nil, err
+	}
+	return snippetToMap(snippet), nil
+}
+
+// UpdateSnippetApi replaces the fields of the snippet with the given id
+func (w *WaAppCoordinator) UpdateSnippetApi(requestMap map[string]interface{}) (map[string]interface{}, error) {
+	id, _ := requestMap["id"].(string)
+	name, _ := requestMap["name"].(string)
+	keyword, _ := requestMap["keyword"].(string)
+	body, _ := requestMap["body"].(string)
+
+	if id == "" {
+		return nil, fmt.Errorf("id is required")
+	}
+	snippet, err := w.waLaunchApp.UpdateSnippet(id, name, keyword, body, requestTags(requestMap))
+	if err != nil {
+		return nil, err
+	}
+	return snippetToMap(snippet), nil
+}
+
+// DeleteSnippetApi removes a snippet
+func (w *WaAppCoordinator) DeleteSnippetApi(id string) error {
+	return w.waLaunchApp.DeleteSnippet(id)
+}
+
+// ImportSnippetsApi imports a .json or .alfredsnippets file and returns how many snippets were stored
+func (w *WaAppCoordinator) ImportSnippetsApi(path string) (int, error) {
+	return w.waLaunchApp.ImportSnippets(path)
+}
+
+// ImportSnippetsByFileDialogApi imports a snippet file chosen in a file dialog
+func (w *WaAppCoordinator) ImportSnippetsByFileDialogApi() (int, error) {
+	return w.waLaunchApp.ImportSnippetsByFileDialog()
+}
+
+// ExportSnippetsApi writes every snippet to path as JSON or as an Alfred collection, by extension
+func (w *WaAppCoordinator) ExportSnippetsApi(path string) error {
+	return w.waLaunchApp.ExportSnippets(path)
 }
 
-func (w *WaAppCoordinator) UpdatePluginUsageApi(usageUpdates []map[string]interface{}) error {
-	updates := make([]models.PluginUsageUpdate, len(usageUpdates))
-	for i, update := range usageUpdates {
-		packageID, _ := update["packageId"].(string)
-		lastUsedAtStr, _ := update["lastUsedAt"].(string)
-		usedCount, _ := update["usedCount"].(float64)
+// ExportSnippetsByFileDialogApi exports every snippet to a file chosen in a save dialog
+func (w *WaAppCoordinator) ExportSnippetsByFileDialogApi() error {
+	return w.waLaunchApp.ExportSnippetsByFileDialog()
+}
 
-		lastUsedAt, err := time.Parse(time.RFC3339, lastUsedAtStr)
-		if err != nil {
-			logger.Error(err, "Failed to parse lastUsedAt")
-			continue
+func requestTags(requestMap map[string]interface{}) []string {
+	values, _ := requestMap["tags"].([]interface{})
+	tags := make([]string, 0, len(values))
+	for _, value := range values {
+		if tag, ok := value.(string); ok {
+			tags = append(tags, tag)
 		}
+	}
+	return tags
+}
+
+func snippetToMap(snippet *models.SnippetCommand) map[string]interface{} {
+	return map[string]interface{}{
+		"id":        snippet.ID,
+		"triggerId": snippet.TriggerID,
+		"name":      snippet.Name,
+		"keyword":   snippet.Keyword,
+		"body":      snippet.Body,
+		"tags":      snippet.Tags,
+		"prompts":   snippet.Prompts,
+	}
+}
+
+// end region snippet
+
+// region files
+
+// SearchFilesApi searches the indexed files by name and path, each result has the "triggerId" and the "actions"
+// RunCommandActionApi accepts with the "File" category
+func (w *WaAppCoordinator) SearchFilesApi(query string, limit int) ([]interface{}, error) {
+	return w.waFiles.Search(query, limit)
+}
+
+func (w *WaAppCoordinator) GetFileIndexStatsApi() (map[string]interface{}, error) {
+	return w.waFiles.GetStats()
+}
+
+// RebuildFileIndexApi rescans every root in the background, watools.fileIndexChanged is emitted when it is done
+func (w *WaAppCoordinator) RebuildFileIndexApi() {
+	w.waFiles.Rebuild()
+}
+
+func (w *WaAppCoordinator) GetFileIndexRootsApi() ([]string, error) {
+	return w.waFiles.GetRoots()
+}
+
+func (w *WaAppCoordinator) AddFileIndexRootApi(path string) error {
+	return w.waFiles.AddRoot(path)
+}
+
+func (w *WaAppCoordinator) RemoveFileIndexRootApi(path string) error {
+	return w.waFiles.RemoveRoot(path)
+}
+
+func (w *WaAppCoordinator) GetFileIndexIgnoresApi() ([]string, error) {
+	return w.waFiles.GetIgnores()
+}
+
+// AddFileIndexIgnoreApi skips paths matching a .gitignore pattern below every root
+func (w *WaAppCoordinator) AddFileIndexIgnoreApi(pattern string) error {
+	return w.waFiles.AddIgnore(pattern)
+}
+
+func (w *WaAppCoordinator) RemoveFileIndexIgnoreApi(pattern string) error {
+	return w.waFiles.RemoveIgnore(pattern)
+}
+
+// end region files
+
+// region git repos
+
+// GetGitRepoRootsApi returns the searched directories as maps with "path" and "maxDepth"
+func (w *WaAppCoordinator) GetGitRepoRootsApi() ([]map[string]interface{}, error) {
+	roots, err := w.waLaunchApp.GetGitRepoRoots()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]map[string]interface{}, len(roots))
+	for i, root := range roots {
+		result[i] = map[string]interface{}{
+			"path":     root.Path,
+			"maxDepth": root.MaxDepth,
+		}
+	}
+	return result, nil
+}
+
+// AddGitRepoRootApi searches a directory for repositories, maxDepth 0 uses the default depth
+func (w *WaAppCoordinator) AddGitRepoRootApi(path string, maxDepth int) error {
+	return w.waLaunchApp.AddGitRepoRoot(path, maxDepth)
+}
+
+func (w *WaAppCoordinator) RemoveGitRepoRootApi(path string) error {
+	return w.waLaunchApp.RemoveGitRepoRoot(path)
+}
+
+func (w *WaAppCoordinator) GetGitRepoIgnoresApi() ([]string, error) {
+	return w.waLaunchApp.GetGitRepoIgnores()
+}
+
+// AddGitRepoIgnoreApi skips directories matching a .gitignore pattern below every root
+func (w *WaAppCoordinator) AddGitRepoIgnoreApi(pattern string) error {
+	return w.waLaunchApp.AddGitRepoIgnore(pattern)
+}
+
+func (w *WaAppCoordinator) RemoveGitRepoIgnoreApi(pattern string) error {
+	return w.waLaunchApp.RemoveGitRepoIgnore(pattern)
+}
+
+// end region git repos
+
+// region clipboard history
+
+// SearchClipboardHistoryApi returns recorded clipboard entries matching query, pinned first.
+// kind is "text", "image", "files" or empty for every kind.
+func (w *WaAppCoordinator) SearchClipboardHistoryApi(query string, kind string, limit int, offset int) ([]interface{}, error) {
+	return w.waHistory.Search(query, kind, limit, offset)
+}
+
+// CopyClipboardHistoryItemApi copies an entry back to the clipboard and moves it to the top
+func (w *WaAppCoordinator) CopyClipboardHistoryItemApi(id int64) error {
+	return w.waHistory.Copy(id)
+}
+
+func (w *WaAppCoordinator) DeleteClipboardHistoryItemApi(id int64) error {
+	return w.waHistory.Delete(id)
+}
 
-		updates[i] = models.PluginUsageUpdate{
-			PackageID:  packageID,
-			LastUsedAt: lastUsedAt,
-			UsedCount:  int(usedCount),
+// PinClipboardHistoryItemApi keeps an entry regardless of the retention limits and clearing
+func (w *WaAppCoordinator) PinClipboardHistoryItemApi(id int64, pinned bool) error {
+	return w.waHistory.Pin(id, pinned)
+}
+
+// ClearClipboardHistoryApi removes every entry that is not pinned
+func (w *WaAppCoordinator) ClearClipboardHistoryApi() error {
+	return w.waHistory.Clear()
+}
+
+// GetClipboardHistorySettingsApi also returns frontmostAppUnknown, true when the excluded apps cannot be checked
+// and nothing is recorded until they are cleared, such as on Wayland
+func (w *WaAppCoordinator) GetClipboardHistorySettingsApi() map[string]interface{} {
+	return w.clipboardHistorySettingsToMap(w.waHistory.GetSettings())
+}
+
+// UpdateClipboardHistorySettingsApi changes enabled, maxItems, maxAgeDays, maxSizeMB and excludedApps, missing fields are kept
+func (w *WaAppCoordinator) UpdateClipboardHistorySettingsApi(requestMap map[string]interface{}) (map[string]interface{}, error) {
+	settings := w.waHistory.GetSettings()
+	if enabled, ok := requestMap["enabled"].(bool); ok {
+		settings.Enabled = enabled
+	}
+	if maxItems, ok := requestMap["maxItems"].(float64); ok {
+		settings.MaxItems = int(maxItems)
+	}
+	if maxAgeDays, ok := requestMap["maxAgeDays"].(float64); ok {
+		settings.MaxAgeDays = int(maxAgeDays)
+	}
+	if maxSizeMB, ok := requestMap["maxSizeMB"].(float64); ok {
+		settings.MaxSizeMB = int(maxSizeMB)
+	}
+	if excludedApps, ok := requestMap["excludedApps"].([]interface{}); ok {
+		settings.ExcludedApps = make([]string, 0, len(excludedApps))
+		for _, value := range excludedApps {
+			if app, ok := value.(string); ok {
+				settings.ExcludedApps = append(settings.ExcludedApps, app)
+			}
 		}
 	}
+	updated, err := w.waHistory.UpdateSettings(settings)
+	if err != nil {
+		return nil, err
+	}
+	return w.clipboardHistorySettingsToMap(updated), nil
+}
 
-	return w.waPluginApp.UpdatePluginUsage(updates)
+func (w *WaAppCoordinator) readClipboardForHistory() (history.Content, error) {
+	content, err := w.waApp.GetClipboardContent()
+	if err != nil {
+		return history.Content{}, err
+	}
+	return history.Content{
+		Text:        content.Text,
+		ImageBase64: content.ImageBase64,
+		Files:       content.Files,
+	}, nil
+}
+
+func (w *WaAppCoordinator) clipboardHistorySettingsToMap(settings history.Settings) map[string]interface{} {
+	return map[string]interface{}{
+		"enabled":             settings.Enabled,
+		"maxItems":            settings.MaxItems,
+		"maxAgeDays":          settings.MaxAgeDays,
+		"maxSizeMB":           settings.MaxSizeMB,
+		"excludedApps":        settings.ExcludedApps,
+		"frontmostAppUnknown": w.waHistory.FrontmostAppUnknown(),
+	}
+}
+
+// end region clipboard history
+
+// region plugin
+
+func (w *WaAppCoordinator) GetPluginsApi() []map[string]interface{} {
+	return w.waPluginApp.GetPlugins()
+}
+
+func (w *WaAppCoordinator) GetPluginJsEntryUrlApi(packageID string) string {
+	return w.waPluginApp.GetJsEntryUrl(packageID)
 }
 
 // InstallPluginApi installs a plugin from a .wt file path
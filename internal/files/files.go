@@ -0,0 +1,208 @@
+package files
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"watools/pkg/db"
+	"watools/pkg/gitignore"
+	"watools/pkg/logger"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+var (
+	waFilesInstance *WaFiles
+	waFilesOnce     sync.Once
+)
+
+// FileIndexChangedEvent is emitted when a rebuild finished or the watcher updated the index
+const FileIndexChangedEvent = "watools.fileIndexChanged"
+
+const (
+	// initializedKey marks in metadata that the default roots and ignores were stored
+	initializedKey = "file_index.initialized"
+	// startupDelay keeps the first scan away from the launcher startup
+	startupDelay = 10 * time.Second
+)
+
+// defaultRoots are created below the home directory on first start when they exist
+var defaultRoots = []string{"Desktop", "Documents", "Downloads"}
+
+var defaultIgnores = []string{".git", "node_modules", ".cache", "__pycache__", ".DS_Store", "Thumbs.db"}
+
+// WaFiles indexes the files below the configured roots and searches them by name and path
+type WaFiles struct {
+	ctx     context.Context
+	indexer *indexer
+}
+
+func GetWaFiles() *WaFiles {
+	waFilesOnce.Do(func() {
+		waFilesInstance = &WaFiles{}
+	})
+	return waFilesInstance
+}
+
+func (f *WaFiles) OnStartup(ctx context.Context) {
+	f.ctx = ctx
+	f.indexer = newIndexer(ctx, func() {
+		runtime.EventsEmit(f.ctx, FileIndexChangedEvent)
+	})
+	if err := f.initDefaults(); err != nil {
+		logger.Error(err, "Failed to store the default file index roots")
+	}
+	go f.indexer.run()
+	go func() {
+		select {
+		case <-time.After(startupDelay):
+			f.indexer.requestReindex()
+		case <-ctx.Done():
+		}
+	}()
+}
+
+func (f *WaFiles) Shutdown(ctx context.Context) {
+	if f.indexer != nil {
+		f.indexer.close()
+	}
+}
+
+func (f *WaFiles) initDefaults() error {
+	dbInstance := db.GetWaDB()
+	initialized, err := dbInstance.GetMetadata(f.ctx, initializedKey)
+	if err != nil || initialized.IsPresent() {
+		return err
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get home dir: %w", err)
+	}
+	for _, name := range defaultRoots {
+		root := filepath.Join(homeDir, name)
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			continue
+		}
+		if err := dbInstance.AddFileIndexRoot(f.ctx, root); err != nil {
+			return err
+		}
+	}
+	for _, pattern := range defaultIgnores {
+		if err := dbInstance.AddFileIndexIgnore(f.ctx, pattern); err != nil {
+			return err
+		}
+	}
+	return dbInstance.SetMetadata(f.ctx, initializedKey, "true")
+}
+
+// Rebuild rescans every root in the background, progress is reported by GetStats
+func (f *WaFiles) Rebuild() {
+	f.indexer.requestReindex()
+}
+
+// GetStats returns the size of the index and the state of the last run
+func (f *WaFiles) GetStats() (map[string]interface{}, error) {
+	dbInstance := db.GetWaDB()
+	total, dirs, err := dbInstance.CountIndexedFiles(f.ctx)
+	if err != nil {
+		return nil, err
+	}
+	roots, err := dbInstance.GetFileIndexRoots(f.ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats := f.indexer.getStats()
+	watchedDirs, watchLimitReached := f.indexer.watchStatus()
+	result := map[string]interface{}{
+		"roots":             roots,
+		"files":             total - dirs,
+		"dirs":              dirs,
+		"indexing":          stats.indexing,
+		"lastDurationMs":    stats.lastDuration.Milliseconds(),
+		"lastError":         stats.lastError,
+		"watching":          f.indexer.watcher != nil,
+		"watchedDirs":       watchedDirs,
+		"watchLimitReached": watchLimitReached,
+	}
+	if !stats.lastIndexedAt.IsZero() {
+		result["lastIndexedAt"] = stats.lastIndexedAt
+	}
+	return result, nil
+}
+
+func (f *WaFiles) GetRoots() ([]string, error) {
+	return db.GetWaDB().GetFileIndexRoots(f.ctx)
+}
+
+// AddRoot indexes an existing directory, "~/" is expanded to the home directory
+func (f *WaFiles) AddRoot(path string) error {
+	root, err := normalizeRoot(path)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		return fmt.Errorf("'%s' is not a directory", root)
+	}
+	if err := db.GetWaDB().AddFileIndexRoot(f.ctx, root); err != nil {
+		return fmt.Errorf("failed to add root: %w", err)
+	}
+	f.indexer.requestReindex()
+	return nil
+}
+
+// RemoveRoot stops indexing a root and removes its files from the index
+func (f *WaFiles) RemoveRoot(path string) error {
+	root, err := normalizeRoot(path)
+	if err != nil {
+		return err
+	}
+	if err := db.GetWaDB().RemoveFileIndexRoot(f.ctx, root); err != nil {
+		return fmt.Errorf("failed to remove root: %w", err)
+	}
+	f.indexer.requestReindex()
+	return nil
+}
+
+func (f *WaFiles) GetIgnores() ([]string, error) {
+	return db.GetWaDB().GetFileIndexIgnores(f.ctx)
+}
+
+// AddIgnore skips paths matching a .gitignore pattern below every root, the index is rebuilt
+func (f *WaFiles) AddIgnore(pattern string) error {
+	pattern = strings.TrimSpace(pattern)
+	if !gitignore.ValidatePattern(pattern) {
+		return fmt.Errorf("'%s' is not an ignore pattern", pattern)
+	}
+	if err := db.GetWaDB().AddFileIndexIgnore(f.ctx, pattern); err != nil {
+		return fmt.Errorf("failed to add ignore pattern: %w", err)
+	}
+	f.indexer.requestReindex()
+	return nil
+}
+
+func (f *WaFiles) RemoveIgnore(pattern string) error {
+	if err := db.GetWaDB().RemoveFileIndexIgnore(f.ctx, strings.TrimSpace(pattern)); err != nil {
+		return fmt.Errorf("failed to remove ignore pattern: %w", err)
+	}
+	f.indexer.requestReindex()
+	return nil
+}
+
+func normalizeRoot(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home dir: %w", err)
+		}
+		path = filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+	}
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("root '%s' must be an absolute path", path)
+	}
+	return filepath.Clean(path), nil
+}
@@ -0,0 +1,313 @@
+package files
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"watools/pkg/db"
+	"watools/pkg/gitignore"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+)
+
+const (
+	// batchSize is how many entries are written per transaction while scanning
+	batchSize = 500
+	// watchDelay collects the events of a burst, such as a checkout, into one update
+	watchDelay = 500 * time.Millisecond
+)
+
+// indexStats describes the last indexing run
+type indexStats struct {
+	indexing      bool
+	lastIndexedAt time.Time
+	lastDuration  time.Duration
+	lastError     string
+}
+
+// indexer keeps the indexed_file table in sync with the roots.
+// Full runs and watcher updates are applied by one goroutine, so they never interleave.
+type indexer struct {
+	ctx      context.Context
+	onChange func()
+	watcher  *treeWatcher
+	reindex  chan struct{}
+	changes  chan []string
+
+	// roots, ignores and matchers are only used by the run goroutine
+	roots    []string
+	ignores  []string
+	matchers map[string]gitignore.Matcher
+
+	mu    sync.Mutex
+	stats indexStats
+}
+
+func newIndexer(ctx context.Context, onChange func()) *indexer {
+	x := &indexer{
+		ctx:      ctx,
+		onChange: onChange,
+		reindex:  make(chan struct{}, 1),
+		changes:  make(chan []string, 16),
+		matchers: make(map[string]gitignore.Matcher),
+	}
+	watcher, err := newTreeWatcher(watchDelay, func(paths []string) {
+		select {
+		case x.changes <- paths:
+		case <-ctx.Done():
+		}
+	})
+	if err != nil {
+		logger.Error(err, "Failed to create file index watcher, the index is only updated by rebuilds")
+	}
+	x.watcher = watcher
+	return x
+}
+
+// requestReindex schedules a full run, requests made while a run is pending are merged
+func (x *indexer) requestReindex() {
+	select {
+	case x.reindex <- struct{}{}:
+	default:
+	}
+}
+
+func (x *indexer) run() {
+	for {
+		select {
+		case <-x.ctx.Done():
+			return
+		case <-x.reindex:
+			x.indexAll()
+		case paths := <-x.changes:
+			x.applyChanges(paths)
+		}
+	}
+}
+
+func (x *indexer) close() {
+	if x.watcher == nil {
+		return
+	}
+	if err := x.watcher.close(); err != nil {
+		logger.Error(err, "Failed to close file index watcher")
+	}
+}
+
+func (x *indexer) getStats() indexStats {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.stats
+}
+
+func (x *indexer) watchStatus() (int, bool) {
+	if x.watcher == nil {
+		return 0, false
+	}
+	return x.watcher.status()
+}
+
+// indexAll reloads the roots and ignores and rescans every root
+func (x *indexer) indexAll() {
+	start := time.Now()
+	x.mu.Lock()
+	x.stats.indexing = true
+	x.mu.Unlock()
+
+	err := x.scanRoots()
+
+	x.mu.Lock()
+	x.stats.indexing = false
+	x.stats.lastIndexedAt = time.Now()
+	x.stats.lastDuration = time.Since(start)
+	x.stats.lastError = ""
+	if err != nil {
+		x.stats.lastError = err.Error()
+	}
+	x.mu.Unlock()
+	if err != nil {
+		logger.Error(err, "Failed to index files")
+	} else {
+		logger.Info(fmt.Sprintf("Indexed files of %d roots in %s", len(x.roots), time.Since(start)))
+	}
+	x.onChange()
+}
+
+func (x *indexer) scanRoots() error {
+	dbInstance := db.GetWaDB()
+	roots, err := dbInstance.GetFileIndexRoots(x.ctx)
+	if err != nil {
+		return err
+	}
+	ignores, err := dbInstance.GetFileIndexIgnores(x.ctx)
+	if err != nil {
+		return err
+	}
+	for _, previous := range x.roots {
+		if !containsPath(roots, previous) && x.watcher != nil {
+			x.watcher.removeUnder(previous)
+		}
+	}
+	x.roots, x.ignores = roots, ignores
+	x.matchers = make(map[string]gitignore.Matcher)
+
+	var errs []error
+	generation := time.Now().UnixNano()
+	for _, root := range roots {
+		if err := x.scanTree(root, root, x.rootMatcher(root), generation); err != nil {
+			errs = append(errs, fmt.Errorf("failed to index %s: %w", root, err))
+			if x.ctx.Err() != nil {
+				break
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// rootMatcher applies the user ignore patterns relative to root
+func (x *indexer) rootMatcher(root string) gitignore.Matcher {
+	return gitignore.Matcher{}.With(gitignore.ParseLines(root, x.ignores))
+}
+
+// scanTree indexes dir below root, then deletes what was indexed below dir before and is gone now
+func (x *indexer) scanTree(root string, dir string, base gitignore.Matcher, generation int64) error {
+	dbInstance := db.GetWaDB()
+	batch := make([]models.IndexedFile, 0, batchSize)
+	flush := func() error {
+		err := dbInstance.SaveIndexedFiles(x.ctx, batch, generation)
+		batch = batch[:0]
+		return err
+	}
+	err := scan(x.ctx, root, dir, base,
+		func(path string) bool {
+			return path != root && containsPath(x.roots, path)
+		},
+		func(path string, matcher gitignore.Matcher) {
+			x.matchers[path] = matcher
+			if x.watcher != nil {
+				x.watcher.add(path)
+			}
+		},
+		func(file models.IndexedFile) error {
+			batch = append(batch, file)
+			if len(batch) >= batchSize {
+				return flush()
+			}
+			return nil
+		})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		return err
+	}
+	return dbInstance.DeleteStaleIndexedFiles(x.ctx, dir, generation)
+}
+
+// applyChanges updates the index for paths reported by the watcher
+func (x *indexer) applyChanges(paths []string) {
+	sort.Strings(paths)
+	dbInstance := db.GetWaDB()
+	generation := time.Now().UnixNano()
+	changed := false
+	for _, path := range paths {
+		root := x.rootOf(path)
+		if root == "" {
+			continue
+		}
+		if filepath.Base(path) == gitignore.FileName {
+			// the rules of the whole directory may have changed
+			path = filepath.Dir(path)
+		}
+		info, err := os.Lstat(path)
+		if err != nil {
+			if err := dbInstance.DeleteIndexedFilesUnder(x.ctx, path); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to remove %s from the file index", path))
+			}
+			x.forget(path)
+			changed = true
+			continue
+		}
+		base, ok := x.matcherFor(root, path)
+		if !ok {
+			// the parent directory is ignored or not indexed yet
+			continue
+		}
+		if path != root && base.Ignored(path, info.IsDir()) {
+			if err := dbInstance.DeleteIndexedFilesUnder(x.ctx, path); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to remove %s from the file index", path))
+			}
+			x.forget(path)
+			changed = true
+			continue
+		}
+		if info.IsDir() {
+			err = x.scanTree(root, path, base, generation)
+		} else {
+			err = dbInstance.SaveIndexedFiles(x.ctx, []models.IndexedFile{newIndexedFile(root, path, info)}, generation)
+		}
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to update %s in the file index", path))
+			continue
+		}
+		changed = true
+	}
+	if changed {
+		x.onChange()
+	}
+}
+
+// matcherFor returns the matcher that applies to path, which is the matcher of its parent directory
+func (x *indexer) matcherFor(root string, path string) (gitignore.Matcher, bool) {
+	if path == root {
+		return x.rootMatcher(root), true
+	}
+	matcher, ok := x.matchers[filepath.Dir(path)]
+	return matcher, ok
+}
+
+// forget drops the watches and matchers of a removed or ignored path
+func (x *indexer) forget(path string) {
+	if x.watcher != nil {
+		x.watcher.removeUnder(path)
+	}
+	prefix := path + string(filepath.Separator)
+	for dir := range x.matchers {
+		if dir == path || strings.HasPrefix(dir, prefix) {
+			delete(x.matchers, dir)
+		}
+	}
+}
+
+// rootOf returns the innermost root containing path, or "" when path is not below a root
+func (x *indexer) rootOf(path string) string {
+	best := ""
+	for _, root := range x.roots {
+		if isWithin(root, path) && len(root) > len(best) {
+			best = root
+		}
+	}
+	return best
+}
+
+func isWithin(dir string, path string) bool {
+	if dir == path {
+		return true
+	}
+	return strings.HasPrefix(path, strings.TrimSuffix(dir, string(filepath.Separator))+string(filepath.Separator))
+}
+
+func containsPath(paths []string, path string) bool {
+	for _, candidate := range paths {
+		if candidate == path {
+			return true
+		}
+	}
+	return false
+}
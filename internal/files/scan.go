@@ -0,0 +1,76 @@
+package files
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"watools/pkg/gitignore"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+)
+
+// scan walks dir below root and emits every entry that is not ignored, dir itself is emitted unless it is the root.
+// base is the matcher that applies to dir, the .gitignore files found while walking are added to it for their subtree.
+// onDir is called with the matcher of each directory that is entered, skip tells which directories belong to another root.
+func scan(ctx context.Context, root string, dir string, base gitignore.Matcher, skip func(dir string) bool,
+	onDir func(dir string, matcher gitignore.Matcher), emit func(file models.IndexedFile) error) error {
+	matchers := map[string]gitignore.Matcher{}
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir {
+				return err
+			}
+			// unreadable entries are left out, the rest of the tree is still indexed
+			logger.Debug("Skip unreadable path " + path)
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		isDir := entry.IsDir()
+		matcher := base
+		if path != dir {
+			matcher = matchers[filepath.Dir(path)]
+			if matcher.Ignored(path, isDir) || (isDir && skip != nil && skip(path)) {
+				if isDir {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+		if isDir {
+			rules, err := gitignore.ParseFile(filepath.Join(path, gitignore.FileName))
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				logger.Debug("Skip unreadable " + filepath.Join(path, gitignore.FileName))
+			}
+			matchers[path] = matcher.With(rules)
+			if onDir != nil {
+				onDir(path, matchers[path])
+			}
+		}
+		if path == root {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		return emit(newIndexedFile(root, path, info))
+	})
+}
+
+func newIndexedFile(root string, path string, info fs.FileInfo) models.IndexedFile {
+	file := models.IndexedFile{
+		Path:       path,
+		Name:       filepath.Base(path),
+		Root:       root,
+		IsDir:      info.IsDir(),
+		ModifiedAt: info.ModTime(),
+	}
+	if !file.IsDir {
+		file.Size = info.Size()
+	}
+	return file
+}
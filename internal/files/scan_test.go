@@ -0,0 +1,86 @@
+package files
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"watools/pkg/gitignore"
+	"watools/pkg/models"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanHonorsIgnores(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "notes.md"), "")
+	writeFile(t, filepath.Join(root, ".git", "HEAD"), "")
+	writeFile(t, filepath.Join(root, "web", "node_modules", "react", "index.js"), "")
+	writeFile(t, filepath.Join(root, "web", ".gitignore"), "dist/\n*.log\n!keep.log\n")
+	writeFile(t, filepath.Join(root, "web", "dist", "app.js"), "")
+	writeFile(t, filepath.Join(root, "web", "debug.log"), "")
+	writeFile(t, filepath.Join(root, "web", "keep.log"), "")
+	writeFile(t, filepath.Join(root, "web", "src", "main.ts"), "")
+	writeFile(t, filepath.Join(root, "nested", "inner.txt"), "")
+
+	base := gitignore.Matcher{}.With(gitignore.ParseLines(root, []string{".git", "node_modules"}))
+	var dirs []string
+	var paths []string
+	err := scan(context.Background(), root, root, base,
+		func(dir string) bool { return dir == filepath.Join(root, "nested") },
+		func(dir string, _ gitignore.Matcher) { dirs = append(dirs, dir) },
+		func(file models.IndexedFile) error {
+			relative, _ := filepath.Rel(root, file.Path)
+			paths = append(paths, filepath.ToSlash(relative))
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(paths)
+	expected := []string{"notes.md", "web", "web/.gitignore", "web/keep.log", "web/src", "web/src/main.ts"}
+	if len(paths) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, paths)
+		}
+	}
+	if len(dirs) != 3 {
+		t.Fatalf("expected the root, web and web/src to be entered, got %v", dirs)
+	}
+}
+
+func TestRankFilePrefersName(t *testing.T) {
+	t.Parallel()
+
+	inName, ok := rankFile(models.IndexedFile{Path: "/home/u/Documents/report-2024.pdf", Name: "report-2024.pdf"}, []string{"report"})
+	if !ok || inName.field != "name" {
+		t.Fatalf("expected a name match, got %+v", inName)
+	}
+	inPath, ok := rankFile(models.IndexedFile{Path: "/home/u/report/summary.pdf", Name: "summary.pdf"}, []string{"report"})
+	if !ok || inPath.field != "path" {
+		t.Fatalf("expected a path match, got %+v", inPath)
+	}
+	if inName.score <= inPath.score {
+		t.Fatalf("expected the name match to rank first: %v <= %v", inName.score, inPath.score)
+	}
+	if _, ok := rankFile(models.IndexedFile{Path: "/home/u/a.txt", Name: "a.txt"}, []string{"zz"}); ok {
+		t.Fatalf("expected a file without the term to be dropped")
+	}
+	if escapeLike(`50%_off\`) != `50\%\_off\\` {
+		t.Fatalf("unexpected like escaping: %s", escapeLike(`50%_off\`))
+	}
+}
@@ -0,0 +1,123 @@
+package files
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+	"watools/pkg/db"
+	"watools/pkg/models"
+	"watools/pkg/search"
+)
+
+const (
+	defaultSearchLimit = 50
+	// candidateFactor is how many more candidates than results are ranked
+	candidateFactor = 8
+	maxCandidates   = 500
+	// trigramLength is the shortest term the trigram index can find
+	trigramLength = 3
+	// weightPath ranks matches in the directories below matches in the name
+	weightPath = 0.5
+)
+
+type fileResult struct {
+	file       models.IndexedFile
+	score      float64
+	field      string
+	highlights []search.Range
+}
+
+// Search returns at most limit indexed files whose name or path matches query, best first.
+// Each result holds the file fields, the matched "field" ("name" or "path"), "highlights" as rune offsets
+// into it, the "triggerId" of the file command and the "actions" that can be run on the file.
+func (f *WaFiles) Search(query string, limit int) ([]interface{}, error) {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return []interface{}{}, nil
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	candidates, err := searchCandidates(f, terms, min(limit*candidateFactor, maxCandidates))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]fileResult, 0, len(candidates))
+	for _, file := range candidates {
+		if result, ok := rankFile(file, terms); ok {
+			results = append(results, result)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		return len(results[i].file.Path) < len(results[j].file.Path)
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	items := make([]interface{}, 0, len(results))
+	for _, result := range results {
+		items = append(items, map[string]interface{}{
+			"path":       result.file.Path,
+			"name":       result.file.Name,
+			"root":       result.file.Root,
+			"isDir":      result.file.IsDir,
+			"size":       result.file.Size,
+			"modifiedAt": result.file.ModifiedAt,
+			"score":      result.score,
+			"field":      result.field,
+			"highlights": result.highlights,
+			"triggerId":  models.FileTriggerID(result.file.Path),
+			"actions":    models.FileActions,
+		})
+	}
+	return items, nil
+}
+
+// searchCandidates finds files containing the terms with the trigram index.
+// Terms shorter than a trigram cannot use it, when every term is that short the names are scanned instead.
+func searchCandidates(f *WaFiles, terms []string, limit int) ([]models.IndexedFile, error) {
+	var phrases []string
+	for _, term := range terms {
+		if utf8.RuneCountInString(term) >= trigramLength {
+			phrases = append(phrases, `"`+strings.ReplaceAll(term, `"`, `""`)+`"`)
+		}
+	}
+	dbInstance := db.GetWaDB()
+	if len(phrases) > 0 {
+		return dbInstance.SearchIndexedFiles(f.ctx, strings.Join(phrases, " "), limit)
+	}
+	escaped := make([]string, 0, len(terms))
+	for _, term := range terms {
+		escaped = append(escaped, escapeLike(term))
+	}
+	return dbInstance.SearchIndexedFilesByName(f.ctx, "%"+strings.Join(escaped, "%")+"%", limit)
+}
+
+// rankFile scores a candidate with the launcher matcher, the name is preferred over the path.
+// Every term must appear in the name or the path, the index does not check the short ones.
+func rankFile(file models.IndexedFile, terms []string) (fileResult, bool) {
+	lowerPath := strings.ToLower(file.Path)
+	for _, term := range terms {
+		if !strings.Contains(lowerPath, strings.ToLower(term)) {
+			return fileResult{}, false
+		}
+	}
+	query := strings.Join(terms, " ")
+	if score, highlights, ok := search.Match(search.NewField(file.Name), query); ok {
+		return fileResult{file: file, score: score, field: "name", highlights: highlights}, true
+	}
+	if score, highlights, ok := search.Match(search.NewField(file.Path), query); ok {
+		return fileResult{file: file, score: score * weightPath, field: "path", highlights: highlights}, true
+	}
+	// the terms appear out of order, keep the file below every ordered match
+	return fileResult{file: file, field: "path"}, true
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
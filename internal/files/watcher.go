@@ -0,0 +1,147 @@
+package files
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"watools/pkg/logger"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// maxWatchedDirs keeps large roots from exhausting the inotify watches or, on macOS, the file descriptors
+const maxWatchedDirs = 20000
+
+// treeWatcher watches the directories of the indexed trees.
+// Events are debounced, onChange receives every path that changed during the delay.
+type treeWatcher struct {
+	watcher  *fsnotify.Watcher
+	delay    time.Duration
+	onChange func(paths []string)
+
+	mu           sync.Mutex
+	watched      map[string]struct{}
+	changed      map[string]struct{}
+	timer        *time.Timer
+	limitReached bool
+	done         chan struct{}
+}
+
+func newTreeWatcher(delay time.Duration, onChange func(paths []string)) (*treeWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fsnotify watcher: %w", err)
+	}
+	tw := &treeWatcher{
+		watcher:  watcher,
+		delay:    delay,
+		onChange: onChange,
+		watched:  make(map[string]struct{}),
+		changed:  make(map[string]struct{}),
+		done:     make(chan struct{}),
+	}
+	go tw.loop()
+	return tw, nil
+}
+
+func (tw *treeWatcher) loop() {
+	for {
+		select {
+		case event, ok := <-tw.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			tw.record(event.Name)
+		case err, ok := <-tw.watcher.Errors:
+			if !ok {
+				return
+			}
+			logger.Error(err, "File index watcher error")
+		case <-tw.done:
+			return
+		}
+	}
+}
+
+func (tw *treeWatcher) record(path string) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.changed[filepath.Clean(path)] = struct{}{}
+	if tw.timer != nil {
+		tw.timer.Stop()
+	}
+	tw.timer = time.AfterFunc(tw.delay, tw.flush)
+}
+
+func (tw *treeWatcher) flush() {
+	tw.mu.Lock()
+	paths := make([]string, 0, len(tw.changed))
+	for path := range tw.changed {
+		paths = append(paths, path)
+	}
+	tw.changed = make(map[string]struct{})
+	tw.mu.Unlock()
+	if len(paths) > 0 {
+		tw.onChange(paths)
+	}
+}
+
+// add watches dir, directories past maxWatchedDirs are indexed but not watched
+func (tw *treeWatcher) add(dir string) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if _, exists := tw.watched[dir]; exists {
+		return
+	}
+	if len(tw.watched) >= maxWatchedDirs {
+		if !tw.limitReached {
+			logger.Info(fmt.Sprintf("File index watches %d directories, changes below further directories are picked up by a rebuild", maxWatchedDirs))
+		}
+		tw.limitReached = true
+		return
+	}
+	if err := tw.watcher.Add(dir); err != nil {
+		logger.Error(err, fmt.Sprintf("Failed to watch %s", dir))
+		return
+	}
+	tw.watched[dir] = struct{}{}
+}
+
+// removeUnder stops watching dir and the directories below it
+func (tw *treeWatcher) removeUnder(dir string) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	prefix := dir + string(filepath.Separator)
+	for watched := range tw.watched {
+		if watched == dir || strings.HasPrefix(watched, prefix) {
+			// the watch is already gone when the directory was deleted
+			_ = tw.watcher.Remove(watched)
+			delete(tw.watched, watched)
+		}
+	}
+	if len(tw.watched) < maxWatchedDirs {
+		tw.limitReached = false
+	}
+}
+
+// status returns the number of watched directories and whether some directories are not watched
+func (tw *treeWatcher) status() (int, bool) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	return len(tw.watched), tw.limitReached
+}
+
+func (tw *treeWatcher) close() error {
+	tw.mu.Lock()
+	if tw.timer != nil {
+		tw.timer.Stop()
+	}
+	tw.mu.Unlock()
+	close(tw.done)
+	return tw.watcher.Close()
+}
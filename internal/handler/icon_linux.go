@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"watools/pkg/xdg"
+
+	"github.com/srwiley/oksvg"
+	"github.com/srwiley/rasterx"
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/webp"
+)
+
+// iconSize is the preferred edge length of application icons in pixels
+const iconSize = 128
+
+func icon2Png(iconPath string, pngPath string) error {
+	iconPath = strings.TrimSpace(iconPath)
+	if iconPath == "" {
+		return fmt.Errorf("icon path is empty")
+	}
+
+	iconFile, err := resolveIconFile(iconPath)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(iconFile)) {
+	case ".png":
+		return copyFile(iconFile, pngPath)
+	case ".svg":
+		return convertSvgToPng(iconFile, pngPath)
+	case ".xpm":
+		return convertXpmToPng(iconFile, pngPath)
+	default:
+		return convertImageToPng(iconFile, pngPath)
+	}
+}
+
+// resolveIconFile maps the Icon value of a desktop entry to a file, either an absolute path or a theme icon name
+func resolveIconFile(icon string) (string, error) {
+	if filepath.IsAbs(icon) {
+		if _, err := os.Stat(icon); err != nil {
+			return "", fmt.Errorf("failed to find icon file: %w", err)
+		}
+		return icon, nil
+	}
+	return xdg.DefaultIconResolver().LookupIcon(icon, iconSize)
+}
+
+func convertImageToPng(sourcePath, destPath string) error {
+	file, err := os.Open(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return err
+	}
+	return writePng(destPath, img)
+}
+
+func convertSvgToPng(sourcePath, destPath string) error {
+	// librsvg renders filters and text that the pure Go rasterizer does not support
+	if _, err := exec.LookPath("rsvg-convert"); err == nil {
+		size := fmt.Sprint(iconSize)
+		cmd := exec.Command("rsvg-convert", "-w", size, "-h", size, "-a", "-f", "png", "-o", destPath, sourcePath)
+		if _, err := cmd.CombinedOutput(); err == nil {
+			return nil
+		}
+	}
+
+	data, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return err
+	}
+	img, err := rasterizeSvg(data, iconSize)
+	if err != nil {
+		return fmt.Errorf("failed to convert svg to png: %w", err)
+	}
+	return writePng(destPath, img)
+}
+
+func rasterizeSvg(data []byte, size int) (image.Image, error) {
+	svgIcon, err := oksvg.ReadIconStream(bytes.NewReader(data), oksvg.WarnErrorMode)
+	if err != nil {
+		return nil, err
+	}
+	svgIcon.SetTarget(0, 0, float64(size), float64(size))
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	scanner := rasterx.NewScannerGV(size, size, img, img.Bounds())
+	svgIcon.Draw(rasterx.NewDasher(size, size, scanner), 1)
+	return img, nil
+}
+
+func convertXpmToPng(sourcePath, destPath string) error {
+	data, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return err
+	}
+	img, err := decodeXpm(data)
+	if err != nil {
+		return fmt.Errorf("failed to convert xpm to png: %w", err)
+	}
+	return writePng(destPath, img)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+func writePng(destPath string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return err
+	}
+
+	out, err := os.Create(destPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	return png.Encode(out, img)
+}
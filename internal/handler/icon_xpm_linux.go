@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+var xpmNamedColors = map[string]color.NRGBA{
+	"black":   {0, 0, 0, 255},
+	"white":   {255, 255, 255, 255},
+	"red":     {255, 0, 0, 255},
+	"green":   {0, 255, 0, 255},
+	"blue":    {0, 0, 255, 255},
+	"yellow":  {255, 255, 0, 255},
+	"cyan":    {0, 255, 255, 255},
+	"magenta": {255, 0, 255, 255},
+	"gray":    {190, 190, 190, 255},
+	"grey":    {190, 190, 190, 255},
+}
+
+// decodeXpm decodes an XPM3 image, the format still used by many legacy /usr/share/pixmaps icons
+func decodeXpm(data []byte) (image.Image, error) {
+	lines := xpmStrings(string(data))
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("no xpm data")
+	}
+
+	var width, height, colorCount, charsPerPixel int
+	if _, err := fmt.Sscan(lines[0], &width, &height, &colorCount, &charsPerPixel); err != nil {
+		return nil, fmt.Errorf("invalid xpm header '%s': %w", lines[0], err)
+	}
+	if width <= 0 || height <= 0 || colorCount <= 0 || charsPerPixel <= 0 {
+		return nil, fmt.Errorf("invalid xpm header '%s'", lines[0])
+	}
+	if len(lines) < 1+colorCount+height {
+		return nil, fmt.Errorf("xpm data is truncated")
+	}
+
+	palette := make(map[string]color.NRGBA, colorCount)
+	for _, line := range lines[1 : 1+colorCount] {
+		if len(line) < charsPerPixel {
+			return nil, fmt.Errorf("invalid xpm color line '%s'", line)
+		}
+		key := line[:charsPerPixel]
+		palette[key] = parseXpmColor(strings.Fields(line[charsPerPixel:]))
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y, line := range lines[1+colorCount : 1+colorCount+height] {
+		for x := 0; x < width; x++ {
+			start := x * charsPerPixel
+			if start+charsPerPixel > len(line) {
+				break
+			}
+			img.SetNRGBA(x, y, palette[line[start:start+charsPerPixel]])
+		}
+	}
+	return img, nil
+}
+
+// xpmStrings extracts the quoted C string literals of an XPM file
+func xpmStrings(data string) []string {
+	var values []string
+	for {
+		start := strings.IndexByte(data, '"')
+		if start < 0 {
+			return values
+		}
+		end := strings.IndexByte(data[start+1:], '"')
+		if end < 0 {
+			return values
+		}
+		values = append(values, data[start+1:start+1+end])
+		data = data[start+end+2:]
+	}
+}
+
+// parseXpmColor picks the color visual ("c" key) of an XPM color definition, falling back to the first key
+func parseXpmColor(fields []string) color.NRGBA {
+	value := ""
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fields[i] == "c" {
+			value = fields[i+1]
+			break
+		}
+		if value == "" {
+			value = fields[i+1]
+		}
+	}
+	value = strings.ToLower(value)
+
+	if value == "none" {
+		return color.NRGBA{}
+	}
+	if named, exists := xpmNamedColors[value]; exists {
+		return named
+	}
+	if !strings.HasPrefix(value, "#") {
+		return color.NRGBA{A: 255}
+	}
+	hex := value[1:]
+	digits := len(hex) / 3
+	if digits == 0 || len(hex)%3 != 0 {
+		return color.NRGBA{A: 255}
+	}
+	channel := func(i int) uint8 {
+		parsed, err := strconv.ParseUint(hex[i*digits:i*digits+digits], 16, 64)
+		if err != nil {
+			return 0
+		}
+		// scale 1 to 4 hex digits per channel into 8 bits
+		maxValue := uint64(1)<<(4*digits) - 1
+		return uint8(parsed * 255 / maxValue)
+	}
+	return color.NRGBA{R: channel(0), G: channel(1), B: channel(2), A: 255}
+}
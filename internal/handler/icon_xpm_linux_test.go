@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestDecodeXpm(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`/* XPM */
+static char * test_xpm[] = {
+"3 2 3 1",
+" 	c None",
+".	c #FF0000",
+"+	c white",
+" .+",
+"+. "};
+`)
+	img, err := decodeXpm(data)
+	if err != nil {
+		t.Fatalf("decodeXpm returned error: %v", err)
+	}
+	if bounds := img.Bounds(); bounds.Dx() != 3 || bounds.Dy() != 2 {
+		t.Fatalf("unexpected bounds %v", bounds)
+	}
+
+	cases := []struct {
+		x, y int
+		want color.NRGBA
+	}{
+		{0, 0, color.NRGBA{}},
+		{1, 0, color.NRGBA{R: 255, A: 255}},
+		{2, 0, color.NRGBA{R: 255, G: 255, B: 255, A: 255}},
+		{0, 1, color.NRGBA{R: 255, G: 255, B: 255, A: 255}},
+	}
+	for _, tc := range cases {
+		got := color.NRGBAModel.Convert(img.At(tc.x, tc.y)).(color.NRGBA)
+		if got != tc.want {
+			t.Fatalf("pixel (%d,%d) = %v, want %v", tc.x, tc.y, got, tc.want)
+		}
+	}
+
+	if _, err := decodeXpm([]byte(`"2 2 1 1", " c None", "  "`)); err == nil {
+		t.Fatalf("expected error for truncated xpm")
+	}
+}
@@ -0,0 +1,380 @@
+package history
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"watools/config"
+	"watools/pkg/db"
+	"watools/pkg/logger"
+	"watools/pkg/models"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+var (
+	waHistoryInstance *WaHistory
+	waHistoryOnce     sync.Once
+)
+
+// ClipboardHistoryChangedEvent is emitted when an entry was recorded, changed or removed
+const ClipboardHistoryChangedEvent = "watools.clipboardHistoryChanged"
+
+// Content is what the clipboard holds, at most one field is recorded: files, then the image, then the text
+type Content struct {
+	Text string
+	// ImageBase64 is a base64 encoded PNG
+	ImageBase64 string
+	Files       []string
+}
+
+// Clipboard is the platform clipboard the history watches and copies entries back to
+type Clipboard struct {
+	// Watch calls onChange whenever the clipboard changes, it blocks until ctx is done
+	Watch func(ctx context.Context, onChange func()) error
+	Read  func() (Content, error)
+	// IsConcealed reports whether the owner asked clipboard managers not to record the content
+	IsConcealed func() bool
+	// FrontmostApp returns the names the frontmost app is known by, the last one is shown as the source
+	FrontmostApp func() []string
+	WriteText    func(text string) error
+	// WriteImage copies the PNG file at path
+	WriteImage func(path string) error
+	WriteFiles func(paths []string) error
+}
+
+// WaHistory records the clipboard into the clipboard_history table and applies the retention limits
+type WaHistory struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	clipboard Clipboard
+
+	mu       sync.Mutex
+	settings Settings
+	// lastHash is the content recorded last, the watcher may report the same content more than once
+	lastHash string
+	// frontmostAppUnknown is set when the last change came without a frontmost app, the excluded apps cannot be told apart then
+	frontmostAppUnknown bool
+}
+
+func GetWaHistory() *WaHistory {
+	waHistoryOnce.Do(func() {
+		waHistoryInstance = &WaHistory{settings: DefaultSettings()}
+	})
+	return waHistoryInstance
+}
+
+// SetClipboard sets the clipboard to watch, the history is not recorded without one
+func (h *WaHistory) SetClipboard(clipboard Clipboard) {
+	h.clipboard = clipboard
+}
+
+func (h *WaHistory) OnStartup(ctx context.Context) {
+	h.ctx, h.cancel = context.WithCancel(ctx)
+	settings, err := loadSettings(h.ctx)
+	if err != nil {
+		logger.Error(err, "Failed to load clipboard history settings")
+	}
+	h.mu.Lock()
+	h.settings = settings
+	h.mu.Unlock()
+	if err := h.prune(); err != nil {
+		logger.Error(err, "Failed to prune clipboard history")
+	}
+	if h.clipboard.Watch == nil || h.clipboard.Read == nil {
+		logger.Warning("No clipboard to watch, clipboard history is not recorded")
+		return
+	}
+	go func() {
+		if err := h.clipboard.Watch(h.ctx, h.onClipboardChange); err != nil {
+			logger.Error(err, "Failed to watch the clipboard")
+		}
+	}()
+}
+
+func (h *WaHistory) Shutdown(ctx context.Context) {
+	if h.cancel != nil {
+		h.cancel()
+	}
+}
+
+func (h *WaHistory) onClipboardChange() {
+	recorded, err := h.record()
+	if err != nil {
+		logger.Error(err, "Failed to record clipboard history")
+		return
+	}
+	if recorded {
+		h.emitChanged()
+	}
+}
+
+// record stores the current clipboard content unless recording is off, the content is concealed or the frontmost app is excluded.
+// Nothing is recorded while there are excluded apps and the frontmost app is unknown.
+func (h *WaHistory) record() (bool, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.settings.Enabled {
+		return false, nil
+	}
+	if h.clipboard.IsConcealed != nil && h.clipboard.IsConcealed() {
+		return false, nil
+	}
+	var apps []string
+	if h.clipboard.FrontmostApp != nil {
+		apps = h.clipboard.FrontmostApp()
+	}
+	h.frontmostAppUnknown = len(apps) == 0
+	if h.settings.isExcluded(apps) {
+		if h.frontmostAppUnknown {
+			logger.Debug("Skip clipboard of an unknown frontmost app, excluded apps cannot be checked")
+		} else {
+			logger.Debug(fmt.Sprintf("Skip clipboard of excluded app %v", apps))
+		}
+		return false, nil
+	}
+
+	content, err := h.clipboard.Read()
+	if err != nil {
+		return false, fmt.Errorf("failed to read clipboard: %w", err)
+	}
+	item, hash, data, ok := itemOf(content)
+	if !ok || hash == h.lastHash {
+		return false, nil
+	}
+	if item.Kind == models.ClipboardHistoryImage {
+		if err := saveImage(item.ImagePath, data); err != nil {
+			return false, err
+		}
+	}
+	if len(apps) > 0 {
+		item.SourceApp = apps[len(apps)-1]
+	}
+	item.CopiedAt = time.Now()
+	if _, err := db.GetWaDB().SaveClipboardHistory(h.ctx, item, hash); err != nil {
+		return false, err
+	}
+	h.lastHash = hash
+	if err := h.pruneLocked(); err != nil {
+		logger.Error(err, "Failed to prune clipboard history")
+	}
+	return true, nil
+}
+
+// itemOf returns the entry to record for content with its hash and the bytes an image entry writes, ok is false when there is nothing to record
+func itemOf(content Content) (item *models.ClipboardHistoryItem, hash string, data []byte, ok bool) {
+	switch {
+	case len(content.Files) > 0:
+		item = &models.ClipboardHistoryItem{Kind: models.ClipboardHistoryFiles, Files: content.Files}
+		data = []byte(strings.Join(content.Files, "\n"))
+	case content.ImageBase64 != "":
+		image, err := base64.StdEncoding.DecodeString(content.ImageBase64)
+		if err != nil || len(image) == 0 {
+			return nil, "", nil, false
+		}
+		data = image
+		hash = contentHash(models.ClipboardHistoryImage, data)
+		item = &models.ClipboardHistoryItem{
+			Kind:      models.ClipboardHistoryImage,
+			ImagePath: filepath.Join(config.ClipboardImageDir(), hash+".png"),
+		}
+	case strings.TrimSpace(content.Text) != "":
+		item = &models.ClipboardHistoryItem{Kind: models.ClipboardHistoryText, Text: content.Text}
+		data = []byte(content.Text)
+	default:
+		return nil, "", nil, false
+	}
+	if hash == "" {
+		hash = contentHash(item.Kind, data)
+	}
+	item.Size = int64(len(data))
+	return item, hash, data, true
+}
+
+// contentHash identifies content by kind and bytes, the same content is recorded once
+func contentHash(kind models.ClipboardHistoryKind, data []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(kind))
+	hash.Write([]byte{0})
+	hash.Write(data)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// hashOf returns the hash a stored entry was recorded with
+func hashOf(item *models.ClipboardHistoryItem) string {
+	switch item.Kind {
+	case models.ClipboardHistoryImage:
+		return strings.TrimSuffix(filepath.Base(item.ImagePath), ".png")
+	case models.ClipboardHistoryFiles:
+		return contentHash(item.Kind, []byte(strings.Join(item.Files, "\n")))
+	default:
+		return contentHash(item.Kind, []byte(item.Text))
+	}
+}
+
+func saveImage(path string, data []byte) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create clipboard image dir: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to save clipboard image: %w", err)
+	}
+	return nil
+}
+
+func (h *WaHistory) emitChanged() {
+	runtime.EventsEmit(h.ctx, ClipboardHistoryChangedEvent)
+}
+
+// Search returns entries whose text or file paths contain every term of query in any order, pinned entries first.
+// kind is "text", "image", "files" or empty for every kind, images only match an empty query.
+func (h *WaHistory) Search(query string, kind string, limit int, offset int) ([]interface{}, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	terms := strings.Fields(query)
+	patterns := make([]string, len(terms))
+	for i, term := range terms {
+		patterns[i] = "%" + escapeLike(term) + "%"
+	}
+	items, err := db.GetWaDB().SearchClipboardHistory(h.ctx, kind, patterns, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]interface{}, len(items))
+	for i, item := range items {
+		result[i] = item
+	}
+	return result, nil
+}
+
+// Copy puts an entry back on the clipboard and moves it to the top
+func (h *WaHistory) Copy(id int64) error {
+	dbInstance := db.GetWaDB()
+	item, err := dbInstance.GetClipboardHistoryItem(h.ctx, id)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	// the watcher sees the copy as a new change, the entry keeps the app it was copied from
+	hash := hashOf(item)
+	h.lastHash = hash
+	if err := h.write(item); err != nil {
+		return err
+	}
+	item.CopiedAt = time.Now()
+	if _, err := dbInstance.SaveClipboardHistory(h.ctx, item, hash); err != nil {
+		return err
+	}
+	h.emitChanged()
+	return nil
+}
+
+func (h *WaHistory) write(item *models.ClipboardHistoryItem) error {
+	var write func() error
+	switch item.Kind {
+	case models.ClipboardHistoryImage:
+		if h.clipboard.WriteImage != nil {
+			write = func() error { return h.clipboard.WriteImage(item.ImagePath) }
+		}
+	case models.ClipboardHistoryFiles:
+		if h.clipboard.WriteFiles != nil {
+			write = func() error { return h.clipboard.WriteFiles(item.Files) }
+		}
+	default:
+		if h.clipboard.WriteText != nil {
+			write = func() error { return h.clipboard.WriteText(item.Text) }
+		}
+	}
+	if write == nil {
+		return fmt.Errorf("copying %s to the clipboard is not supported", item.Kind)
+	}
+	return write()
+}
+
+// Delete removes an entry, pinned or not
+func (h *WaHistory) Delete(id int64) error {
+	dbInstance := db.GetWaDB()
+	item, err := dbInstance.GetClipboardHistoryItem(h.ctx, id)
+	if err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := h.deleteItems([]*models.ClipboardHistoryItem{item}); err != nil {
+		return err
+	}
+	h.emitChanged()
+	return nil
+}
+
+// Pin keeps an entry out of the retention limits and Clear
+func (h *WaHistory) Pin(id int64, pinned bool) error {
+	if err := db.GetWaDB().SetClipboardHistoryPinned(h.ctx, id, pinned); err != nil {
+		return fmt.Errorf("failed to pin clipboard history item %d: %w", id, err)
+	}
+	h.emitChanged()
+	return nil
+}
+
+// Clear removes every entry that is not pinned
+func (h *WaHistory) Clear() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	items, err := db.GetWaDB().GetUnpinnedClipboardHistory(h.ctx)
+	if err != nil {
+		return err
+	}
+	if err := h.deleteItems(items); err != nil {
+		return err
+	}
+	h.emitChanged()
+	return nil
+}
+
+// deleteItems removes entries and their image files, h.mu must be held
+func (h *WaHistory) deleteItems(items []*models.ClipboardHistoryItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	if err := db.GetWaDB().DeleteClipboardHistoryItems(h.ctx, ids); err != nil {
+		return err
+	}
+	for _, item := range items {
+		if hashOf(item) == h.lastHash {
+			// copying the removed content again records it again
+			h.lastHash = ""
+		}
+		if item.Kind != models.ClipboardHistoryImage {
+			continue
+		}
+		// images are stored by hash, an entry with the same image is the same row so the file is unused now
+		if err := os.Remove(item.ImagePath); err != nil && !os.IsNotExist(err) {
+			logger.Error(err, fmt.Sprintf("Failed to remove clipboard image %s", item.ImagePath))
+		}
+	}
+	return nil
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
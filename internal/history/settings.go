@@ -0,0 +1,157 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"watools/pkg/db"
+	"watools/pkg/models"
+)
+
+// settingsKey stores the Settings as JSON in metadata
+const settingsKey = "clipboard_history.settings"
+
+// defaultExcludedApps are password managers, their copies are not recorded even when they do not mark the content as concealed
+var defaultExcludedApps = []string{"1password", "bitwarden", "keepassxc", "keepass", "lastpass", "dashlane", "enpass", "keychain access"}
+
+// Settings controls what is recorded and how long it is kept, a limit of 0 is no limit
+type Settings struct {
+	Enabled    bool `json:"enabled"`
+	MaxItems   int  `json:"maxItems"`
+	MaxAgeDays int  `json:"maxAgeDays"`
+	MaxSizeMB  int  `json:"maxSizeMB"`
+	// ExcludedApps match the frontmost app names case-insensitively as substrings, such as "bitwarden" for "Bitwarden.exe"
+	ExcludedApps []string `json:"excludedApps"`
+}
+
+func DefaultSettings() Settings {
+	return Settings{
+		Enabled:      true,
+		MaxItems:     500,
+		MaxAgeDays:   30,
+		MaxSizeMB:    200,
+		ExcludedApps: append([]string(nil), defaultExcludedApps...),
+	}
+}
+
+func loadSettings(ctx context.Context) (Settings, error) {
+	settings := DefaultSettings()
+	value, err := db.GetWaDB().GetMetadata(ctx, settingsKey)
+	if err != nil {
+		return settings, err
+	}
+	stored, ok := value.Get()
+	if !ok {
+		return settings, nil
+	}
+	if err := json.Unmarshal([]byte(stored), &settings); err != nil {
+		return DefaultSettings(), fmt.Errorf("failed to decode clipboard history settings: %w", err)
+	}
+	return settings, nil
+}
+
+// normalize drops negative limits and empty or repeated app rules
+func (s Settings) normalize() Settings {
+	s.MaxItems = max(s.MaxItems, 0)
+	s.MaxAgeDays = max(s.MaxAgeDays, 0)
+	s.MaxSizeMB = max(s.MaxSizeMB, 0)
+	apps := make([]string, 0, len(s.ExcludedApps))
+	seen := make(map[string]bool, len(s.ExcludedApps))
+	for _, app := range s.ExcludedApps {
+		app = strings.ToLower(strings.TrimSpace(app))
+		if app == "" || seen[app] {
+			continue
+		}
+		seen[app] = true
+		apps = append(apps, app)
+	}
+	s.ExcludedApps = apps
+	return s
+}
+
+// isExcluded reports whether one of the frontmost app names contains an excluded app.
+// An unknown frontmost app, such as a native Wayland window, is excluded while there are excluded apps.
+func (s Settings) isExcluded(apps []string) bool {
+	if len(apps) == 0 {
+		return len(s.ExcludedApps) > 0
+	}
+	for _, app := range apps {
+		app = strings.ToLower(app)
+		for _, excluded := range s.ExcludedApps {
+			if excluded = strings.ToLower(excluded); excluded != "" && strings.Contains(app, excluded) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// expired returns the unpinned entries, newest first, that are beyond a retention limit at now.
+// Entries are kept from the newest until the count, the age or the total size is exceeded.
+func (s Settings) expired(items []*models.ClipboardHistoryItem, now time.Time) []*models.ClipboardHistoryItem {
+	maxSize := int64(s.MaxSizeMB) * 1024 * 1024
+	var totalSize int64
+	for i, item := range items {
+		totalSize += item.Size
+		if (s.MaxItems > 0 && i >= s.MaxItems) ||
+			(s.MaxAgeDays > 0 && now.Sub(item.CopiedAt) > time.Duration(s.MaxAgeDays)*24*time.Hour) ||
+			(maxSize > 0 && totalSize > maxSize) {
+			return items[i:]
+		}
+	}
+	return nil
+}
+
+func (h *WaHistory) GetSettings() Settings {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	settings := h.settings
+	settings.ExcludedApps = append([]string(nil), h.settings.ExcludedApps...)
+	return settings
+}
+
+// FrontmostAppUnknown reports whether the last clipboard change came without a frontmost app,
+// nothing is recorded then until the excluded apps are cleared
+func (h *WaHistory) FrontmostAppUnknown() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.frontmostAppUnknown
+}
+
+// UpdateSettings stores settings and applies the new limits right away
+func (h *WaHistory) UpdateSettings(settings Settings) (Settings, error) {
+	settings = settings.normalize()
+	encoded, err := json.Marshal(settings)
+	if err != nil {
+		return Settings{}, err
+	}
+	if err := db.GetWaDB().SetMetadata(h.ctx, settingsKey, string(encoded)); err != nil {
+		return Settings{}, fmt.Errorf("failed to save clipboard history settings: %w", err)
+	}
+	h.mu.Lock()
+	h.settings = settings
+	err = h.pruneLocked()
+	h.mu.Unlock()
+	if err != nil {
+		return settings, err
+	}
+	h.emitChanged()
+	return settings, nil
+}
+
+func (h *WaHistory) prune() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.pruneLocked()
+}
+
+// pruneLocked removes the entries beyond the retention limits, h.mu must be held
+func (h *WaHistory) pruneLocked() error {
+	items, err := db.GetWaDB().GetUnpinnedClipboardHistory(h.ctx)
+	if err != nil {
+		return err
+	}
+	return h.deleteItems(h.settings.expired(items, time.Now()))
+}
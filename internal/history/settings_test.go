@@ -0,0 +1,120 @@
+package history
+
+import (
+	"encoding/base64"
+	"path/filepath"
+	"testing"
+	"time"
+	"watools/pkg/models"
+)
+
+func TestSettingsExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	items := []*models.ClipboardHistoryItem{
+		{ID: 1, Size: 1024 * 1024, CopiedAt: now.Add(-time.Hour)},
+		{ID: 2, Size: 1024 * 1024, CopiedAt: now.Add(-24 * time.Hour)},
+		{ID: 3, Size: 1024 * 1024, CopiedAt: now.Add(-3 * 24 * time.Hour)},
+		{ID: 4, Size: 1024 * 1024, CopiedAt: now.Add(-10 * 24 * time.Hour)},
+	}
+
+	cases := []struct {
+		name     string
+		settings Settings
+		expected []int64
+	}{
+		{name: "no limits", settings: Settings{}, expected: nil},
+		{name: "count", settings: Settings{MaxItems: 3}, expected: []int64{4}},
+		{name: "age", settings: Settings{MaxAgeDays: 2}, expected: []int64{3, 4}},
+		{name: "size", settings: Settings{MaxSizeMB: 1}, expected: []int64{2, 3, 4}},
+		{name: "strictest limit wins", settings: Settings{MaxItems: 10, MaxAgeDays: 7, MaxSizeMB: 2}, expected: []int64{3, 4}},
+	}
+	for _, c := range cases {
+		expired := c.settings.expired(items, now)
+		if len(expired) != len(c.expected) {
+			t.Fatalf("%s: expected %v, got %d entries", c.name, c.expected, len(expired))
+		}
+		for i, item := range expired {
+			if item.ID != c.expected[i] {
+				t.Fatalf("%s: expected %v, got id %d at %d", c.name, c.expected, item.ID, i)
+			}
+		}
+	}
+}
+
+func TestSettingsIsExcluded(t *testing.T) {
+	t.Parallel()
+
+	settings := DefaultSettings()
+	for _, apps := range [][]string{
+		{"com.bitwarden.desktop", "Bitwarden"},
+		{"KeePassXC.exe"},
+		{"1Password", "1password", "1password"},
+		{"com.apple.keychainaccess", "Keychain Access"},
+	} {
+		if !settings.isExcluded(apps) {
+			t.Fatalf("expected %v to be excluded", apps)
+		}
+	}
+	for _, apps := range [][]string{{"firefox", "Firefox"}, {"Code.exe"}} {
+		if settings.isExcluded(apps) {
+			t.Fatalf("expected %v to be recorded", apps)
+		}
+	}
+}
+
+func TestSettingsIsExcludedUnknownApp(t *testing.T) {
+	t.Parallel()
+
+	if !DefaultSettings().isExcluded(nil) {
+		t.Fatal("expected an unknown frontmost app to be excluded while there are excluded apps")
+	}
+	if (Settings{}).isExcluded(nil) {
+		t.Fatal("expected an unknown frontmost app to be recorded without excluded apps")
+	}
+}
+
+func TestSettingsNormalize(t *testing.T) {
+	t.Parallel()
+
+	settings := Settings{MaxItems: -1, MaxAgeDays: 7, MaxSizeMB: -5, ExcludedApps: []string{" Bitwarden ", "", "bitwarden", "Enpass"}}.normalize()
+	if settings.MaxItems != 0 || settings.MaxAgeDays != 7 || settings.MaxSizeMB != 0 {
+		t.Fatalf("unexpected limits %+v", settings)
+	}
+	if len(settings.ExcludedApps) != 2 || settings.ExcludedApps[0] != "bitwarden" || settings.ExcludedApps[1] != "enpass" {
+		t.Fatalf("unexpected excluded apps %v", settings.ExcludedApps)
+	}
+}
+
+func TestItemOf(t *testing.T) {
+	t.Parallel()
+
+	if _, _, _, ok := itemOf(Content{Text: " \n\t"}); ok {
+		t.Fatal("expected blank text to be skipped")
+	}
+
+	files, filesHash, _, ok := itemOf(Content{Text: "/tmp/a.txt", Files: []string{"/tmp/a.txt", "/tmp/b.txt"}})
+	if !ok || files.Kind != models.ClipboardHistoryFiles || len(files.Files) != 2 {
+		t.Fatalf("expected files to be preferred, got %+v", files)
+	}
+	if hashOf(files) != filesHash {
+		t.Fatal("expected the stored files to hash like the recorded content")
+	}
+
+	image, imageHash, data, ok := itemOf(Content{Text: "image", ImageBase64: base64.StdEncoding.EncodeToString([]byte("png"))})
+	if !ok || image.Kind != models.ClipboardHistoryImage || string(data) != "png" || image.Size != 3 {
+		t.Fatalf("expected an image entry, got %+v", image)
+	}
+	if filepath.Base(image.ImagePath) != imageHash+".png" || hashOf(image) != imageHash {
+		t.Fatalf("expected the image file to be named by hash, got %s", image.ImagePath)
+	}
+
+	text, textHash, _, ok := itemOf(Content{Text: "png"})
+	if !ok || text.Kind != models.ClipboardHistoryText || text.Text != "png" {
+		t.Fatalf("expected a text entry, got %+v", text)
+	}
+	if textHash == imageHash {
+		t.Fatal("expected text and image with the same bytes to hash differently")
+	}
+}
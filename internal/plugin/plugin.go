@@ -63,11 +63,6 @@ func (p *WaPlugin) GetJsEntryUrl(packageID string) string {
 	return plugin.GetJsEntryUrl()
 }
 
-func (p *WaPlugin) UpdatePluginUsage(updates []models.PluginUsageUpdate) error {
-	dbInstance := db.GetWaDB()
-	return dbInstance.BatchUpdatePluginUsage(p.ctx, updates)
-}
-
 // InstallPlugin installs a plugin from a .wt file
 func (p *WaPlugin) InstallPlugin(wtFilePath string) error {
 	if err := p.installer.InstallFromWtFile(wtFilePath); err != nil {
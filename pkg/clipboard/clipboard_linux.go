@@ -0,0 +1,189 @@
+package clipboard
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// MIME targets understood by the Linux clipboard backend
+const (
+	MimeTextUTF8          = "text/plain;charset=utf-8"
+	MimeText              = "text/plain"
+	MimeUTF8String        = "UTF8_STRING"
+	MimeURIList           = "text/uri-list"
+	MimeGnomeCopiedFiles  = "x-special/gnome-copied-files"
+	MimePNG               = "image/png"
+	xclipTargetsSelection = "TARGETS"
+)
+
+// TextTargets are the text targets in order of preference
+var TextTargets = []string{MimeTextUTF8, MimeUTF8String, MimeText, "STRING", "TEXT"}
+
+// ToolRunner executes the clipboard command line tools, tests replace it to run without a display
+type ToolRunner interface {
+	LookPath(file string) (string, error)
+	Output(name string, args ...string) ([]byte, error)
+	RunWithInput(input []byte, name string, args ...string) error
+}
+
+type execRunner struct{}
+
+func (execRunner) LookPath(file string) (string, error) {
+	return exec.LookPath(file)
+}
+
+func (execRunner) Output(name string, args ...string) ([]byte, error) {
+	return exec.Command(name, args...).Output()
+}
+
+func (execRunner) RunWithInput(input []byte, name string, args ...string) error {
+	// wl-copy and xclip fork to keep serving the selection, stdout must not be a pipe or Run would wait for the child
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = bytes.NewReader(input)
+	return cmd.Run()
+}
+
+// Backend reads and writes the clipboard through wl-clipboard on Wayland and xclip on X11
+type Backend struct {
+	runner  ToolRunner
+	wayland bool
+}
+
+// NewBackend create a clipboard backend, wayland selects wl-paste/wl-copy when they are installed
+func NewBackend(runner ToolRunner, wayland bool) *Backend {
+	return &Backend{runner: runner, wayland: wayland}
+}
+
+var (
+	defaultBackend     *Backend
+	defaultBackendOnce sync.Once
+)
+
+// DefaultBackend returns the backend for the current display server
+func DefaultBackend() *Backend {
+	defaultBackendOnce.Do(func() {
+		defaultBackend = NewBackend(execRunner{}, os.Getenv("WAYLAND_DISPLAY") != "")
+	})
+	return defaultBackend
+}
+
+func (b *Backend) useWlClipboard() (bool, error) {
+	if b.wayland {
+		if _, err := b.runner.LookPath("wl-paste"); err == nil {
+			return true, nil
+		}
+	}
+	// XWayland sessions still expose the X11 clipboard
+	if _, err := b.runner.LookPath("xclip"); err == nil {
+		return false, nil
+	}
+	if b.wayland {
+		return false, fmt.Errorf("neither wl-clipboard nor xclip is installed")
+	}
+	return false, fmt.Errorf("xclip is not installed")
+}
+
+// Targets returns the MIME targets offered by the current clipboard owner
+func (b *Backend) Targets() ([]string, error) {
+	wl, err := b.useWlClipboard()
+	if err != nil {
+		return nil, err
+	}
+	var output []byte
+	if wl {
+		output, err = b.runner.Output("wl-paste", "--list-types")
+	} else {
+		output, err = b.runner.Output("xclip", "-selection", "clipboard", "-o", "-t", xclipTargetsSelection)
+	}
+	if err != nil {
+		// both tools fail when the clipboard is empty
+		return []string{}, nil
+	}
+	targets := []string{}
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" && line != xclipTargetsSelection {
+			targets = append(targets, line)
+		}
+	}
+	return targets, nil
+}
+
+// Read returns the clipboard data for target
+func (b *Backend) Read(target string) ([]byte, error) {
+	wl, err := b.useWlClipboard()
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if wl {
+		data, err = b.runner.Output("wl-paste", "--no-newline", "--type", target)
+	} else {
+		data, err = b.runner.Output("xclip", "-selection", "clipboard", "-o", "-t", target)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read clipboard target '%s': %w", target, err)
+	}
+	return data, nil
+}
+
+// Write replaces the clipboard content with data offered as target
+func (b *Backend) Write(target string, data []byte) error {
+	wl, err := b.useWlClipboard()
+	if err != nil {
+		return err
+	}
+	if wl {
+		err = b.runner.RunWithInput(data, "wl-copy", "--type", target)
+	} else {
+		err = b.runner.RunWithInput(data, "xclip", "-selection", "clipboard", "-i", "-t", target)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write clipboard target '%s': %w", target, err)
+	}
+	return nil
+}
+
+// ParseURIList decodes a text/uri-list payload into local file paths, comments and non file URIs are skipped
+func ParseURIList(data []byte) []string {
+	files := []string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if path, ok := fileURIToPath(line); ok {
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+// ParseGnomeCopiedFiles decodes x-special/gnome-copied-files, a "copy" or "cut" line followed by file URIs
+func ParseGnomeCopiedFiles(data []byte) []string {
+	text := string(data)
+	if action, rest, found := strings.Cut(text, "\n"); found && (action == "copy" || action == "cut") {
+		text = rest
+	} else if action == "copy" || action == "cut" {
+		return []string{}
+	}
+	return ParseURIList([]byte(text))
+}
+
+func fileURIToPath(uri string) (string, bool) {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "file" {
+		return "", false
+	}
+	if parsed.Host != "" && parsed.Host != "localhost" {
+		return "", false
+	}
+	if parsed.Path == "" {
+		return "", false
+	}
+	return parsed.Path, true
+}
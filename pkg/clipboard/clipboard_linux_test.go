@@ -0,0 +1,136 @@
+package clipboard
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type fakeRunner struct {
+	installed map[string]bool
+	outputs   map[string]string
+	inputs    map[string]string
+}
+
+func (f *fakeRunner) LookPath(file string) (string, error) {
+	if f.installed[file] {
+		return "/usr/bin/" + file, nil
+	}
+	return "", fmt.Errorf("%s not found", file)
+}
+
+func (f *fakeRunner) Output(name string, args ...string) ([]byte, error) {
+	line := strings.Join(append([]string{name}, args...), " ")
+	output, exists := f.outputs[line]
+	if !exists {
+		return nil, fmt.Errorf("unexpected command: %s", line)
+	}
+	return []byte(output), nil
+}
+
+func (f *fakeRunner) RunWithInput(input []byte, name string, args ...string) error {
+	if f.inputs == nil {
+		f.inputs = make(map[string]string)
+	}
+	f.inputs[strings.Join(append([]string{name}, args...), " ")] = string(input)
+	return nil
+}
+
+func TestBackendSelectsTool(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		wayland   bool
+		installed map[string]bool
+		command   string
+	}{
+		{name: "wayland", wayland: true, installed: map[string]bool{"wl-paste": true, "xclip": true}, command: "wl-paste --list-types"},
+		{name: "xwayland fallback", wayland: true, installed: map[string]bool{"xclip": true}, command: "xclip -selection clipboard -o -t TARGETS"},
+		{name: "x11", wayland: false, installed: map[string]bool{"wl-paste": true, "xclip": true}, command: "xclip -selection clipboard -o -t TARGETS"},
+	}
+	for _, tc := range cases {
+		runner := &fakeRunner{
+			installed: tc.installed,
+			outputs:   map[string]string{tc.command: "TARGETS\ntext/plain\nimage/png\n"},
+		}
+		targets, err := NewBackend(runner, tc.wayland).Targets()
+		if err != nil {
+			t.Fatalf("%s: Targets returned error: %v", tc.name, err)
+		}
+		if want := []string{"text/plain", "image/png"}; !reflect.DeepEqual(targets, want) {
+			t.Fatalf("%s: Targets = %v, want %v", tc.name, targets, want)
+		}
+	}
+
+	if _, err := NewBackend(&fakeRunner{}, false).Targets(); err == nil {
+		t.Fatalf("expected error without clipboard tools")
+	}
+}
+
+func TestBackendWrite(t *testing.T) {
+	t.Parallel()
+
+	runner := &fakeRunner{installed: map[string]bool{"wl-paste": true}}
+	if err := NewBackend(runner, true).Write(MimePNG, []byte("png")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if got := runner.inputs["wl-copy --type image/png"]; got != "png" {
+		t.Fatalf("wl-copy input = %q, want %q", got, "png")
+	}
+
+	runner = &fakeRunner{installed: map[string]bool{"xclip": true}}
+	if err := NewBackend(runner, false).Write(MimePNG, []byte("png")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if got := runner.inputs["xclip -selection clipboard -i -t image/png"]; got != "png" {
+		t.Fatalf("xclip input = %q, want %q", got, "png")
+	}
+}
+
+func TestParseFileLists(t *testing.T) {
+	t.Parallel()
+
+	uriList := "# comment\r\nfile:///home/user/My%20File.txt\r\nhttps://example.com/\r\nfile://localhost/tmp/a\r\nfile://remote/tmp/b\r\n"
+	if got, want := ParseURIList([]byte(uriList)), []string{"/home/user/My File.txt", "/tmp/a"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseURIList = %v, want %v", got, want)
+	}
+
+	gnome := "cut\nfile:///home/user/a.txt\nfile:///home/user/b%23.txt"
+	if got, want := ParseGnomeCopiedFiles([]byte(gnome)), []string{"/home/user/a.txt", "/home/user/b#.txt"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseGnomeCopiedFiles = %v, want %v", got, want)
+	}
+	if got := ParseGnomeCopiedFiles([]byte("copy")); len(got) != 0 {
+		t.Fatalf("ParseGnomeCopiedFiles(copy) = %v, want empty", got)
+	}
+}
+
+func TestBackendChangeMarker(t *testing.T) {
+	t.Parallel()
+
+	x11 := &fakeRunner{
+		installed: map[string]bool{"xclip": true},
+		outputs:   map[string]string{"xclip -selection clipboard -o -t TIMESTAMP": "\x01\x02"},
+	}
+	if got := NewBackend(x11, false).ChangeMarker(); got != "timestamp:0102" {
+		t.Fatalf("ChangeMarker() on X11 = %q", got)
+	}
+
+	wayland := &fakeRunner{
+		installed: map[string]bool{"wl-paste": true},
+		outputs: map[string]string{
+			"wl-paste --list-types":                                 "text/plain;charset=utf-8\n",
+			"wl-paste --no-newline --type text/plain;charset=utf-8": "first",
+		},
+	}
+	backend := NewBackend(wayland, true)
+	first := backend.ChangeMarker()
+	wayland.outputs["wl-paste --no-newline --type text/plain;charset=utf-8"] = "second"
+	if second := backend.ChangeMarker(); first == "" || first == second {
+		t.Fatalf("ChangeMarker() = %q then %q, want two different markers", first, second)
+	}
+	if got := NewBackend(&fakeRunner{installed: map[string]bool{"xclip": true}, outputs: map[string]string{}}, false).ChangeMarker(); got != "" {
+		t.Fatalf("ChangeMarker() of an empty clipboard = %q", got)
+	}
+}
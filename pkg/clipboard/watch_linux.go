@@ -0,0 +1,113 @@
+package clipboard
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// xclipTimestampSelection is the X11 target holding the time the owner took the selection
+const xclipTimestampSelection = "TIMESTAMP"
+
+// Watch calls onChange whenever the clipboard changes, until ctx is done.
+// wl-paste --watch reports changes on compositors with the data-control protocol, everywhere else ChangeMarker is polled every interval.
+func (b *Backend) Watch(ctx context.Context, interval time.Duration, onChange func()) error {
+	wl, err := b.useWlClipboard()
+	if err != nil {
+		return err
+	}
+	if wl {
+		for watchWlPaste(ctx, onChange) {
+			// wl-paste exits when the compositor goes away, watching resumes once it is back
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(interval):
+			}
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last := b.ChangeMarker()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if marker := b.ChangeMarker(); marker != last {
+				last = marker
+				onChange()
+			}
+		}
+	}
+}
+
+// ChangeMarker returns a value that changes with the clipboard content.
+// On X11 it is the TIMESTAMP of the selection owner, otherwise it is derived from the targets and the text.
+func (b *Backend) ChangeMarker() string {
+	wl, err := b.useWlClipboard()
+	if err != nil {
+		return ""
+	}
+	if !wl {
+		if timestamp, err := b.runner.Output("xclip", "-selection", "clipboard", "-o", "-t", xclipTimestampSelection); err == nil && len(timestamp) > 0 {
+			return "timestamp:" + hex.EncodeToString(timestamp)
+		}
+	}
+	targets, err := b.Targets()
+	if err != nil || len(targets) == 0 {
+		return ""
+	}
+	hash := sha1.New()
+	hash.Write([]byte(strings.Join(targets, "\n")))
+	for _, target := range TextTargets {
+		if !containsTarget(targets, target) {
+			continue
+		}
+		if text, err := b.Read(target); err == nil {
+			hash.Write([]byte{0})
+			hash.Write(text)
+		}
+		break
+	}
+	return "content:" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// watchWlPaste runs wl-paste --watch until it exits.
+// It reports whether wl-paste watched and should be restarted, false when ctx is done or the compositor does not support watching.
+func watchWlPaste(ctx context.Context, onChange func()) bool {
+	cmd := exec.CommandContext(ctx, "wl-paste", "--watch", "echo")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return false
+	}
+	if err := cmd.Start(); err != nil {
+		return false
+	}
+	// the command also runs for the selection present when watching starts, duplicates are left to the caller
+	scanner := bufio.NewScanner(stdout)
+	watched := false
+	for scanner.Scan() {
+		watched = true
+		onChange()
+	}
+	_ = cmd.Wait()
+	return watched && ctx.Err() == nil
+}
+
+func containsTarget(targets []string, target string) bool {
+	for _, candidate := range targets {
+		if strings.EqualFold(candidate, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -15,9 +15,9 @@ import (
 )
 
 const createApplication = `-- name: CreateApplication :one
-INSERT INTO application (id, name, description, category, path, icon_path, dir_updated_at)
-VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
-RETURNING id, name, description, category, path, icon_path, updated_at, dir_updated_at, last_used_at, used_count
+INSERT INTO application (id, name, description, category, path, icon_path, dir_updated_at, parent_id, action_id)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9)
+RETURNING id, name, description, category, path, icon_path, updated_at, dir_updated_at, last_used_at, used_count, parent_id, action_id
 `
 
 type CreateApplicationParams struct {
@@ -28,6 +28,8 @@ type CreateApplicationParams struct {
 	Path         string
 	IconPath     mo.Option[string]
 	DirUpdatedAt time.Time
+	ParentID     mo.Option[string]
+	ActionID     string
 }
 
 func (q *Queries) CreateApplication(ctx context.Context, arg CreateApplicationParams) (Application, error) {
@@ -39,6 +41,8 @@ func (q *Queries) CreateApplication(ctx context.Context, arg CreateApplicationPa
 		arg.Path,
 		arg.IconPath,
 		arg.DirUpdatedAt,
+		arg.ParentID,
+		arg.ActionID,
 	)
 	var i Application
 	err := row.Scan(
@@ -52,6 +56,8 @@ func (q *Queries) CreateApplication(ctx context.Context, arg CreateApplicationPa
 		&i.DirUpdatedAt,
 		&i.LastUsedAt,
 		&i.UsedCount,
+		&i.ParentID,
+		&i.ActionID,
 	)
 	return i, err
 }
@@ -77,11 +83,101 @@ func (q *Queries) DeleteApplication(ctx context.Context, ids []string) error {
 	return err
 }
 
+const deleteApplicationActions = `-- name: DeleteApplicationActions :exec
+DELETE
+FROM application
+WHERE parent_id IN (/*SLICE:parent_ids*/?)
+`
+
+func (q *Queries) DeleteApplicationActions(ctx context.Context, parentIds []string) error {
+	query := deleteApplicationActions
+	var queryParams []interface{}
+	if len(parentIds) > 0 {
+		for _, v := range parentIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:parent_ids*/?", strings.Repeat(",?", len(parentIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:parent_ids*/?", "NULL", 1)
+	}
+	_, err := q.db.ExecContext(ctx, query, queryParams...)
+	return err
+}
+
+const getApplication = `-- name: GetApplication :one
+SELECT id, name, description, category, path, icon_path, updated_at, dir_updated_at, last_used_at, used_count, parent_id, action_id
+FROM application
+WHERE id = ?1
+`
+
+func (q *Queries) GetApplication(ctx context.Context, id string) (Application, error) {
+	row := q.db.QueryRowContext(ctx, getApplication, id)
+	var i Application
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Category,
+		&i.Path,
+		&i.IconPath,
+		&i.UpdatedAt,
+		&i.DirUpdatedAt,
+		&i.LastUsedAt,
+		&i.UsedCount,
+		&i.ParentID,
+		&i.ActionID,
+	)
+	return i, err
+}
+
+const getApplicationActions = `-- name: GetApplicationActions :many
+SELECT id, name, description, category, path, icon_path, updated_at, dir_updated_at, last_used_at, used_count, parent_id, action_id
+FROM application
+WHERE parent_id = ?1
+`
+
+func (q *Queries) GetApplicationActions(ctx context.Context, parentID mo.Option[string]) ([]Application, error) {
+	rows, err := q.db.QueryContext(ctx, getApplicationActions, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Application
+	for rows.Next() {
+		var i Application
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Category,
+			&i.Path,
+			&i.IconPath,
+			&i.UpdatedAt,
+			&i.DirUpdatedAt,
+			&i.LastUsedAt,
+			&i.UsedCount,
+			&i.ParentID,
+			&i.ActionID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getApplicationIsUpdatedDir = `-- name: GetApplicationIsUpdatedDir :one
-SELECT id, name, description, category, path, icon_path, updated_at, dir_updated_at, last_used_at, used_count
+SELECT id, name, description, category, path, icon_path, updated_at, dir_updated_at, last_used_at, used_count, parent_id, action_id
 FROM application
 WHERE dir_updated_at != ?1
   AND path = ?2
+  AND action_id = ''
 LIMIT 1
 `
 
@@ -104,12 +200,14 @@ func (q *Queries) GetApplicationIsUpdatedDir(ctx context.Context, arg GetApplica
 		&i.DirUpdatedAt,
 		&i.LastUsedAt,
 		&i.UsedCount,
+		&i.ParentID,
+		&i.ActionID,
 	)
 	return i, err
 }
 
 const getApplications = `-- name: GetApplications :many
-SELECT id, name, description, category, path, icon_path, updated_at, dir_updated_at, last_used_at, used_count
+SELECT id, name, description, category, path, icon_path, updated_at, dir_updated_at, last_used_at, used_count, parent_id, action_id
 FROM application
 `
 
@@ -133,6 +231,8 @@ func (q *Queries) GetApplications(ctx context.Context) ([]Application, error) {
 			&i.DirUpdatedAt,
 			&i.LastUsedAt,
 			&i.UsedCount,
+			&i.ParentID,
+			&i.ActionID,
 		); err != nil {
 			return nil, err
 		}
@@ -148,7 +248,7 @@ func (q *Queries) GetApplications(ctx context.Context) ([]Application, error) {
 }
 
 const getExpiredApplications = `-- name: GetExpiredApplications :many
-SELECT id, name, description, category, path, icon_path, updated_at, dir_updated_at, last_used_at, used_count
+SELECT id, name, description, category, path, icon_path, updated_at, dir_updated_at, last_used_at, used_count, parent_id, action_id
 FROM application
 WHERE updated_at < ?1
 `
@@ -173,6 +273,8 @@ func (q *Queries) GetExpiredApplications(ctx context.Context, updatedAt time.Tim
 			&i.DirUpdatedAt,
 			&i.LastUsedAt,
 			&i.UsedCount,
+			&i.ParentID,
+			&i.ActionID,
 		); err != nil {
 			return nil, err
 		}
@@ -187,6 +289,24 @@ func (q *Queries) GetExpiredApplications(ctx context.Context, updatedAt time.Tim
 	return items, nil
 }
 
+const incrementApplicationUsage = `-- name: IncrementApplicationUsage :exec
+UPDATE application
+SET last_used_at = ?1,
+    used_count   = used_count + 1,
+    updated_at   = datetime('now', 'localtime')
+WHERE id = ?2
+`
+
+type IncrementApplicationUsageParams struct {
+	LastUsedAt models.OptionTime
+	ID         string
+}
+
+func (q *Queries) IncrementApplicationUsage(ctx context.Context, arg IncrementApplicationUsageParams) error {
+	_, err := q.db.ExecContext(ctx, incrementApplicationUsage, arg.LastUsedAt, arg.ID)
+	return err
+}
+
 const updateApplicationPartial = `-- name: UpdateApplicationPartial :exec
 UPDATE application
 SET name           = COALESCE(?1, name),
@@ -221,22 +341,3 @@ func (q *Queries) UpdateApplicationPartial(ctx context.Context, arg UpdateApplic
 	)
 	return err
 }
-
-const updateApplicationUsage = `-- name: UpdateApplicationUsage :exec
-UPDATE application
-SET last_used_at = ?1,
-    used_count   = ?2,
-    updated_at   = datetime('now', 'localtime')
-WHERE id = ?3
-`
-
-type UpdateApplicationUsageParams struct {
-	LastUsedAt models.OptionTime
-	UsedCount  int64
-	ID         string
-}
-
-func (q *Queries) UpdateApplicationUsage(ctx context.Context, arg UpdateApplicationUsageParams) error {
-	_, err := q.db.ExecContext(ctx, updateApplicationUsage, arg.LastUsedAt, arg.UsedCount, arg.ID)
-	return err
-}
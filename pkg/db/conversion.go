@@ -3,6 +3,9 @@ package db
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"strings"
+	"watools/config"
 	"watools/pkg/logger"
 	"watools/pkg/models"
 
@@ -10,7 +13,12 @@ import (
 )
 
 func ConvertApplicationCommand(command Application) *models.ApplicationCommand {
-	return models.NewApplicationCommand(command.Name, command.Description, command.Path, command.IconPath, mo.Some(command.ID), command.DirUpdatedAt)
+	cmd := models.NewApplicationCommand(command.Name, command.Description, command.Path, command.IconPath, mo.Some(command.ID), command.DirUpdatedAt)
+	cmd.ParentID = command.ParentID.OrEmpty()
+	cmd.ActionID = command.ActionID
+	cmd.LastUsedAt = command.LastUsedAt
+	cmd.UsedCount = command.UsedCount
+	return cmd
 }
 
 func ConvertPluginState(plugin PluginState) *models.PluginState {
@@ -29,3 +37,107 @@ func ConvertPluginState(plugin PluginState) *models.PluginState {
 		UsedCount:  plugin.UsedCount,
 	}
 }
+
+func ConvertQuicklink(quicklink Quicklink) *models.QuicklinkCommand {
+	return models.NewQuicklinkCommand(quicklink.Name, quicklink.Keyword, quicklink.Url, quicklink.Icon.OrEmpty(), quicklink.TargetApp.OrEmpty(), mo.Some(quicklink.ID))
+}
+
+// ConvertSnippet decodes the JSON tags of a snippet, unreadable tags are dropped
+func ConvertSnippet(snippet Snippet) *models.SnippetCommand {
+	var tags []string
+	if err := json.Unmarshal([]byte(snippet.Tags), &tags); err != nil {
+		logger.Error(err, fmt.Sprintf("Failed to unmarshal snippet tags: %s", snippet.Tags))
+	}
+	return models.NewSnippetCommand(snippet.Name, snippet.Keyword, snippet.Body, tags, mo.Some(snippet.ID))
+}
+
+func encodeSnippetTags(tags []string) (string, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+	encoded, err := json.Marshal(tags)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal snippet tags: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// ConvertClipboardHistory resolves the content column by kind: the text, the image file name or the file paths one per line
+func ConvertClipboardHistory(item ClipboardHistory) *models.ClipboardHistoryItem {
+	converted := &models.ClipboardHistoryItem{
+		ID:        item.ID,
+		Kind:      models.ClipboardHistoryKind(item.Kind),
+		Size:      item.Size,
+		SourceApp: item.SourceApp,
+		Pinned:    item.Pinned,
+		CopiedAt:  item.CopiedAt,
+	}
+	switch converted.Kind {
+	case models.ClipboardHistoryImage:
+		converted.ImagePath = filepath.Join(config.ClipboardImageDir(), item.Content)
+	case models.ClipboardHistoryFiles:
+		converted.Files = strings.Split(item.Content, "\n")
+	default:
+		converted.Text = item.Content
+	}
+	return converted
+}
+
+// clipboardHistoryContent is the reverse of ConvertClipboardHistory
+func clipboardHistoryContent(item *models.ClipboardHistoryItem) string {
+	switch item.Kind {
+	case models.ClipboardHistoryImage:
+		return filepath.Base(item.ImagePath)
+	case models.ClipboardHistoryFiles:
+		return strings.Join(item.Files, "\n")
+	default:
+		return item.Text
+	}
+}
+
+func ConvertSelectionEvent(event SelectionEvent) models.SelectionEvent {
+	return models.SelectionEvent{
+		TriggerID:     event.TriggerID,
+		Source:        event.Source,
+		Query:         event.Query,
+		ClipboardType: event.ClipboardType,
+		SelectedAt:    event.SelectedAt,
+	}
+}
+
+func ConvertCommandVisibility(visibility CommandVisibility) models.CommandVisibility {
+	pinOrder := mo.None[int]()
+	if order, ok := visibility.PinOrder.Get(); ok {
+		pinOrder = mo.Some(int(order))
+	}
+	return models.CommandVisibility{
+		Source:   models.CommandCategory(visibility.Source),
+		Key:      visibility.CommandKey,
+		Hidden:   visibility.Hidden,
+		PinOrder: pinOrder,
+	}
+}
+
+func ConvertIndexedFile(file IndexedFile) models.IndexedFile {
+	return models.IndexedFile{
+		Path:       file.Path,
+		Name:       file.Name,
+		Root:       file.Root,
+		IsDir:      file.IsDir,
+		Size:       file.Size,
+		ModifiedAt: file.ModifiedAt,
+	}
+}
+
+func ConvertGitRepo(repo GitRepo) models.GitRepo {
+	return models.GitRepo{
+		Path:      repo.Path,
+		Root:      repo.Root,
+		Name:      repo.Name,
+		RemoteURL: repo.RemoteUrl,
+	}
+}
+
+func ConvertBookmark(bookmark Bookmark) *models.BookmarkCommand {
+	return models.NewBookmarkCommand(bookmark.Source, bookmark.Browser, bookmark.Profile, bookmark.Title, bookmark.Url, bookmark.FolderPath, bookmark.Favicon)
+}
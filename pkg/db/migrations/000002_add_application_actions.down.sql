@@ -0,0 +1,33 @@
+CREATE TABLE application_old
+(
+    id             TEXT     NOT NULL PRIMARY KEY,
+    name           TEXT     NOT NULL,
+    description    TEXT,
+    category       TEXT     NOT NULL,
+    path           TEXT     NOT NULL UNIQUE,
+    icon_path      TEXT,
+    updated_at     DATETIME NOT NULL DEFAULT (datetime('now', 'localtime')),
+    dir_updated_at DATETIME NOT NULL,
+    last_used_at   DATETIME,
+    used_count     INT      NOT NULL DEFAULT 0
+);
+
+INSERT INTO application_old (id, name, description, category, path, icon_path, updated_at, dir_updated_at,
+                             last_used_at, used_count)
+SELECT id,
+       name,
+       description,
+       category,
+       path,
+       icon_path,
+       updated_at,
+       dir_updated_at,
+       last_used_at,
+       used_count
+FROM application
+WHERE parent_id IS NULL;
+
+DROP TABLE application;
+
+ALTER TABLE application_old
+    RENAME TO application;
@@ -0,0 +1,39 @@
+-- Desktop entry actions are stored as child rows that share the path of their parent application,
+-- so the unique constraint moves from path to (path, action_id)
+CREATE TABLE application_new
+(
+    id             TEXT     NOT NULL PRIMARY KEY,
+    name           TEXT     NOT NULL,
+    description    TEXT,
+    category       TEXT     NOT NULL,
+    path           TEXT     NOT NULL,
+    icon_path      TEXT,
+    updated_at     DATETIME NOT NULL DEFAULT (datetime('now', 'localtime')),
+    dir_updated_at DATETIME NOT NULL,
+    last_used_at   DATETIME,
+    used_count     INT      NOT NULL DEFAULT 0,
+    parent_id      TEXT,
+    action_id      TEXT     NOT NULL DEFAULT '',
+    UNIQUE (path, action_id)
+);
+
+INSERT INTO application_new (id, name, description, category, path, icon_path, updated_at, dir_updated_at,
+                             last_used_at, used_count)
+SELECT id,
+       name,
+       description,
+       category,
+       path,
+       icon_path,
+       updated_at,
+       dir_updated_at,
+       last_used_at,
+       used_count
+FROM application;
+
+DROP TABLE application;
+
+ALTER TABLE application_new
+    RENAME TO application;
+
+CREATE INDEX IF NOT EXISTS idx_application_parent_id ON application (parent_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_quicklink_keyword;
+DROP TABLE IF EXISTS quicklink;
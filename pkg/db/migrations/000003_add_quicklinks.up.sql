@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS quicklink
+(
+    id         TEXT     NOT NULL PRIMARY KEY,
+    name       TEXT     NOT NULL,
+    keyword    TEXT     NOT NULL DEFAULT '',
+    url        TEXT     NOT NULL,
+    icon       TEXT,
+    target_app TEXT,
+    created_at DATETIME NOT NULL DEFAULT (datetime('now', 'localtime')),
+    updated_at DATETIME NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_quicklink_keyword ON quicklink (keyword);
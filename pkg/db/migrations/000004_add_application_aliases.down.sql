@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_application_alias_alias;
+DROP TABLE IF EXISTS application_alias;
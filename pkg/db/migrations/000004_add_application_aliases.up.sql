@@ -0,0 +1,12 @@
+-- Aliases are keyed by the application path and action id instead of the application id,
+-- application rows are deleted and inserted again with a new id when they are rescanned
+CREATE TABLE IF NOT EXISTS application_alias
+(
+    path       TEXT     NOT NULL,
+    action_id  TEXT     NOT NULL DEFAULT '',
+    alias      TEXT     NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT (datetime('now', 'localtime')),
+    PRIMARY KEY (path, action_id, alias)
+);
+
+CREATE INDEX IF NOT EXISTS idx_application_alias_alias ON application_alias (alias);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_selection_event_trigger_id;
+DROP TABLE IF EXISTS selection_event;
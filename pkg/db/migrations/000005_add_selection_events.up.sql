@@ -0,0 +1,12 @@
+-- One row per command launched from the launcher, it feeds the frecency ranking
+CREATE TABLE IF NOT EXISTS selection_event
+(
+    id             INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+    trigger_id     TEXT     NOT NULL,
+    source         TEXT     NOT NULL,
+    query          TEXT     NOT NULL DEFAULT '',
+    clipboard_type TEXT     NOT NULL DEFAULT '',
+    selected_at    DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_selection_event_trigger_id ON selection_event (trigger_id);
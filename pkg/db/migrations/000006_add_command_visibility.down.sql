@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS command_exclusion;
+DROP TABLE IF EXISTS command_visibility;
@@ -0,0 +1,18 @@
+-- Visibility set by the user. Applications are keyed by path (and "#<action id>" for actions)
+-- so the state survives rescans, other commands by their trigger id.
+CREATE TABLE IF NOT EXISTS command_visibility
+(
+    source      TEXT     NOT NULL,
+    command_key TEXT     NOT NULL,
+    hidden      BOOLEAN  NOT NULL DEFAULT FALSE,
+    pin_order   INTEGER,
+    updated_at  DATETIME NOT NULL DEFAULT (datetime('now', 'localtime')),
+    PRIMARY KEY (source, command_key)
+);
+
+-- Path globs of applications and scripts that are never listed nor stored
+CREATE TABLE IF NOT EXISTS command_exclusion
+(
+    pattern    TEXT     NOT NULL PRIMARY KEY,
+    created_at DATETIME NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS indexed_file_au;
+DROP TRIGGER IF EXISTS indexed_file_ad;
+DROP TRIGGER IF EXISTS indexed_file_ai;
+DROP TABLE IF EXISTS indexed_file_fts;
+DROP INDEX IF EXISTS idx_indexed_file_root;
+DROP TABLE IF EXISTS indexed_file;
+DROP TABLE IF EXISTS file_index_ignore;
+DROP TABLE IF EXISTS file_index_root;
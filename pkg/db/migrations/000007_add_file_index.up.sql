@@ -0,0 +1,60 @@
+-- Directories indexed by the file search
+CREATE TABLE IF NOT EXISTS file_index_root
+(
+    path       TEXT     NOT NULL PRIMARY KEY,
+    created_at DATETIME NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
+
+-- Patterns in .gitignore syntax skipped below every root, besides the .gitignore files found while indexing
+CREATE TABLE IF NOT EXISTS file_index_ignore
+(
+    pattern    TEXT     NOT NULL PRIMARY KEY,
+    created_at DATETIME NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
+
+-- generation is the run that last saw the file, rows of an older generation are deleted after a rescan
+CREATE TABLE IF NOT EXISTS indexed_file
+(
+    id          INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+    path        TEXT     NOT NULL UNIQUE,
+    name        TEXT     NOT NULL,
+    root        TEXT     NOT NULL,
+    is_dir      BOOLEAN  NOT NULL DEFAULT FALSE,
+    size        INTEGER  NOT NULL DEFAULT 0,
+    modified_at DATETIME NOT NULL,
+    generation  INTEGER  NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_indexed_file_root ON indexed_file (root);
+
+-- trigram tokens match any part of a name or path with three or more characters
+CREATE VIRTUAL TABLE IF NOT EXISTS indexed_file_fts USING fts5
+(
+    name,
+    path,
+    content = 'indexed_file',
+    content_rowid = 'id',
+    tokenize = 'trigram'
+);
+
+CREATE TRIGGER IF NOT EXISTS indexed_file_ai
+    AFTER INSERT
+    ON indexed_file
+BEGIN
+    INSERT INTO indexed_file_fts (rowid, name, path) VALUES (new.id, new.name, new.path);
+END;
+
+CREATE TRIGGER IF NOT EXISTS indexed_file_ad
+    AFTER DELETE
+    ON indexed_file
+BEGIN
+    INSERT INTO indexed_file_fts (indexed_file_fts, rowid, name, path) VALUES ('delete', old.id, old.name, old.path);
+END;
+
+CREATE TRIGGER IF NOT EXISTS indexed_file_au
+    AFTER UPDATE OF name, path
+    ON indexed_file
+BEGIN
+    INSERT INTO indexed_file_fts (indexed_file_fts, rowid, name, path) VALUES ('delete', old.id, old.name, old.path);
+    INSERT INTO indexed_file_fts (rowid, name, path) VALUES (new.id, new.name, new.path);
+END;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_bookmark_source;
+DROP TABLE IF EXISTS bookmark;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS bookmark
+(
+    id          TEXT     NOT NULL PRIMARY KEY,
+    source      TEXT     NOT NULL,
+    browser     TEXT     NOT NULL,
+    profile     TEXT     NOT NULL,
+    title       TEXT     NOT NULL,
+    url         TEXT     NOT NULL,
+    folder_path TEXT     NOT NULL DEFAULT '',
+    favicon     TEXT,
+    imported_at DATETIME NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_bookmark_source ON bookmark (source);
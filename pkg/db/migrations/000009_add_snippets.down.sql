@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_snippet_keyword;
+DROP TABLE IF EXISTS snippet;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS snippet
+(
+    id         TEXT     NOT NULL PRIMARY KEY,
+    name       TEXT     NOT NULL,
+    keyword    TEXT     NOT NULL DEFAULT '',
+    body       TEXT     NOT NULL,
+    tags       TEXT     NOT NULL DEFAULT '[]',
+    created_at DATETIME NOT NULL DEFAULT (datetime('now', 'localtime')),
+    updated_at DATETIME NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_snippet_keyword ON snippet (keyword);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_clipboard_history_copied_at;
+DROP TABLE IF EXISTS clipboard_history;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS clipboard_history
+(
+    id         INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+    kind       TEXT     NOT NULL,
+    content    TEXT     NOT NULL,
+    hash       TEXT     NOT NULL UNIQUE,
+    size       INTEGER  NOT NULL,
+    source_app TEXT     NOT NULL DEFAULT '',
+    pinned     BOOLEAN  NOT NULL DEFAULT FALSE,
+    copied_at  DATETIME NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_clipboard_history_copied_at ON clipboard_history (copied_at);
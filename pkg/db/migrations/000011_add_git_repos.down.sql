@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_git_repo_root;
+DROP TABLE IF EXISTS git_repo;
+DROP TABLE IF EXISTS git_repo_ignore;
+DROP TABLE IF EXISTS git_repo_root;
@@ -0,0 +1,26 @@
+-- Directories searched for git repositories, max_depth is how many levels below the root are visited
+CREATE TABLE IF NOT EXISTS git_repo_root
+(
+    path       TEXT     NOT NULL PRIMARY KEY,
+    max_depth  INTEGER  NOT NULL DEFAULT 3,
+    created_at DATETIME NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
+
+-- Patterns in .gitignore syntax skipped below every root
+CREATE TABLE IF NOT EXISTS git_repo_ignore
+(
+    pattern    TEXT     NOT NULL PRIMARY KEY,
+    created_at DATETIME NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
+
+-- Repositories found by the last scan of their root, branch and dirty state are read from the repository on demand
+CREATE TABLE IF NOT EXISTS git_repo
+(
+    path       TEXT     NOT NULL PRIMARY KEY,
+    root       TEXT     NOT NULL,
+    name       TEXT     NOT NULL,
+    remote_url TEXT     NOT NULL DEFAULT '',
+    scanned_at DATETIME NOT NULL DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_git_repo_root ON git_repo (root);
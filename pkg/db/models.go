@@ -22,6 +22,96 @@ type Application struct {
 	DirUpdatedAt time.Time
 	LastUsedAt   models.OptionTime
 	UsedCount    int64
+	ParentID     mo.Option[string]
+	ActionID     string
+}
+
+type ApplicationAlias struct {
+	Path      string
+	ActionID  string
+	Alias     string
+	CreatedAt time.Time
+}
+
+type Bookmark struct {
+	ID         string
+	Source     string
+	Browser    string
+	Profile    string
+	Title      string
+	Url        string
+	FolderPath string
+	Favicon    mo.Option[string]
+	ImportedAt time.Time
+}
+
+type ClipboardHistory struct {
+	ID        int64
+	Kind      string
+	Content   string
+	Hash      string
+	Size      int64
+	SourceApp string
+	Pinned    bool
+	CopiedAt  time.Time
+}
+
+type CommandExclusion struct {
+	Pattern   string
+	CreatedAt time.Time
+}
+
+type CommandVisibility struct {
+	Source     string
+	CommandKey string
+	Hidden     bool
+	PinOrder   mo.Option[int64]
+	UpdatedAt  time.Time
+}
+
+type FileIndexIgnore struct {
+	Pattern   string
+	CreatedAt time.Time
+}
+
+type FileIndexRoot struct {
+	Path      string
+	CreatedAt time.Time
+}
+
+type GitRepo struct {
+	Path      string
+	Root      string
+	Name      string
+	RemoteUrl string
+	ScannedAt time.Time
+}
+
+type GitRepoIgnore struct {
+	Pattern   string
+	CreatedAt time.Time
+}
+
+type GitRepoRoot struct {
+	Path      string
+	MaxDepth  int64
+	CreatedAt time.Time
+}
+
+type IndexedFile struct {
+	ID         int64
+	Path       string
+	Name       string
+	Root       string
+	IsDir      bool
+	Size       int64
+	ModifiedAt time.Time
+	Generation int64
+}
+
+type IndexedFileFt struct {
+	Name string
+	Path string
 }
 
 type Metadata struct {
@@ -36,3 +126,33 @@ type PluginState struct {
 	LastUsedAt models.OptionTime
 	UsedCount  int64
 }
+
+type Quicklink struct {
+	ID        string
+	Name      string
+	Keyword   string
+	Url       string
+	Icon      mo.Option[string]
+	TargetApp mo.Option[string]
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type SelectionEvent struct {
+	ID            int64
+	TriggerID     string
+	Source        string
+	Query         string
+	ClipboardType string
+	SelectedAt    time.Time
+}
+
+type Snippet struct {
+	ID        string
+	Name      string
+	Keyword   string
+	Body      string
+	Tags      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
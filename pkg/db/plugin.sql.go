@@ -71,20 +71,19 @@ func (q *Queries) DeletePlugin(ctx context.Context, packageID string) error {
 	return err
 }
 
-const updatePluginUsage = `-- name: UpdatePluginUsage :exec
+const incrementPluginUsage = `-- name: IncrementPluginUsage :exec
 UPDATE plugin_state
-SET last_used_at = ?, used_count = ?
+SET last_used_at = ?, used_count = used_count + 1
 WHERE package_id = ?
 `
 
-type UpdatePluginUsageParams struct {
+type IncrementPluginUsageParams struct {
 	LastUsedAt models.OptionTime
-	UsedCount  int64
 	PackageID  string
 }
 
-func (q *Queries) UpdatePluginUsage(ctx context.Context, arg UpdatePluginUsageParams) error {
-	_, err := q.db.ExecContext(ctx, updatePluginUsage, arg.LastUsedAt, arg.UsedCount, arg.PackageID)
+func (q *Queries) IncrementPluginUsage(ctx context.Context, arg IncrementPluginUsageParams) error {
+	_, err := q.db.ExecContext(ctx, incrementPluginUsage, arg.LastUsedAt, arg.PackageID)
 	return err
 }
 
@@ -1,8 +1,13 @@
 -- name: CreateApplication :one
-INSERT INTO application (id, name, description, category, path, icon_path, dir_updated_at)
-VALUES (@id, @name, @description, @category, @path, @icon_path, @dir_updated_at)
+INSERT INTO application (id, name, description, category, path, icon_path, dir_updated_at, parent_id, action_id)
+VALUES (@id, @name, @description, @category, @path, @icon_path, @dir_updated_at, @parent_id, @action_id)
 RETURNING *;
 
+-- name: GetApplication :one
+SELECT *
+FROM application
+WHERE id = @id;
+
 -- name: GetApplications :many
 SELECT *
 FROM application;
@@ -17,6 +22,16 @@ DELETE
 FROM application
 WHERE id IN (sqlc.slice('ids'));
 
+-- name: DeleteApplicationActions :exec
+DELETE
+FROM application
+WHERE parent_id IN (sqlc.slice('parent_ids'));
+
+-- name: GetApplicationActions :many
+SELECT *
+FROM application
+WHERE parent_id = @parent_id;
+
 -- name: UpdateApplicationPartial :exec
 UPDATE application
 SET name           = COALESCE(sqlc.narg(name), name),
@@ -28,10 +43,10 @@ SET name           = COALESCE(sqlc.narg(name), name),
     updated_at     = datetime('now', 'localtime')
 WHERE id = @id;
 
--- name: UpdateApplicationUsage :exec
+-- name: IncrementApplicationUsage :exec
 UPDATE application
 SET last_used_at = @last_used_at,
-    used_count   = @used_count,
+    used_count   = used_count + 1,
     updated_at   = datetime('now', 'localtime')
 WHERE id = @id;
 
@@ -40,4 +55,5 @@ SELECT *
 FROM application
 WHERE dir_updated_at != @dir_updated_at
   AND path = @path
+  AND action_id = ''
 LIMIT 1;
\ No newline at end of file
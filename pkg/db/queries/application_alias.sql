@@ -0,0 +1,22 @@
+-- name: GetApplicationAliases :many
+SELECT *
+FROM application_alias
+ORDER BY path, action_id, created_at, alias;
+
+-- name: GetApplicationAliasesByPath :many
+SELECT *
+FROM application_alias
+WHERE path = @path
+  AND action_id = @action_id
+ORDER BY created_at, alias;
+
+-- name: CreateApplicationAlias :exec
+INSERT OR IGNORE INTO application_alias (path, action_id, alias)
+VALUES (@path, @action_id, @alias);
+
+-- name: DeleteApplicationAlias :exec
+DELETE
+FROM application_alias
+WHERE path = @path
+  AND action_id = @action_id
+  AND alias = @alias;
@@ -0,0 +1,18 @@
+-- name: GetBookmarks :many
+SELECT *
+FROM bookmark
+ORDER BY browser, profile, folder_path, title;
+
+-- name: GetBookmarkSources :many
+SELECT DISTINCT source
+FROM bookmark
+ORDER BY source;
+
+-- name: CreateBookmark :exec
+INSERT INTO bookmark (id, source, browser, profile, title, url, folder_path, favicon)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: DeleteBookmarksBySource :exec
+DELETE FROM bookmark
+WHERE source = ?;
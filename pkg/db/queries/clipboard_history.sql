@@ -0,0 +1,37 @@
+-- name: SaveClipboardHistory :one
+INSERT INTO clipboard_history (kind, content, hash, size, source_app, copied_at)
+VALUES (@kind, @content, @hash, @size, @source_app, @copied_at)
+ON CONFLICT (hash) DO UPDATE SET source_app = excluded.source_app,
+                                 copied_at  = excluded.copied_at
+RETURNING *;
+
+-- name: SearchClipboardHistory :many
+SELECT *
+FROM clipboard_history
+WHERE (@kind = '' OR kind = @kind)
+  AND (@patterns = '[]' OR (kind != 'image' AND NOT EXISTS (SELECT 1
+                                                          FROM json_each(@patterns) AS term
+                                                          WHERE content NOT LIKE term.value ESCAPE '\')))
+ORDER BY pinned DESC, copied_at DESC
+LIMIT @limit OFFSET @offset;
+
+-- name: GetClipboardHistoryItem :one
+SELECT *
+FROM clipboard_history
+WHERE id = @id;
+
+-- name: GetUnpinnedClipboardHistory :many
+SELECT *
+FROM clipboard_history
+WHERE pinned = FALSE
+ORDER BY copied_at DESC;
+
+-- name: SetClipboardHistoryPinned :exec
+UPDATE clipboard_history
+SET pinned = @pinned
+WHERE id = @id;
+
+-- name: DeleteClipboardHistoryItem :exec
+DELETE
+FROM clipboard_history
+WHERE id = @id;
@@ -0,0 +1,32 @@
+-- name: GetCommandVisibilities :many
+SELECT *
+FROM command_visibility
+ORDER BY source, command_key;
+
+-- name: UpsertCommandVisibility :exec
+INSERT INTO command_visibility (source, command_key, hidden, pin_order)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (source, command_key) DO UPDATE
+    SET hidden     = excluded.hidden,
+        pin_order  = excluded.pin_order,
+        updated_at = datetime('now', 'localtime');
+
+-- name: DeleteCommandVisibility :exec
+DELETE
+FROM command_visibility
+WHERE source = ?
+  AND command_key = ?;
+
+-- name: GetCommandExclusions :many
+SELECT *
+FROM command_exclusion
+ORDER BY pattern;
+
+-- name: CreateCommandExclusion :exec
+INSERT OR IGNORE INTO command_exclusion (pattern)
+VALUES (?);
+
+-- name: DeleteCommandExclusion :exec
+DELETE
+FROM command_exclusion
+WHERE pattern = ?;
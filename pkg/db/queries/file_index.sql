@@ -0,0 +1,79 @@
+-- name: GetFileIndexRoots :many
+SELECT *
+FROM file_index_root
+ORDER BY path;
+
+-- name: CreateFileIndexRoot :exec
+INSERT OR IGNORE INTO file_index_root (path)
+VALUES (?);
+
+-- name: DeleteFileIndexRoot :exec
+DELETE
+FROM file_index_root
+WHERE path = ?;
+
+-- name: GetFileIndexIgnores :many
+SELECT *
+FROM file_index_ignore
+ORDER BY pattern;
+
+-- name: CreateFileIndexIgnore :exec
+INSERT OR IGNORE INTO file_index_ignore (pattern)
+VALUES (?);
+
+-- name: DeleteFileIndexIgnore :exec
+DELETE
+FROM file_index_ignore
+WHERE pattern = ?;
+
+-- name: UpsertIndexedFile :exec
+INSERT INTO indexed_file (path, name, root, is_dir, size, modified_at, generation)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (path) DO UPDATE
+    SET root        = excluded.root,
+        is_dir      = excluded.is_dir,
+        size        = excluded.size,
+        modified_at = excluded.modified_at,
+        generation  = excluded.generation;
+
+-- name: GetIndexedFile :one
+SELECT *
+FROM indexed_file
+WHERE path = ?;
+
+-- name: DeleteIndexedFilesUnder :exec
+DELETE
+FROM indexed_file
+WHERE path = @path
+   OR substr(path, 1, length(@prefix)) = @prefix;
+
+-- name: DeleteStaleIndexedFiles :exec
+DELETE
+FROM indexed_file
+WHERE (path = @path OR substr(path, 1, length(@prefix)) = @prefix)
+  AND generation < @generation;
+
+-- name: DeleteIndexedFilesByRoot :exec
+DELETE
+FROM indexed_file
+WHERE root = ?;
+
+-- name: SearchIndexedFiles :many
+SELECT f.*
+FROM indexed_file_fts
+         JOIN indexed_file f ON f.id = indexed_file_fts.rowid
+WHERE indexed_file_fts MATCH @query
+ORDER BY bm25(indexed_file_fts, 10.0, 1.0)
+LIMIT @limit;
+
+-- name: SearchIndexedFilesByName :many
+SELECT *
+FROM indexed_file
+WHERE name LIKE @pattern ESCAPE '\'
+ORDER BY length(name), name
+LIMIT @limit;
+
+-- name: CountIndexedFiles :one
+SELECT COUNT(*)                                     AS total,
+       CAST(COALESCE(SUM(is_dir), 0) AS INTEGER) AS dirs
+FROM indexed_file;
@@ -0,0 +1,48 @@
+-- name: GetGitRepoRoots :many
+SELECT *
+FROM git_repo_root
+ORDER BY path;
+
+-- name: CreateGitRepoRoot :exec
+INSERT INTO git_repo_root (path, max_depth)
+VALUES (?, ?)
+ON CONFLICT (path) DO UPDATE
+    SET max_depth = excluded.max_depth;
+
+-- name: DeleteGitRepoRoot :exec
+DELETE
+FROM git_repo_root
+WHERE path = ?;
+
+-- name: GetGitRepoIgnores :many
+SELECT *
+FROM git_repo_ignore
+ORDER BY pattern;
+
+-- name: CreateGitRepoIgnore :exec
+INSERT OR IGNORE INTO git_repo_ignore (pattern)
+VALUES (?);
+
+-- name: DeleteGitRepoIgnore :exec
+DELETE
+FROM git_repo_ignore
+WHERE pattern = ?;
+
+-- name: GetGitRepos :many
+SELECT *
+FROM git_repo
+ORDER BY name, path;
+
+-- name: CreateGitRepo :exec
+INSERT INTO git_repo (path, root, name, remote_url)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (path) DO UPDATE
+    SET root       = excluded.root,
+        name       = excluded.name,
+        remote_url = excluded.remote_url,
+        scanned_at = datetime('now', 'localtime');
+
+-- name: DeleteGitReposByRoot :exec
+DELETE
+FROM git_repo
+WHERE root = ?;
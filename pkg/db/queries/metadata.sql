@@ -0,0 +1,10 @@
+-- name: GetMetadata :one
+SELECT value
+FROM metadata
+WHERE key = ?;
+
+-- name: SetMetadata :exec
+INSERT INTO metadata (key, value)
+VALUES (?, ?)
+ON CONFLICT (key) DO UPDATE
+    SET value = excluded.value;
@@ -10,9 +10,9 @@ VALUES (?, ?, ?);
 DELETE FROM plugin_state
 WHERE package_id = ?;
 
--- name: UpdatePluginUsage :exec
+-- name: IncrementPluginUsage :exec
 UPDATE plugin_state
-SET last_used_at = ?, used_count = ?
+SET last_used_at = ?, used_count = used_count + 1
 WHERE package_id = ?;
 
 -- name: UpdatePluginEnabled :exec
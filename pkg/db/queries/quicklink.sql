@@ -0,0 +1,27 @@
+-- name: GetQuicklinks :many
+SELECT *
+FROM quicklink
+ORDER BY name;
+
+-- name: GetQuicklink :one
+SELECT *
+FROM quicklink
+WHERE id = ?;
+
+-- name: CreateQuicklink :exec
+INSERT INTO quicklink (id, name, keyword, url, icon, target_app)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: UpdateQuicklink :exec
+UPDATE quicklink
+SET name       = ?,
+    keyword    = ?,
+    url        = ?,
+    icon       = ?,
+    target_app = ?,
+    updated_at = datetime('now', 'localtime')
+WHERE id = ?;
+
+-- name: DeleteQuicklink :exec
+DELETE FROM quicklink
+WHERE id = ?;
@@ -0,0 +1,14 @@
+-- name: CreateSelectionEvent :exec
+INSERT INTO selection_event (trigger_id, source, query, clipboard_type, selected_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: GetRecentSelectionEvents :many
+SELECT *
+FROM selection_event
+ORDER BY id DESC
+LIMIT @limit;
+
+-- name: PruneSelectionEvents :exec
+DELETE
+FROM selection_event
+WHERE id <= (SELECT id FROM selection_event ORDER BY id DESC LIMIT 1 OFFSET @keep);
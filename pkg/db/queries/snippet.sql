@@ -0,0 +1,26 @@
+-- name: GetSnippets :many
+SELECT *
+FROM snippet
+ORDER BY name;
+
+-- name: GetSnippet :one
+SELECT *
+FROM snippet
+WHERE id = ?;
+
+-- name: CreateSnippet :exec
+INSERT INTO snippet (id, name, keyword, body, tags)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: UpdateSnippet :exec
+UPDATE snippet
+SET name       = ?,
+    keyword    = ?,
+    body       = ?,
+    tags       = ?,
+    updated_at = datetime('now', 'localtime')
+WHERE id = ?;
+
+-- name: DeleteSnippet :exec
+DELETE FROM snippet
+WHERE id = ?;
@@ -4,15 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"embed"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 	"watools/config"
 	"watools/pkg/logger"
 	"watools/pkg/models"
+	"watools/pkg/utils"
 
 	"github.com/samber/lo"
 	"github.com/samber/mo"
@@ -150,6 +153,8 @@ func (d *WaDB) BatchInsertCommands(ctx context.Context, commands []*models.Appli
 				Path:         command.Path,
 				IconPath:     command.IconPath,
 				DirUpdatedAt: command.DirUpdatedAt,
+				ParentID:     mo.TupleToOption(command.ParentID, command.ParentID != ""),
+				ActionID:     command.ActionID,
 			}); err != nil {
 				return err
 			}
@@ -199,6 +204,67 @@ func (d *WaDB) DeleteCommands(ctx context.Context, ids []string) error {
 		if err := txQuery.DeleteApplication(ctx, ids); err != nil {
 			return fmt.Errorf("failed to delete command: %w", err)
 		}
+		if err := txQuery.DeleteApplicationActions(ctx, ids); err != nil {
+			return fmt.Errorf("failed to delete command actions: %w", err)
+		}
+		return tx.Commit()
+	})
+}
+
+// ReplaceCommandActions stores actions as the children of parentID.
+// Existing children keep their id and usage when their action id is still present, the others are deleted.
+func (d *WaDB) ReplaceCommandActions(ctx context.Context, parentID string, actions []*models.ApplicationCommand) error {
+	return d.withTx(ctx, func(tx *sql.Tx) error {
+		txQuery := d.query.WithTx(tx)
+		existingActions, err := txQuery.GetApplicationActions(ctx, mo.Some(parentID))
+		if err != nil {
+			return fmt.Errorf("failed to get command actions: %w", err)
+		}
+		existingByActionID := lo.KeyBy(existingActions, func(item Application) string {
+			return item.ActionID
+		})
+
+		for _, action := range actions {
+			existing, exists := existingByActionID[action.ActionID]
+			if !exists {
+				if _, err := txQuery.CreateApplication(ctx, CreateApplicationParams{
+					ID:           action.ID,
+					Name:         action.Name,
+					Description:  action.Description,
+					Category:     string(action.Category),
+					Path:         action.Path,
+					IconPath:     action.IconPath,
+					DirUpdatedAt: action.DirUpdatedAt,
+					ParentID:     mo.Some(parentID),
+					ActionID:     action.ActionID,
+				}); err != nil {
+					return fmt.Errorf("failed to insert command action: %w", err)
+				}
+				continue
+			}
+			delete(existingByActionID, action.ActionID)
+			action.ID = existing.ID
+			if err := txQuery.UpdateApplicationPartial(ctx, UpdateApplicationPartialParams{
+				ID:           existing.ID,
+				DirUpdatedAt: action.DirUpdatedAt,
+				Name:         mo.Some(action.Name),
+				Description:  action.Description,
+				Category:     mo.Some(string(action.Category)),
+				Path:         mo.Some(action.Path),
+				IconPath:     action.IconPath,
+			}); err != nil {
+				return fmt.Errorf("failed to update command action: %w", err)
+			}
+		}
+
+		if len(existingByActionID) > 0 {
+			staleIDs := lo.MapToSlice(existingByActionID, func(_ string, item Application) string {
+				return item.ID
+			})
+			if err := txQuery.DeleteApplication(ctx, staleIDs); err != nil {
+				return fmt.Errorf("failed to delete command actions: %w", err)
+			}
+		}
 		return tx.Commit()
 	})
 }
@@ -250,34 +316,650 @@ func (d *WaDB) UpdatePluginStorage(ctx context.Context, packageID string, storag
 	})
 }
 
-func (d *WaDB) BatchUpdateApplicationUsage(ctx context.Context, usageUpdates []models.ApplicationUsageUpdate) error {
-	logger.Info(fmt.Sprintf("Updating usage for %d applications", len(usageUpdates)))
+func (d *WaDB) GetQuicklinks(ctx context.Context) ([]*models.QuicklinkCommand, error) {
+	dbQuicklinks, err := d.query.GetQuicklinks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quicklinks: %w", err)
+	}
+	return lo.Map(dbQuicklinks, func(item Quicklink, _ int) *models.QuicklinkCommand {
+		return ConvertQuicklink(item)
+	}), nil
+}
+
+func (d *WaDB) GetQuicklink(ctx context.Context, id string) (*models.QuicklinkCommand, error) {
+	quicklink, err := d.query.GetQuicklink(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quicklink %s: %w", id, err)
+	}
+	return ConvertQuicklink(quicklink), nil
+}
+
+func (d *WaDB) CreateQuicklink(ctx context.Context, quicklink *models.QuicklinkCommand) error {
+	return d.query.CreateQuicklink(ctx, CreateQuicklinkParams{
+		ID:        quicklink.ID,
+		Name:      quicklink.Name,
+		Keyword:   quicklink.Keyword,
+		Url:       quicklink.URL,
+		Icon:      mo.TupleToOption(quicklink.Icon, quicklink.Icon != ""),
+		TargetApp: mo.TupleToOption(quicklink.TargetApp, quicklink.TargetApp != ""),
+	})
+}
+
+func (d *WaDB) UpdateQuicklink(ctx context.Context, quicklink *models.QuicklinkCommand) error {
+	return d.query.UpdateQuicklink(ctx, UpdateQuicklinkParams{
+		ID:        quicklink.ID,
+		Name:      quicklink.Name,
+		Keyword:   quicklink.Keyword,
+		Url:       quicklink.URL,
+		Icon:      mo.TupleToOption(quicklink.Icon, quicklink.Icon != ""),
+		TargetApp: mo.TupleToOption(quicklink.TargetApp, quicklink.TargetApp != ""),
+	})
+}
+
+func (d *WaDB) DeleteQuicklink(ctx context.Context, id string) error {
+	return d.query.DeleteQuicklink(ctx, id)
+}
+
+func (d *WaDB) GetSnippets(ctx context.Context) ([]*models.SnippetCommand, error) {
+	dbSnippets, err := d.query.GetSnippets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snippets: %w", err)
+	}
+	return lo.Map(dbSnippets, func(item Snippet, _ int) *models.SnippetCommand {
+		return ConvertSnippet(item)
+	}), nil
+}
+
+func (d *WaDB) GetSnippet(ctx context.Context, id string) (*models.SnippetCommand, error) {
+	snippet, err := d.query.GetSnippet(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snippet %s: %w", id, err)
+	}
+	return ConvertSnippet(snippet), nil
+}
+
+func (d *WaDB) CreateSnippet(ctx context.Context, snippet *models.SnippetCommand) error {
+	tags, err := encodeSnippetTags(snippet.Tags)
+	if err != nil {
+		return err
+	}
+	return d.query.CreateSnippet(ctx, CreateSnippetParams{
+		ID:      snippet.ID,
+		Name:    snippet.Name,
+		Keyword: snippet.Keyword,
+		Body:    snippet.Body,
+		Tags:    tags,
+	})
+}
+
+func (d *WaDB) UpdateSnippet(ctx context.Context, snippet *models.SnippetCommand) error {
+	tags, err := encodeSnippetTags(snippet.Tags)
+	if err != nil {
+		return err
+	}
+	return d.query.UpdateSnippet(ctx, UpdateSnippetParams{
+		ID:      snippet.ID,
+		Name:    snippet.Name,
+		Keyword: snippet.Keyword,
+		Body:    snippet.Body,
+		Tags:    tags,
+	})
+}
+
+// SaveSnippets creates or replaces snippets by id in one transaction, it is used by imports
+func (d *WaDB) SaveSnippets(ctx context.Context, snippets []*models.SnippetCommand) error {
 	return d.withTx(ctx, func(tx *sql.Tx) error {
 		txQuery := d.query.WithTx(tx)
-		for _, update := range usageUpdates {
-			if err := txQuery.UpdateApplicationUsage(ctx, UpdateApplicationUsageParams{
-				ID:         update.ID,
-				LastUsedAt: models.ToOptionTime(update.LastUsedAt),
-				UsedCount:  int64(update.UsedCount),
-			}); err != nil {
-				return fmt.Errorf("failed to update application usage: %w", err)
+		for _, snippet := range snippets {
+			tags, err := encodeSnippetTags(snippet.Tags)
+			if err != nil {
+				return err
+			}
+			if err := txQuery.DeleteSnippet(ctx, snippet.ID); err != nil {
+				return fmt.Errorf("failed to replace snippet %s: %w", snippet.Name, err)
+			}
+			err = txQuery.CreateSnippet(ctx, CreateSnippetParams{
+				ID:      snippet.ID,
+				Name:    snippet.Name,
+				Keyword: snippet.Keyword,
+				Body:    snippet.Body,
+				Tags:    tags,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to save snippet %s: %w", snippet.Name, err)
 			}
 		}
 		return tx.Commit()
 	})
 }
 
-func (d *WaDB) BatchUpdatePluginUsage(ctx context.Context, usageUpdates []models.PluginUsageUpdate) error {
-	logger.Info(fmt.Sprintf("Updating usage for %d plugins", len(usageUpdates)))
+func (d *WaDB) DeleteSnippet(ctx context.Context, id string) error {
+	return d.query.DeleteSnippet(ctx, id)
+}
+
+func applicationAliasKey(path string, actionID string) string {
+	return path + "\x00" + actionID
+}
+
+// FillCommandAliases sets the aliases of commands, aliases are matched by path and action id
+func (d *WaDB) FillCommandAliases(ctx context.Context, commands []*models.ApplicationCommand) error {
+	aliases, err := d.query.GetApplicationAliases(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get application aliases: %w", err)
+	}
+	aliasesByKey := make(map[string][]string)
+	for _, alias := range aliases {
+		key := applicationAliasKey(alias.Path, alias.ActionID)
+		aliasesByKey[key] = append(aliasesByKey[key], alias.Alias)
+	}
+	for _, command := range commands {
+		command.Aliases = aliasesByKey[applicationAliasKey(command.Path, command.ActionID)]
+		if command.Aliases == nil {
+			command.Aliases = []string{}
+		}
+	}
+	return nil
+}
+
+// GetCommandAliases returns the aliases of the application with the given id
+func (d *WaDB) GetCommandAliases(ctx context.Context, id string) ([]string, error) {
+	application, err := d.query.GetApplication(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application %s: %w", id, err)
+	}
+	aliases, err := d.query.GetApplicationAliasesByPath(ctx, GetApplicationAliasesByPathParams{
+		Path:     application.Path,
+		ActionID: application.ActionID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application aliases: %w", err)
+	}
+	return lo.Map(aliases, func(item ApplicationAlias, _ int) string {
+		return item.Alias
+	}), nil
+}
+
+// AddCommandAlias stores an alias for the application with the given id, adding an existing alias does nothing
+func (d *WaDB) AddCommandAlias(ctx context.Context, id string, alias string) error {
+	application, err := d.query.GetApplication(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get application %s: %w", id, err)
+	}
+	return d.query.CreateApplicationAlias(ctx, CreateApplicationAliasParams{
+		Path:     application.Path,
+		ActionID: application.ActionID,
+		Alias:    alias,
+	})
+}
+
+// RemoveCommandAlias deletes an alias of the application with the given id
+func (d *WaDB) RemoveCommandAlias(ctx context.Context, id string, alias string) error {
+	application, err := d.query.GetApplication(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get application %s: %w", id, err)
+	}
+	return d.query.DeleteApplicationAlias(ctx, DeleteApplicationAliasParams{
+		Path:     application.Path,
+		ActionID: application.ActionID,
+		Alias:    alias,
+	})
+}
+
+// maxSelectionEvents is how many selection events are kept, older ones are pruned when a new one is recorded
+const maxSelectionEvents = 5000
+
+// RecordSelection stores a selection event and increments the usage of the application or plugin that was selected.
+// applicationID and pluginID may be empty when the selection is not an application or a plugin.
+func (d *WaDB) RecordSelection(ctx context.Context, event models.SelectionEvent, applicationID string, pluginID string) error {
 	return d.withTx(ctx, func(tx *sql.Tx) error {
 		txQuery := d.query.WithTx(tx)
-		for _, update := range usageUpdates {
-			if err := txQuery.UpdatePluginUsage(ctx, UpdatePluginUsageParams{
-				PackageID:  update.PackageID,
-				LastUsedAt: models.ToOptionTime(update.LastUsedAt),
-				UsedCount:  int64(update.UsedCount),
+		if err := txQuery.CreateSelectionEvent(ctx, CreateSelectionEventParams{
+			TriggerID:     event.TriggerID,
+			Source:        event.Source,
+			Query:         event.Query,
+			ClipboardType: event.ClipboardType,
+			SelectedAt:    event.SelectedAt,
+		}); err != nil {
+			return fmt.Errorf("failed to create selection event: %w", err)
+		}
+		if applicationID != "" {
+			if err := txQuery.IncrementApplicationUsage(ctx, IncrementApplicationUsageParams{
+				LastUsedAt: models.ToOptionTime(event.SelectedAt),
+				ID:         applicationID,
+			}); err != nil {
+				return fmt.Errorf("failed to increment application usage: %w", err)
+			}
+		}
+		if pluginID != "" {
+			if err := txQuery.IncrementPluginUsage(ctx, IncrementPluginUsageParams{
+				LastUsedAt: models.ToOptionTime(event.SelectedAt),
+				PackageID:  pluginID,
 			}); err != nil {
-				return fmt.Errorf("failed to update plugin usage: %w", err)
+				return fmt.Errorf("failed to increment plugin usage: %w", err)
+			}
+		}
+		if err := txQuery.PruneSelectionEvents(ctx, maxSelectionEvents); err != nil {
+			return fmt.Errorf("failed to prune selection events: %w", err)
+		}
+		return tx.Commit()
+	})
+}
+
+// GetRecentSelectionEvents returns the last limit selection events, newest first
+func (d *WaDB) GetRecentSelectionEvents(ctx context.Context, limit int) ([]models.SelectionEvent, error) {
+	events, err := d.query.GetRecentSelectionEvents(ctx, int64(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get selection events: %w", err)
+	}
+	return lo.Map(events, func(event SelectionEvent, _ int) models.SelectionEvent {
+		return ConvertSelectionEvent(event)
+	}), nil
+}
+
+func (d *WaDB) GetCommandVisibilities(ctx context.Context) ([]models.CommandVisibility, error) {
+	visibilities, err := d.query.GetCommandVisibilities(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get command visibilities: %w", err)
+	}
+	return lo.Map(visibilities, func(visibility CommandVisibility, _ int) models.CommandVisibility {
+		return ConvertCommandVisibility(visibility)
+	}), nil
+}
+
+// SetCommandVisibility stores the visibility of a command, a default visibility removes the stored one
+func (d *WaDB) SetCommandVisibility(ctx context.Context, visibility models.CommandVisibility) error {
+	if visibility.IsDefault() {
+		return d.query.DeleteCommandVisibility(ctx, DeleteCommandVisibilityParams{
+			Source:     string(visibility.Source),
+			CommandKey: visibility.Key,
+		})
+	}
+	pinOrder := mo.None[int64]()
+	if order, ok := visibility.PinOrder.Get(); ok {
+		pinOrder = mo.Some(int64(order))
+	}
+	return d.query.UpsertCommandVisibility(ctx, UpsertCommandVisibilityParams{
+		Source:     string(visibility.Source),
+		CommandKey: visibility.Key,
+		Hidden:     visibility.Hidden,
+		PinOrder:   pinOrder,
+	})
+}
+
+// GetCommandExclusions returns the path globs of excluded commands
+func (d *WaDB) GetCommandExclusions(ctx context.Context) ([]string, error) {
+	exclusions, err := d.query.GetCommandExclusions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get command exclusions: %w", err)
+	}
+	return lo.Map(exclusions, func(exclusion CommandExclusion, _ int) string {
+		return exclusion.Pattern
+	}), nil
+}
+
+func (d *WaDB) AddCommandExclusion(ctx context.Context, pattern string) error {
+	return d.query.CreateCommandExclusion(ctx, pattern)
+}
+
+func (d *WaDB) RemoveCommandExclusion(ctx context.Context, pattern string) error {
+	return d.query.DeleteCommandExclusion(ctx, pattern)
+}
+
+// IsPathExcluded reports whether path matches one of the command exclusions
+func (d *WaDB) IsPathExcluded(ctx context.Context, path string) bool {
+	patterns, err := d.GetCommandExclusions(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to check command exclusions")
+		return false
+	}
+	return utils.MatchAnyPathGlob(patterns, path)
+}
+
+func (d *WaDB) GetMetadata(ctx context.Context, key string) (mo.Option[string], error) {
+	value, err := d.query.GetMetadata(ctx, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return mo.None[string](), nil
+	}
+	if err != nil {
+		return mo.None[string](), fmt.Errorf("failed to get metadata %s: %w", key, err)
+	}
+	return value, nil
+}
+
+func (d *WaDB) SetMetadata(ctx context.Context, key string, value string) error {
+	return d.query.SetMetadata(ctx, SetMetadataParams{Key: key, Value: mo.Some(value)})
+}
+
+func (d *WaDB) GetFileIndexRoots(ctx context.Context) ([]string, error) {
+	roots, err := d.query.GetFileIndexRoots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file index roots: %w", err)
+	}
+	return lo.Map(roots, func(root FileIndexRoot, _ int) string {
+		return root.Path
+	}), nil
+}
+
+func (d *WaDB) AddFileIndexRoot(ctx context.Context, path string) error {
+	return d.query.CreateFileIndexRoot(ctx, path)
+}
+
+// RemoveFileIndexRoot stops indexing a root and deletes the files indexed below it
+func (d *WaDB) RemoveFileIndexRoot(ctx context.Context, path string) error {
+	return d.withTx(ctx, func(tx *sql.Tx) error {
+		txQuery := d.query.WithTx(tx)
+		if err := txQuery.DeleteFileIndexRoot(ctx, path); err != nil {
+			return fmt.Errorf("failed to delete file index root: %w", err)
+		}
+		if err := txQuery.DeleteIndexedFilesByRoot(ctx, path); err != nil {
+			return fmt.Errorf("failed to delete indexed files: %w", err)
+		}
+		return tx.Commit()
+	})
+}
+
+func (d *WaDB) GetFileIndexIgnores(ctx context.Context) ([]string, error) {
+	ignores, err := d.query.GetFileIndexIgnores(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file index ignores: %w", err)
+	}
+	return lo.Map(ignores, func(ignore FileIndexIgnore, _ int) string {
+		return ignore.Pattern
+	}), nil
+}
+
+func (d *WaDB) AddFileIndexIgnore(ctx context.Context, pattern string) error {
+	return d.query.CreateFileIndexIgnore(ctx, pattern)
+}
+
+func (d *WaDB) RemoveFileIndexIgnore(ctx context.Context, pattern string) error {
+	return d.query.DeleteFileIndexIgnore(ctx, pattern)
+}
+
+// SaveIndexedFiles inserts or updates files and marks them as seen by generation
+func (d *WaDB) SaveIndexedFiles(ctx context.Context, files []models.IndexedFile, generation int64) error {
+	if len(files) == 0 {
+		return nil
+	}
+	return d.withTx(ctx, func(tx *sql.Tx) error {
+		txQuery := d.query.WithTx(tx)
+		for _, file := range files {
+			err := txQuery.UpsertIndexedFile(ctx, UpsertIndexedFileParams{
+				Path:       file.Path,
+				Name:       file.Name,
+				Root:       file.Root,
+				IsDir:      file.IsDir,
+				Size:       file.Size,
+				ModifiedAt: file.ModifiedAt,
+				Generation: generation,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to save indexed file %s: %w", file.Path, err)
+			}
+		}
+		return tx.Commit()
+	})
+}
+
+// GetIndexedFile returns the indexed file at path, none when the path is not in the index
+func (d *WaDB) GetIndexedFile(ctx context.Context, path string) (mo.Option[models.IndexedFile], error) {
+	file, err := d.query.GetIndexedFile(ctx, path)
+	if errors.Is(err, sql.ErrNoRows) {
+		return mo.None[models.IndexedFile](), nil
+	}
+	if err != nil {
+		return mo.None[models.IndexedFile](), fmt.Errorf("failed to get indexed file %s: %w", path, err)
+	}
+	return mo.Some(ConvertIndexedFile(file)), nil
+}
+
+// descendantPrefix is the prefix shared by every path below dir
+func descendantPrefix(dir string) string {
+	if strings.HasSuffix(dir, string(filepath.Separator)) {
+		return dir
+	}
+	return dir + string(filepath.Separator)
+}
+
+// DeleteIndexedFilesUnder deletes path and everything indexed below it
+func (d *WaDB) DeleteIndexedFilesUnder(ctx context.Context, path string) error {
+	return d.query.DeleteIndexedFilesUnder(ctx, DeleteIndexedFilesUnderParams{
+		Path:   path,
+		Prefix: descendantPrefix(path),
+	})
+}
+
+// DeleteStaleIndexedFiles deletes path and the files below it that were not seen by generation
+func (d *WaDB) DeleteStaleIndexedFiles(ctx context.Context, path string, generation int64) error {
+	return d.query.DeleteStaleIndexedFiles(ctx, DeleteStaleIndexedFilesParams{
+		Path:       path,
+		Prefix:     descendantPrefix(path),
+		Generation: generation,
+	})
+}
+
+// SearchIndexedFiles returns files whose name or path matches an FTS5 query, best matches first
+func (d *WaDB) SearchIndexedFiles(ctx context.Context, query string, limit int) ([]models.IndexedFile, error) {
+	files, err := d.query.SearchIndexedFiles(ctx, SearchIndexedFilesParams{Query: query, Limit: int64(limit)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search indexed files: %w", err)
+	}
+	return lo.Map(files, func(file IndexedFile, _ int) models.IndexedFile {
+		return ConvertIndexedFile(file)
+	}), nil
+}
+
+// SearchIndexedFilesByName returns files whose name matches a LIKE pattern escaped with "\", shortest names first
+func (d *WaDB) SearchIndexedFilesByName(ctx context.Context, pattern string, limit int) ([]models.IndexedFile, error) {
+	files, err := d.query.SearchIndexedFilesByName(ctx, SearchIndexedFilesByNameParams{Pattern: pattern, Limit: int64(limit)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search indexed files: %w", err)
+	}
+	return lo.Map(files, func(file IndexedFile, _ int) models.IndexedFile {
+		return ConvertIndexedFile(file)
+	}), nil
+}
+
+// CountIndexedFiles returns the number of indexed entries and how many of them are directories
+func (d *WaDB) CountIndexedFiles(ctx context.Context) (total int64, dirs int64, err error) {
+	row, err := d.query.CountIndexedFiles(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count indexed files: %w", err)
+	}
+	return row.Total, row.Dirs, nil
+}
+
+func (d *WaDB) GetGitRepoRoots(ctx context.Context) ([]models.GitRepoRoot, error) {
+	roots, err := d.query.GetGitRepoRoots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git repo roots: %w", err)
+	}
+	return lo.Map(roots, func(root GitRepoRoot, _ int) models.GitRepoRoot {
+		return models.GitRepoRoot{Path: root.Path, MaxDepth: int(root.MaxDepth)}
+	}), nil
+}
+
+// AddGitRepoRoot adds a root, the depth of a root already added is updated
+func (d *WaDB) AddGitRepoRoot(ctx context.Context, path string, maxDepth int) error {
+	return d.query.CreateGitRepoRoot(ctx, CreateGitRepoRootParams{Path: path, MaxDepth: int64(maxDepth)})
+}
+
+// RemoveGitRepoRoot stops searching a root and deletes the repositories found below it
+func (d *WaDB) RemoveGitRepoRoot(ctx context.Context, path string) error {
+	return d.withTx(ctx, func(tx *sql.Tx) error {
+		txQuery := d.query.WithTx(tx)
+		if err := txQuery.DeleteGitRepoRoot(ctx, path); err != nil {
+			return fmt.Errorf("failed to delete git repo root: %w", err)
+		}
+		if err := txQuery.DeleteGitReposByRoot(ctx, path); err != nil {
+			return fmt.Errorf("failed to delete git repos: %w", err)
+		}
+		return tx.Commit()
+	})
+}
+
+func (d *WaDB) GetGitRepoIgnores(ctx context.Context) ([]string, error) {
+	ignores, err := d.query.GetGitRepoIgnores(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git repo ignores: %w", err)
+	}
+	return lo.Map(ignores, func(ignore GitRepoIgnore, _ int) string {
+		return ignore.Pattern
+	}), nil
+}
+
+func (d *WaDB) AddGitRepoIgnore(ctx context.Context, pattern string) error {
+	return d.query.CreateGitRepoIgnore(ctx, pattern)
+}
+
+func (d *WaDB) RemoveGitRepoIgnore(ctx context.Context, pattern string) error {
+	return d.query.DeleteGitRepoIgnore(ctx, pattern)
+}
+
+func (d *WaDB) GetGitRepos(ctx context.Context) ([]models.GitRepo, error) {
+	repos, err := d.query.GetGitRepos(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git repos: %w", err)
+	}
+	return lo.Map(repos, func(repo GitRepo, _ int) models.GitRepo {
+		return ConvertGitRepo(repo)
+	}), nil
+}
+
+// ReplaceGitRepos replaces the repositories found below root with the result of a new scan
+func (d *WaDB) ReplaceGitRepos(ctx context.Context, root string, repos []models.GitRepo) error {
+	return d.withTx(ctx, func(tx *sql.Tx) error {
+		txQuery := d.query.WithTx(tx)
+		if err := txQuery.DeleteGitReposByRoot(ctx, root); err != nil {
+			return fmt.Errorf("failed to delete git repos of %s: %w", root, err)
+		}
+		for _, repo := range repos {
+			err := txQuery.CreateGitRepo(ctx, CreateGitRepoParams{
+				Path:      repo.Path,
+				Root:      root,
+				Name:      repo.Name,
+				RemoteUrl: repo.RemoteURL,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to save git repo %s: %w", repo.Path, err)
+			}
+		}
+		return tx.Commit()
+	})
+}
+
+func (d *WaDB) GetBookmarks(ctx context.Context) ([]*models.BookmarkCommand, error) {
+	bookmarks, err := d.query.GetBookmarks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookmarks: %w", err)
+	}
+	return lo.Map(bookmarks, func(item Bookmark, _ int) *models.BookmarkCommand {
+		return ConvertBookmark(item)
+	}), nil
+}
+
+// GetBookmarkSources returns the browser files bookmarks were imported from
+func (d *WaDB) GetBookmarkSources(ctx context.Context) ([]string, error) {
+	return d.query.GetBookmarkSources(ctx)
+}
+
+// ReplaceBookmarks replaces the bookmarks imported from source, duplicates in the same folder are stored once
+func (d *WaDB) ReplaceBookmarks(ctx context.Context, source string, bookmarks []*models.BookmarkCommand) error {
+	return d.withTx(ctx, func(tx *sql.Tx) error {
+		txQuery := d.query.WithTx(tx)
+		if err := txQuery.DeleteBookmarksBySource(ctx, source); err != nil {
+			return fmt.Errorf("failed to delete bookmarks of %s: %w", source, err)
+		}
+		for _, bookmark := range bookmarks {
+			err := txQuery.CreateBookmark(ctx, CreateBookmarkParams{
+				ID:         bookmark.ID,
+				Source:     source,
+				Browser:    bookmark.Browser,
+				Profile:    bookmark.Profile,
+				Title:      bookmark.Name,
+				Url:        bookmark.URL,
+				FolderPath: bookmark.FolderPath,
+				Favicon:    bookmark.Favicon,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to save bookmark %s: %w", bookmark.URL, err)
+			}
+		}
+		return tx.Commit()
+	})
+}
+
+func (d *WaDB) DeleteBookmarksBySource(ctx context.Context, source string) error {
+	return d.query.DeleteBookmarksBySource(ctx, source)
+}
+
+// SaveClipboardHistory records an entry, content already in the history is moved to the top and keeps its pin
+func (d *WaDB) SaveClipboardHistory(ctx context.Context, item *models.ClipboardHistoryItem, hash string) (*models.ClipboardHistoryItem, error) {
+	saved, err := d.query.SaveClipboardHistory(ctx, SaveClipboardHistoryParams{
+		Kind:      string(item.Kind),
+		Content:   clipboardHistoryContent(item),
+		Hash:      hash,
+		Size:      item.Size,
+		SourceApp: item.SourceApp,
+		CopiedAt:  item.CopiedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save clipboard history: %w", err)
+	}
+	return ConvertClipboardHistory(saved), nil
+}
+
+// SearchClipboardHistory returns pinned entries first, then the most recently copied.
+// patterns are LIKE patterns escaped with "\", the text or file paths of an entry must match each of them in any order.
+// Every entry matches no patterns, kind is empty for every kind.
+func (d *WaDB) SearchClipboardHistory(ctx context.Context, kind string, patterns []string, limit int, offset int) ([]*models.ClipboardHistoryItem, error) {
+	encoded, err := json.Marshal(append([]string{}, patterns...))
+	if err != nil {
+		return nil, err
+	}
+	items, err := d.query.SearchClipboardHistory(ctx, SearchClipboardHistoryParams{
+		Kind:     kind,
+		Patterns: string(encoded),
+		Limit:    int64(limit),
+		Offset:   int64(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search clipboard history: %w", err)
+	}
+	return lo.Map(items, func(item ClipboardHistory, _ int) *models.ClipboardHistoryItem {
+		return ConvertClipboardHistory(item)
+	}), nil
+}
+
+func (d *WaDB) GetClipboardHistoryItem(ctx context.Context, id int64) (*models.ClipboardHistoryItem, error) {
+	item, err := d.query.GetClipboardHistoryItem(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clipboard history item %d: %w", id, err)
+	}
+	return ConvertClipboardHistory(item), nil
+}
+
+// GetUnpinnedClipboardHistory returns the entries retention limits apply to, most recently copied first
+func (d *WaDB) GetUnpinnedClipboardHistory(ctx context.Context) ([]*models.ClipboardHistoryItem, error) {
+	items, err := d.query.GetUnpinnedClipboardHistory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clipboard history: %w", err)
+	}
+	return lo.Map(items, func(item ClipboardHistory, _ int) *models.ClipboardHistoryItem {
+		return ConvertClipboardHistory(item)
+	}), nil
+}
+
+func (d *WaDB) SetClipboardHistoryPinned(ctx context.Context, id int64, pinned bool) error {
+	return d.query.SetClipboardHistoryPinned(ctx, SetClipboardHistoryPinnedParams{Pinned: pinned, ID: id})
+}
+
+func (d *WaDB) DeleteClipboardHistoryItems(ctx context.Context, ids []int64) error {
+	return d.withTx(ctx, func(tx *sql.Tx) error {
+		txQuery := d.query.WithTx(tx)
+		for _, id := range ids {
+			if err := txQuery.DeleteClipboardHistoryItem(ctx, id); err != nil {
+				return fmt.Errorf("failed to delete clipboard history item %d: %w", id, err)
 			}
 		}
 		return tx.Commit()
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+	"watools/pkg/models"
+
+	"github.com/samber/mo"
+)
+
+func newTestWaDB(t *testing.T) *WaDB {
+	t.Helper()
+
+	conn, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "watools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if err := runMigrations(conn); err != nil {
+		t.Fatal(err)
+	}
+	return &WaDB{db: conn, query: New(conn)}
+}
+
+func TestCommandAliasesSurviveRescan(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	waDB := newTestWaDB(t)
+
+	scan := func() (*models.ApplicationCommand, *models.ApplicationCommand) {
+		application := models.NewApplicationCommand("Firefox", mo.None[string](), "/usr/share/applications/firefox.desktop", mo.None[string](), mo.None[string](), time.Now())
+		action := models.NewApplicationActionCommand(application, "new-private-window", "New Private Window", mo.None[string](), mo.None[string]())
+		if err := waDB.BatchInsertCommands(ctx, []*models.ApplicationCommand{application}); err != nil {
+			t.Fatal(err)
+		}
+		if err := waDB.ReplaceCommandActions(ctx, application.ID, []*models.ApplicationCommand{action}); err != nil {
+			t.Fatal(err)
+		}
+		return application, action
+	}
+
+	application, action := scan()
+	if err := waDB.AddCommandAlias(ctx, application.ID, "ff"); err != nil {
+		t.Fatal(err)
+	}
+	if err := waDB.AddCommandAlias(ctx, action.ID, "private"); err != nil {
+		t.Fatal(err)
+	}
+	if err := waDB.DeleteCommands(ctx, []string{application.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	application, action = scan()
+	for _, c := range []struct {
+		command *models.ApplicationCommand
+		alias   string
+	}{{application, "ff"}, {action, "private"}} {
+		aliases, err := waDB.GetCommandAliases(ctx, c.command.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(aliases, []string{c.alias}) {
+			t.Fatalf("expected aliases of %s to be [%s], got %v", c.command.Name, c.alias, aliases)
+		}
+	}
+
+	commands := waDB.GetCommands(ctx)
+	if len(commands) != 2 {
+		t.Fatalf("expected the application and its action, got %d commands", len(commands))
+	}
+	if err := waDB.FillCommandAliases(ctx, commands); err != nil {
+		t.Fatal(err)
+	}
+	for _, command := range commands {
+		expected := map[string]string{application.ID: "ff", action.ID: "private"}[command.ID]
+		if !slices.Equal(command.Aliases, []string{expected}) {
+			t.Fatalf("expected filled aliases of %s to be [%s], got %v", command.Name, expected, command.Aliases)
+		}
+	}
+}
+
+func TestSearchClipboardHistoryMatchesTermsInAnyOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	waDB := newTestWaDB(t)
+	for i, item := range []*models.ClipboardHistoryItem{
+		{Kind: models.ClipboardHistoryText, Text: "foo bar"},
+		{Kind: models.ClipboardHistoryText, Text: "only foo"},
+		{Kind: models.ClipboardHistoryFiles, Files: []string{"/tmp/bar/foo.txt"}},
+		{Kind: models.ClipboardHistoryImage, ImagePath: "/tmp/image.png"},
+	} {
+		item.CopiedAt = time.Now().Add(time.Duration(i) * time.Second)
+		if _, err := waDB.SaveClipboardHistory(ctx, item, string(rune('a'+i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, c := range []struct {
+		patterns []string
+		expected int
+	}{
+		{patterns: nil, expected: 4},
+		{patterns: []string{"%bar%", "%foo%"}, expected: 2},
+		{patterns: []string{"%foo%"}, expected: 3},
+		{patterns: []string{"%foo%", "%baz%"}, expected: 0},
+	} {
+		items, err := waDB.SearchClipboardHistory(ctx, "", c.patterns, 10, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != c.expected {
+			t.Fatalf("expected %d entries for %v, got %d", c.expected, c.patterns, len(items))
+		}
+	}
+}
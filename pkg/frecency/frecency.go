@@ -0,0 +1,98 @@
+package frecency
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"watools/pkg/models"
+)
+
+// Model scores commands from their selection history.
+// Every selection counts 1 when it just happened and half as much each HalfLife later.
+// Selections made with a query related to the current one add a boost on top, weighted the same way.
+type Model struct {
+	HalfLife time.Duration
+	// ExactQueryBoost weights selections made with the same query and clipboard type
+	ExactQueryBoost float64
+	// RelatedQueryBoost weights selections whose query is a prefix of the current one or the other way around
+	RelatedQueryBoost float64
+}
+
+var DefaultModel = Model{
+	HalfLife:          7 * 24 * time.Hour,
+	ExactQueryBoost:   4,
+	RelatedQueryBoost: 1.5,
+}
+
+// Score is the ranking of one command
+type Score struct {
+	TriggerID      string    `json:"triggerId"`
+	Source         string    `json:"source"`
+	Score          float64   `json:"score"`
+	Frecency       float64   `json:"frecency"`
+	QueryBoost     float64   `json:"queryBoost"`
+	Count          int       `json:"count"`
+	LastSelectedAt time.Time `json:"lastSelectedAt"`
+}
+
+// Scores ranks the commands found in events for query and clipboardType, best first
+func (m Model) Scores(events []models.SelectionEvent, query string, clipboardType string, now time.Time) []Score {
+	query = models.NormalizeQuery(query)
+	scores := make(map[string]*Score)
+	for _, event := range events {
+		key := event.Source + "\x00" + event.TriggerID
+		score, ok := scores[key]
+		if !ok {
+			score = &Score{TriggerID: event.TriggerID, Source: event.Source}
+			scores[key] = score
+		}
+		weight := m.decay(now.Sub(event.SelectedAt))
+		score.Frecency += weight
+		score.Count++
+		if event.SelectedAt.After(score.LastSelectedAt) {
+			score.LastSelectedAt = event.SelectedAt
+		}
+		if event.ClipboardType != clipboardType {
+			continue
+		}
+		switch {
+		case event.Query == query:
+			score.QueryBoost += weight * m.ExactQueryBoost
+		case relatedQueries(event.Query, query):
+			score.QueryBoost += weight * m.RelatedQueryBoost
+		}
+	}
+
+	result := make([]Score, 0, len(scores))
+	for _, score := range scores {
+		score.Score = score.Frecency + score.QueryBoost
+		result = append(result, *score)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		if !result[i].LastSelectedAt.Equal(result[j].LastSelectedAt) {
+			return result[i].LastSelectedAt.After(result[j].LastSelectedAt)
+		}
+		return result[i].TriggerID < result[j].TriggerID
+	})
+	return result
+}
+
+// decay returns the weight of a selection made age ago, selections from the future count fully
+func (m Model) decay(age time.Duration) float64 {
+	if age <= 0 || m.HalfLife <= 0 {
+		return 1
+	}
+	return math.Exp2(-float64(age) / float64(m.HalfLife))
+}
+
+// relatedQueries reports whether one query extends the other, like typing "code" after having selected with "co"
+func relatedQueries(a string, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
@@ -0,0 +1,73 @@
+package frecency
+
+import (
+	"math"
+	"testing"
+	"time"
+	"watools/pkg/models"
+)
+
+func TestScoresDecay(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	model := Model{HalfLife: 24 * time.Hour}
+	events := []models.SelectionEvent{
+		{TriggerID: "recent", Source: "Application", SelectedAt: now},
+		{TriggerID: "old", Source: "Application", SelectedAt: now.Add(-24 * time.Hour)},
+		{TriggerID: "old", Source: "Application", SelectedAt: now.Add(-48 * time.Hour)},
+	}
+
+	scores := model.Scores(events, "", "", now)
+	if len(scores) != 2 {
+		t.Fatalf("Scores() returned %d scores, want 2", len(scores))
+	}
+	if scores[0].TriggerID != "recent" || scores[0].Frecency != 1 {
+		t.Fatalf("first score = %+v, want recent with frecency 1", scores[0])
+	}
+	if math.Abs(scores[1].Frecency-0.75) > 1e-9 || scores[1].Count != 2 || !scores[1].LastSelectedAt.Equal(now.Add(-24*time.Hour)) {
+		t.Fatalf("second score = %+v, want frecency 0.75 from 2 selections", scores[1])
+	}
+}
+
+func TestScoresQueryBoost(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	events := []models.SelectionEvent{
+		{TriggerID: "terminal", Source: "Application", SelectedAt: now},
+		{TriggerID: "terminal", Source: "Application", SelectedAt: now},
+		{TriggerID: "code", Source: "Application", Query: "co", SelectedAt: now},
+		{TriggerID: "calc", Source: "Application", Query: "co", ClipboardType: "image", SelectedAt: now},
+	}
+
+	scores := DefaultModel.Scores(events, " CO ", "", now)
+	if scores[0].TriggerID != "code" || scores[0].QueryBoost != DefaultModel.ExactQueryBoost {
+		t.Fatalf("first score = %+v, want code boosted by its query", scores[0])
+	}
+	for _, score := range scores {
+		if score.TriggerID == "calc" && score.QueryBoost != 0 {
+			t.Fatalf("selection with another clipboard type was boosted: %+v", score)
+		}
+	}
+
+	scores = DefaultModel.Scores(events, "cod", "", now)
+	if scores[0].TriggerID != "code" || scores[0].QueryBoost != DefaultModel.RelatedQueryBoost {
+		t.Fatalf("first score = %+v, want code boosted by a related query", scores[0])
+	}
+}
+
+func TestRelatedQueries(t *testing.T) {
+	t.Parallel()
+
+	for _, pair := range [][2]string{{"co", "code"}, {"code", "co"}, {"code", "code"}} {
+		if !relatedQueries(pair[0], pair[1]) {
+			t.Fatalf("expected %q and %q to be related", pair[0], pair[1])
+		}
+	}
+	for _, pair := range [][2]string{{"de", "code"}, {"code", "od"}, {"", "code"}, {"calc", "code"}} {
+		if relatedQueries(pair[0], pair[1]) {
+			t.Fatalf("expected %q and %q to be unrelated", pair[0], pair[1])
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Package gitignore matches paths against .gitignore rules
+package gitignore
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// FileName is the name of the files rules are read from
+const FileName = ".gitignore"
+
+type pattern struct {
+	segments []string
+	negate   bool
+	dirOnly  bool
+	// anchored patterns contain a slash and match from the base directory, the others match any path component
+	anchored bool
+}
+
+// Rules are the patterns of one .gitignore file, they apply to paths below Base
+type Rules struct {
+	Base     string
+	patterns []pattern
+}
+
+// Parse reads rules in .gitignore syntax that apply below base
+func Parse(base string, r io.Reader) *Rules {
+	rules := &Rules{Base: filepath.Clean(base)}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if p, ok := parseLine(scanner.Text()); ok {
+			rules.patterns = append(rules.patterns, p)
+		}
+	}
+	return rules
+}
+
+// ParseLines reads rules from lines, used for patterns that do not come from a file
+func ParseLines(base string, lines []string) *Rules {
+	return Parse(base, strings.NewReader(strings.Join(lines, "\n")))
+}
+
+// ParseFile reads the rules of a .gitignore file, they apply below the directory of the file
+func ParseFile(file string) (*Rules, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(filepath.Dir(file), f), nil
+}
+
+// ValidatePattern reports whether line is a pattern that can match, comments and blank lines are not
+func ValidatePattern(line string) bool {
+	_, ok := parseLine(line)
+	return ok
+}
+
+func parseLine(line string) (pattern, bool) {
+	line = strings.TrimRight(line, "\r")
+	// trailing spaces are ignored unless escaped
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\\ ") {
+		line = line[:len(line)-1]
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return pattern{}, false
+	}
+	var p pattern
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, "\\!") || strings.HasPrefix(line, "\\#") {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return pattern{}, false
+	}
+	p.anchored = strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	p.segments = strings.Split(line, "/")
+	if !p.anchored {
+		p.segments = append([]string{"**"}, p.segments...)
+	}
+	for i, segment := range p.segments {
+		p.segments[i] = strings.ReplaceAll(segment, "\\ ", " ")
+	}
+	return p, true
+}
+
+// Match reports whether the rules decide about file, and if so whether it is ignored.
+// The last matching pattern wins, so a negated pattern can include a path again.
+func (r *Rules) Match(file string, isDir bool) (ignored bool, matched bool) {
+	relative, err := filepath.Rel(r.Base, file)
+	if err != nil || relative == "." || strings.HasPrefix(relative, "..") {
+		return false, false
+	}
+	segments := strings.Split(filepath.ToSlash(relative), "/")
+	for i := len(r.patterns) - 1; i >= 0; i-- {
+		p := r.patterns[i]
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if matchSegments(p.segments, segments) {
+			return !p.negate, true
+		}
+	}
+	return false, false
+}
+
+// Matcher combines the rules of nested directories, outermost first
+type Matcher []*Rules
+
+// With returns a matcher that also applies rules, the receiver is not modified
+func (m Matcher) With(rules *Rules) Matcher {
+	if rules == nil || len(rules.patterns) == 0 {
+		return m
+	}
+	next := make(Matcher, len(m), len(m)+1)
+	copy(next, m)
+	return append(next, rules)
+}
+
+// Ignored reports whether file is ignored, rules of deeper directories override outer ones.
+// Parents are not checked, callers skip the contents of ignored directories.
+func (m Matcher) Ignored(file string, isDir bool) bool {
+	for i := len(m) - 1; i >= 0; i-- {
+		if ignored, matched := m[i].Match(file, isDir); matched {
+			return ignored
+		}
+	}
+	return false
+}
+
+func matchSegments(pattern []string, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	ok, err := path.Match(pattern[0], segments[0])
+	return err == nil && ok && matchSegments(pattern[1:], segments[1:])
+}
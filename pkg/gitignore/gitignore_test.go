@@ -0,0 +1,73 @@
+package gitignore
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRulesMatch(t *testing.T) {
+	t.Parallel()
+
+	base := filepath.FromSlash("/repo")
+	rules := ParseLines(base, []string{
+		"# build output",
+		"node_modules",
+		"*.log",
+		"!keep.log",
+		"/dist",
+		"build/",
+		"docs/**/*.tmp",
+		"\\#notes",
+	})
+	cases := []struct {
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		{"node_modules", true, true},
+		{"web/node_modules", true, true},
+		{"debug.log", false, true},
+		{"logs/keep.log", false, false},
+		{"dist", true, true},
+		{"web/dist", true, false},
+		{"build", true, true},
+		{"build", false, false},
+		{"docs/a/b/c.tmp", false, true},
+		{"docs/c.tmp", false, true},
+		{"src/c.tmp", false, false},
+		{"#notes", false, true},
+		{"main.go", false, false},
+	}
+	for _, c := range cases {
+		ignored, _ := rules.Match(filepath.Join(base, filepath.FromSlash(c.path)), c.isDir)
+		if ignored != c.ignored {
+			t.Fatalf("expected %q ignored=%v, got %v", c.path, c.ignored, ignored)
+		}
+	}
+	if _, matched := rules.Match(filepath.FromSlash("/other/debug.log"), false); matched {
+		t.Fatalf("expected rules not to apply outside their base")
+	}
+}
+
+func TestMatcherNestedRules(t *testing.T) {
+	t.Parallel()
+
+	root := filepath.FromSlash("/repo")
+	sub := filepath.Join(root, "web")
+	matcher := Matcher{}.
+		With(ParseLines(root, []string{"*.gen.go", "tmp"})).
+		With(ParseLines(sub, []string{"!*.gen.go"}))
+
+	if !matcher.Ignored(filepath.Join(root, "api.gen.go"), false) {
+		t.Fatalf("expected outer rule to ignore generated file")
+	}
+	if matcher.Ignored(filepath.Join(sub, "api.gen.go"), false) {
+		t.Fatalf("expected nested rule to include generated file again")
+	}
+	if !matcher.Ignored(filepath.Join(sub, "tmp"), true) {
+		t.Fatalf("expected outer rule to apply in nested directory")
+	}
+	if ValidatePattern("# comment") || ValidatePattern("   ") || !ValidatePattern("*.log") {
+		t.Fatalf("unexpected pattern validation")
+	}
+}
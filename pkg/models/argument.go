@@ -0,0 +1,84 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+// ArgumentKind is a kind of user input a command can be triggered with
+type ArgumentKind string
+
+const (
+	// ArgumentText is free text typed by the user, such as a query or a URL
+	ArgumentText ArgumentKind = "text"
+	// ArgumentFiles are absolute file paths, e.g. files dropped on the launcher
+	ArgumentFiles ArgumentKind = "files"
+	// ArgumentClipboard is the clipboard content captured when the launcher was shown
+	ArgumentClipboard ArgumentKind = "clipboard"
+)
+
+// ClipboardSnapshot is the clipboard content a command is triggered with
+type ClipboardSnapshot struct {
+	Text        string   `json:"text,omitempty"`
+	ImageBase64 string   `json:"imageBase64,omitempty"`
+	Files       []string `json:"files,omitempty"`
+}
+
+// CommandArguments is the user input handed to a command when it is triggered
+type CommandArguments struct {
+	Text string `json:"text,omitempty"`
+	// Values are the answers to several prompts in order, such as script arguments or snippet placeholders, they count as text
+	Values    []string                     `json:"values,omitempty"`
+	Files     []string                     `json:"files,omitempty"`
+	Clipboard mo.Option[ClipboardSnapshot] `json:"clipboard"`
+}
+
+// NoArguments triggers a command without user input
+var NoArguments = CommandArguments{}
+
+// TextValues returns the Values, or the Text as the only value
+func (a CommandArguments) TextValues() []string {
+	if len(a.Values) > 0 {
+		return a.Values
+	}
+	if a.Text != "" {
+		return []string{a.Text}
+	}
+	return nil
+}
+
+// Kinds returns the kinds of input present in the arguments
+func (a CommandArguments) Kinds() []ArgumentKind {
+	var kinds []ArgumentKind
+	if a.Text != "" || len(a.Values) > 0 {
+		kinds = append(kinds, ArgumentText)
+	}
+	if len(a.Files) > 0 {
+		kinds = append(kinds, ArgumentFiles)
+	}
+	if a.Clipboard.IsPresent() {
+		kinds = append(kinds, ArgumentClipboard)
+	}
+	return kinds
+}
+
+// ValidateAction checks that action is one of the Actions of the command
+func ValidateAction(command *Command, action string) error {
+	if !lo.Contains(command.Actions, action) {
+		return fmt.Errorf("command '%s' has no action '%s'", command.Name, action)
+	}
+	return nil
+}
+
+// ValidateArguments checks that every kind of input in arguments is accepted by the command
+func ValidateArguments(command *Command, arguments CommandArguments) error {
+	rejected := lo.Without(arguments.Kinds(), command.Accepts...)
+	if len(rejected) == 0 {
+		return nil
+	}
+	names := lo.Map(rejected, func(kind ArgumentKind, _ int) string { return string(kind) })
+	return fmt.Errorf("command '%s' does not accept %s arguments", command.Name, strings.Join(names, ", "))
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/samber/mo"
+)
+
+const CategoryBookmark CommandCategory = "Bookmark"
+
+// BookmarkCommand is a bookmark imported from a browser profile, it opens in the default browser
+type BookmarkCommand struct {
+	Command
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// FolderPath is the folders the bookmark is in, joined with "/", such as "Bookmarks bar/Work"
+	FolderPath string `json:"folderPath"`
+	Browser    string `json:"browser"`
+	Profile    string `json:"profile"`
+	// Favicon is a data URL of the icon the browser cached for the page
+	Favicon mo.Option[string] `json:"favicon"`
+	// Source is the browser file the bookmark was imported from
+	Source string `json:"source"`
+}
+
+func (b *BookmarkCommand) GetTriggerID() string {
+	return b.TriggerID
+}
+
+func (b *BookmarkCommand) OnTrigger(_ CommandArguments) error {
+	return openURL(b.URL, "")
+}
+
+func (b *BookmarkCommand) GetMetadata() *Command {
+	return &b.Command
+}
+
+// BookmarkID identifies a bookmark across imports, so that its usage and visibility are kept
+func BookmarkID(browser string, profile string, folderPath string, url string) string {
+	sum := sha1.Sum([]byte(browser + "\x00" + profile + "\x00" + folderPath + "\x00" + url))
+	return hex.EncodeToString(sum[:10])
+}
+
+func NewBookmarkCommand(source string, browser string, profile string, title string, url string, folderPath string, favicon mo.Option[string]) *BookmarkCommand {
+	category := CategoryBookmark
+	id := BookmarkID(browser, profile, folderPath, url)
+	if title == "" {
+		title = url
+	}
+	return &BookmarkCommand{
+		Command: Command{
+			TriggerID:   fmt.Sprintf("%s-%s", category, id),
+			Name:        title,
+			Description: mo.Some(url),
+			Category:    category,
+			Accepts:     []ArgumentKind{},
+		},
+		ID:         id,
+		URL:        url,
+		FolderPath: folderPath,
+		Browser:    browser,
+		Profile:    profile,
+		Favicon:    favicon,
+		Source:     source,
+	}
+}
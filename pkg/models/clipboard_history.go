@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// ClipboardHistoryKind is the kind of content a clipboard history entry holds
+type ClipboardHistoryKind string
+
+const (
+	ClipboardHistoryText  ClipboardHistoryKind = "text"
+	ClipboardHistoryImage ClipboardHistoryKind = "image"
+	ClipboardHistoryFiles ClipboardHistoryKind = "files"
+)
+
+// ClipboardHistoryItem is one recorded clipboard content, copying the same content again moves it to the top
+type ClipboardHistoryItem struct {
+	ID   int64                `json:"id"`
+	Kind ClipboardHistoryKind `json:"kind"`
+	Text string               `json:"text,omitempty"`
+	// ImagePath is the PNG file of an image entry, it lives in the cache directory
+	ImagePath string   `json:"imagePath,omitempty"`
+	Files     []string `json:"files,omitempty"`
+	// Size is the stored size in bytes, it counts towards the history size limit
+	Size int64 `json:"size"`
+	// SourceApp is the app that was in front when the content was copied, empty when it is unknown
+	SourceApp string    `json:"sourceApp"`
+	Pinned    bool      `json:"pinned"`
+	CopiedAt  time.Time `json:"copiedAt"`
+}
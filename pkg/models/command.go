@@ -3,12 +3,15 @@ package models
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
+	"watools/pkg/xdg"
 
 	"github.com/google/uuid"
 	"github.com/samber/mo"
 )
 
+// CommandCategory names the command source a command belongs to
 type CommandCategory string
 
 const (
@@ -16,20 +19,10 @@ const (
 	CategoryOperation   CommandCategory = "Operation"
 )
 
-func ParseCommandCategory(category string) (CommandCategory, error) {
-	switch category {
-	case string(CategoryApplication):
-		return CategoryApplication, nil
-	case string(CategoryOperation):
-		return CategoryOperation, nil
-	default:
-		return CategoryApplication, fmt.Errorf("cant parse command category")
-	}
-}
-
 type CommandRunner interface {
 	GetTriggerID() string
-	OnTrigger() error
+	// OnTrigger runs the command, arguments only hold the kinds listed in the Accepts of its metadata
+	OnTrigger(arguments CommandArguments) error
 	GetMetadata() *Command
 }
 
@@ -40,6 +33,10 @@ type Command struct {
 	Category    CommandCategory      `json:"category"`
 	LastUsedAt  mo.Option[time.Time] `json:"lastUsedAt"`
 	UsedCount   int64                `json:"usedCount"`
+	// Accepts lists the kinds of arguments the command can be triggered with
+	Accepts []ArgumentKind `json:"accepts"`
+	// Actions lists what the command can do besides its trigger, they are run through the action of its source
+	Actions []string `json:"actions,omitempty"`
 }
 
 type ApplicationCommand struct {
@@ -49,18 +46,41 @@ type ApplicationCommand struct {
 	ID           string            `json:"id"`
 	DirUpdatedAt time.Time         `json:"dirUpdatedAt"`
 	IsUserApp    bool              `json:"isUserApp"` // Computed field, not stored in DB
+	ParentID     string            `json:"parentId,omitempty"`
+	ActionID     string            `json:"actionId,omitempty"`
+	// Origin is computed from the path, it tells whether the app is native or installed as a Flatpak or Snap
+	Origin xdg.PackagingOrigin `json:"origin"`
+	// Aliases are extra search keywords, they are stored by path so they survive rescans
+	Aliases []string `json:"aliases"`
 }
 
 func (a *ApplicationCommand) GetTriggerID() string {
 	return a.TriggerID
 }
 
-func (a *ApplicationCommand) OnTrigger() error {
+// OnTrigger launches the application, files and the text (usually a URL) are passed as launch arguments.
+// Files must be absolute paths that exist, so that they are not taken for options of the application.
+func (a *ApplicationCommand) OnTrigger(arguments CommandArguments) error {
 	path := a.Path
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		return fmt.Errorf("failed to find application file '%s': %w", path, err)
 	}
-	return openApplication(path)
+	for _, file := range arguments.Files {
+		if !filepath.IsAbs(file) {
+			return fmt.Errorf("file '%s' is not an absolute path", file)
+		}
+		if _, err := os.Stat(file); err != nil {
+			return fmt.Errorf("cannot open '%s' with %s: %w", file, a.Name, err)
+		}
+	}
+	targets := append([]string{}, arguments.Files...)
+	if arguments.Text != "" {
+		targets = append(targets, arguments.Text)
+	}
+	if a.ActionID != "" {
+		return openApplicationAction(path, a.ActionID, targets)
+	}
+	return openApplication(path, targets)
 }
 
 func (a *ApplicationCommand) GetMetadata() *Command {
@@ -78,6 +98,7 @@ func NewApplicationCommand(name string, description mo.Option[string], path stri
 			Name:        name,
 			Description: description,
 			Category:    category,
+			Accepts:     applicationAccepts(path),
 		},
 		IconPath:     iconPath,
 		Path:         path,
@@ -85,21 +106,33 @@ func NewApplicationCommand(name string, description mo.Option[string], path stri
 		DirUpdatedAt: dirUpdatedAt,
 	}
 	cmd.IsUserApp = cmd.IsUserApplication()
+	cmd.Origin = cmd.PackagingOrigin()
+	return cmd
+}
+
+// NewApplicationActionCommand create a child command launching one action of the parent application
+func NewApplicationActionCommand(parent *ApplicationCommand, actionID string, name string, iconPath mo.Option[string], id mo.Option[string]) *ApplicationCommand {
+	if iconPath.IsNone() {
+		iconPath = parent.IconPath
+	}
+	cmd := NewApplicationCommand(name, mo.Some(parent.Name), parent.Path, iconPath, id, parent.DirUpdatedAt)
+	cmd.ParentID = parent.ID
+	cmd.ActionID = actionID
 	return cmd
 }
 
 type OperationCommand struct {
 	Command
 	Icon      string `json:"icon"`
-	onTrigger func() error
+	onTrigger func(arguments CommandArguments) error
 }
 
 func (o *OperationCommand) GetTriggerID() string {
 	return o.TriggerID
 }
 
-func (o *OperationCommand) OnTrigger() error {
-	return o.onTrigger()
+func (o *OperationCommand) OnTrigger(arguments CommandArguments) error {
+	return o.onTrigger(arguments)
 }
 
 func (o *OperationCommand) GetMetadata() *Command {
@@ -107,21 +140,26 @@ func (o *OperationCommand) GetMetadata() *Command {
 }
 
 func NewOperationCommand(name string, description string, icon string, onTrigger func() error) *OperationCommand {
+	return NewOperationCommandWithArguments(name, description, icon, nil, func(CommandArguments) error {
+		return onTrigger()
+	})
+}
+
+// NewOperationCommandWithArguments create an operation that takes the argument kinds listed in accepts
+func NewOperationCommandWithArguments(name string, description string, icon string, accepts []ArgumentKind, onTrigger func(arguments CommandArguments) error) *OperationCommand {
 	category := CategoryOperation
+	if accepts == nil {
+		accepts = []ArgumentKind{}
+	}
 	return &OperationCommand{
 		Command: Command{
 			TriggerID:   fmt.Sprintf("%s-%s", category, name),
 			Name:        name,
 			Description: mo.Some(description),
 			Category:    category,
+			Accepts:     accepts,
 		},
 		Icon:      icon,
 		onTrigger: onTrigger,
 	}
 }
-
-type ApplicationUsageUpdate struct {
-	ID         string
-	LastUsedAt time.Time
-	UsedCount  int
-}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"watools/pkg/xdg"
 )
 
 func (a *ApplicationCommand) IsUserApplication() bool {
@@ -21,3 +22,12 @@ func (a *ApplicationCommand) IsUserApplication() bool {
 
 	return false
 }
+
+// applicationAccepts lists the arguments open -a can hand to an application, text would be opened as a file name
+func applicationAccepts(_ string) []ArgumentKind {
+	return []ArgumentKind{ArgumentFiles}
+}
+
+func (a *ApplicationCommand) PackagingOrigin() xdg.PackagingOrigin {
+	return xdg.OriginNative
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"watools/pkg/xdg"
+)
+
+func (a *ApplicationCommand) IsUserApplication() bool {
+	path := filepath.Clean(a.Path)
+
+	if strings.HasPrefix(path, "/usr/local/") || strings.HasPrefix(path, "/opt/") {
+		return true
+	}
+
+	userDirs := []string{xdg.DataHome()}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		userDirs = append(userDirs, homeDir)
+	}
+	for _, dir := range userDirs {
+		if dir == "" {
+			continue
+		}
+		if strings.HasPrefix(path, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applicationAccepts lists the arguments an application at path can be started with,
+// desktop entries and executables both take files and URLs
+func applicationAccepts(_ string) []ArgumentKind {
+	return []ArgumentKind{ArgumentText, ArgumentFiles}
+}
+
+func (a *ApplicationCommand) PackagingOrigin() xdg.PackagingOrigin {
+	return xdg.DataDirOrigin(a.Path)
+}
@@ -5,10 +5,49 @@ import (
 	"os/exec"
 )
 
-func openApplication(path string) error {
+// openApplication opens the application at path, targets are opened with it as documents or URLs
+func openApplication(path string, targets []string) error {
 	cmd := exec.Command("open", path)
+	if len(targets) > 0 {
+		cmd = exec.Command("open", append([]string{"-a", path}, targets...)...)
+	}
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to run application: %w\n%s", err, output)
 	}
 	return nil
 }
+
+func openApplicationAction(path string, actionID string, _ []string) error {
+	return fmt.Errorf("application action '%s' of '%s' is not supported on this platform", actionID, path)
+}
+
+// openURL opens a URL with appPath, or with the default handler when appPath is empty
+func openURL(url string, appPath string) error {
+	args := []string{url}
+	if appPath != "" {
+		args = []string{"-a", appPath, url}
+	}
+	cmd := exec.Command("open", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to open url: %w\n%s", err, output)
+	}
+	return nil
+}
+
+// openSSH connects to host with the ssh:// handler, Terminal unless the user picked another terminal for it
+func openSSH(host string, port int) error {
+	url := "ssh://" + host
+	if port != 0 {
+		url = fmt.Sprintf("%s:%d", url, port)
+	}
+	return openURL(url, "")
+}
+
+// openTerminal opens a Terminal window in dir
+func openTerminal(dir string) error {
+	cmd := exec.Command("open", "-a", "Terminal", dir)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to open terminal: %w\n%s", err, output)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"watools/pkg/xdg"
+)
+
+// openApplication launches the application at path with targets as file or URL arguments
+func openApplication(path string, targets []string) error {
+	if !strings.HasSuffix(path, xdg.DesktopEntrySuffix) {
+		if len(targets) > 0 {
+			return startDetached(exec.Command(path, targets...))
+		}
+		return startDetached(exec.Command("xdg-open", path))
+	}
+	return launchDesktopEntry(path, xdg.GroupDesktopEntry, targets)
+}
+
+func openApplicationAction(path string, actionID string, targets []string) error {
+	return launchDesktopEntry(path, xdg.ActionGroup(actionID), targets)
+}
+
+// openURL opens a URL with appPath, or with the default handler when appPath is empty.
+// appPath may be a desktop entry or an executable.
+func openURL(url string, appPath string) error {
+	switch {
+	case appPath == "":
+		return startDetached(exec.Command("xdg-open", url))
+	case strings.HasSuffix(appPath, xdg.DesktopEntrySuffix):
+		return launchDesktopEntry(appPath, xdg.GroupDesktopEntry, []string{url})
+	default:
+		return startDetached(exec.Command(appPath, url))
+	}
+}
+
+// launchDesktopEntry runs the Exec line of group in a desktop entry with targets as file or URL arguments
+func launchDesktopEntry(path string, group string, targets []string) error {
+	entry, err := xdg.ParseDesktopEntryFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read desktop entry '%s': %w", path, err)
+	}
+	if !entry.HasGroup(group) {
+		return fmt.Errorf("desktop entry '%s' has no [%s] group", path, group)
+	}
+	cmd, err := desktopEntryCommand(entry, group, path, targets)
+	if err != nil {
+		return err
+	}
+	return startDetached(cmd)
+}
+
+// desktopEntryCommand builds the process for the Exec key of group honoring Terminal= and Path= of the application
+func desktopEntryCommand(entry *xdg.DesktopEntry, group string, path string, targets []string) (*exec.Cmd, error) {
+	if entry.String(group, "Exec") == "" {
+		if group != xdg.GroupDesktopEntry {
+			return nil, fmt.Errorf("action [%s] of '%s' has no Exec key", group, path)
+		}
+		// D-Bus activatable entries without Exec are launched by GIO
+		return exec.Command("gio", append([]string{"launch", path}, targets...)...), nil
+	}
+
+	args, err := xdg.ExpandExec(entry, group, path, targets)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Exec in '%s': %w", path, err)
+	}
+	args = sandboxLaunchArgs(entry, path, args)
+	if entry.Bool(xdg.GroupDesktopEntry, "Terminal") {
+		if args, err = xdg.TerminalCommand(args); err != nil {
+			return nil, fmt.Errorf("failed to run '%s' in a terminal: %w", path, err)
+		}
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	if workDir := entry.String(xdg.GroupDesktopEntry, "Path"); workDir != "" {
+		if fi, err := os.Stat(workDir); err == nil && fi.IsDir() {
+			cmd.Dir = workDir
+		}
+	}
+	return cmd, nil
+}
+
+// sandboxLaunchArgs routes Flatpak and Snap entries through "flatpak run" / "snap run"
+// when the exported Exec line cannot be run as is, e.g. a wrapper path that does not exist on this system
+func sandboxLaunchArgs(entry *xdg.DesktopEntry, path string, args []string) []string {
+	group := xdg.GroupDesktopEntry
+	origin := xdg.DataDirOrigin(path)
+	flatpakID := entry.String(group, "X-Flatpak")
+	snapName := entry.String(group, "X-SnapInstanceName")
+
+	switch {
+	case flatpakID != "" || origin == xdg.OriginFlatpak:
+		if flatpakID == "" {
+			flatpakID = strings.TrimSuffix(filepath.Base(path), xdg.DesktopEntrySuffix)
+		}
+		if filepath.Base(args[0]) == "flatpak" {
+			if isRunnable(args[0]) {
+				return args
+			}
+			return append([]string{"flatpak"}, args[1:]...)
+		}
+		if isRunnable(args[0]) {
+			return args
+		}
+		// the Exec line is meant to run inside the sandbox
+		return append([]string{"flatpak", "run", "--command=" + args[0], flatpakID}, args[1:]...)
+
+	case snapName != "" || origin == xdg.OriginSnap:
+		if snapName == "" {
+			snapName, _, _ = strings.Cut(strings.TrimSuffix(filepath.Base(path), xdg.DesktopEntrySuffix), "_")
+		}
+		// snapd writes "env VAR=value... /snap/bin/<app> args"
+		commandIndex := 0
+		if filepath.Base(args[0]) == "env" {
+			commandIndex = 1
+			for commandIndex < len(args) && strings.Contains(args[commandIndex], "=") {
+				commandIndex++
+			}
+		}
+		if commandIndex < len(args) && isRunnable(args[commandIndex]) {
+			return args
+		}
+		if commandIndex >= len(args) {
+			return []string{"snap", "run", snapName}
+		}
+		// /snap/bin/<snap>.<app> names the app to run when it differs from the snap
+		snapApp := snapName
+		if strings.HasPrefix(args[commandIndex], "/snap/bin/") {
+			snapApp = filepath.Base(args[commandIndex])
+		}
+		return append([]string{"snap", "run", snapApp}, args[commandIndex+1:]...)
+	}
+	return args
+}
+
+func isRunnable(command string) bool {
+	_, err := exec.LookPath(command)
+	return err == nil
+}
+
+// startDetached starts cmd in its own session so it outlives the launcher
+func startDetached(cmd *exec.Cmd) error {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	cmd.Stdin = nil
+	cmd.Stdout = nil
+	cmd.Stderr = nil
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to run application: %w", err)
+	}
+	// reap the child so it does not linger as a zombie
+	go func() {
+		_ = cmd.Wait()
+	}()
+	return nil
+}
+
+// openSSH connects to host in the terminal emulator of the user
+func openSSH(host string, port int) error {
+	args, err := xdg.TerminalCommand(sshArgs(host, port))
+	if err != nil {
+		return err
+	}
+	return startDetached(exec.Command(args[0], args[1:]...))
+}
+
+// openTerminal opens the terminal emulator of the user with their shell in dir
+func openTerminal(dir string) error {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+	args, err := xdg.TerminalCommand([]string{shell})
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = dir
+	return startDetached(cmd)
+}
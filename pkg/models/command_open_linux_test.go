@@ -0,0 +1,88 @@
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"watools/pkg/xdg"
+
+	"github.com/samber/mo"
+)
+
+func TestSandboxLaunchArgs(t *testing.T) {
+	cases := []struct {
+		name  string
+		path  string
+		entry string
+		args  []string
+		want  []string
+	}{
+		{
+			name:  "native entry is untouched",
+			path:  "/usr/share/applications/editor.desktop",
+			entry: "Exec=editor",
+			args:  []string{"editor", "/tmp/a.txt"},
+			want:  []string{"editor", "/tmp/a.txt"},
+		},
+		{
+			name:  "missing flatpak wrapper",
+			path:  "/usr/share/applications/org.example.App.desktop",
+			entry: "X-Flatpak=org.example.App",
+			args:  []string{"/nonexistent/bin/flatpak", "run", "org.example.App", "@@u", "https://example.com", "@@"},
+			want:  []string{"flatpak", "run", "org.example.App", "@@u", "https://example.com", "@@"},
+		},
+		{
+			name:  "flatpak entry without wrapper",
+			path:  "/usr/share/applications/org.example.App.desktop",
+			entry: "X-Flatpak=org.example.App",
+			args:  []string{"app", "--new-window"},
+			want:  []string{"flatpak", "run", "--command=app", "org.example.App", "--new-window"},
+		},
+		{
+			name:  "runnable flatpak entry",
+			path:  "/usr/share/applications/org.example.App.desktop",
+			entry: "X-Flatpak=org.example.App",
+			args:  []string{"sh", "-c", "true"},
+			want:  []string{"sh", "-c", "true"},
+		},
+		{
+			name:  "missing snap binary",
+			path:  "/usr/share/applications/player_player.desktop",
+			entry: "X-SnapInstanceName=player",
+			args:  []string{"env", "BAMF_DESKTOP_FILE_HINT=/x.desktop", "/snap/bin/player.tool", "/tmp/song.ogg"},
+			want:  []string{"snap", "run", "player.tool", "/tmp/song.ogg"},
+		},
+	}
+	for _, tc := range cases {
+		entry, err := xdg.ParseDesktopEntry(strings.NewReader("[Desktop Entry]\nType=Application\nName=App\n" + tc.entry + "\n"))
+		if err != nil {
+			t.Fatalf("%s: ParseDesktopEntry: %v", tc.name, err)
+		}
+		got := sandboxLaunchArgs(entry, tc.path, tc.args)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("%s: sandboxLaunchArgs = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestApplicationRejectsFilesBeforeLaunching(t *testing.T) {
+	dir := t.TempDir()
+	appPath := filepath.Join(dir, "editor.desktop")
+	if err := os.WriteFile(appPath, []byte("[Desktop Entry]\nType=Application\nName=Editor\nExec=editor %F\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	app := NewApplicationCommand("Editor", mo.None[string](), appPath, mo.None[string](), mo.None[string](), time.Now())
+
+	for name, file := range map[string]string{
+		"relative path": "notes.txt",
+		"option":        "--help",
+		"missing file":  filepath.Join(dir, "missing.txt"),
+	} {
+		if err := app.OnTrigger(CommandArguments{Files: []string{file}}); err == nil {
+			t.Errorf("%s: expected %q to be rejected", name, file)
+		}
+	}
+}
@@ -3,12 +3,79 @@ package models
 import (
 	"fmt"
 	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/windows"
 )
 
-func openApplication(path string) error {
+// openApplication starts the application at path, targets are only passed to executables
+// because "start" would let cmd interpret "&" in URLs
+func openApplication(path string, targets []string) error {
+	if len(targets) > 0 {
+		if !strings.EqualFold(filepath.Ext(path), ".exe") {
+			return fmt.Errorf("passing files to '%s' is not supported", path)
+		}
+		cmd := exec.Command(path, targets...)
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to run application: %w", err)
+		}
+		go cmd.Wait()
+		return nil
+	}
 	cmd := exec.Command("cmd", "/c", "start", "", path)
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to run application: %w\n%s", err, output)
 	}
 	return nil
 }
+
+func openApplicationAction(path string, actionID string, _ []string) error {
+	return fmt.Errorf("application action '%s' of '%s' is not supported on this platform", actionID, path)
+}
+
+// openURL opens a URL with appPath, or with the default handler when appPath is empty.
+// The URL is handed to the shell without cmd so that "&" in query strings is not interpreted.
+func openURL(url string, appPath string) error {
+	cmd := exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	if appPath != "" {
+		cmd = exec.Command(appPath, url)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open url: %w", err)
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// openSSH connects to host in Windows Terminal, or in a console window when it is not installed.
+// The host name is checked by the command source, so cmd has nothing to interpret in it.
+func openSSH(host string, port int) error {
+	args := sshArgs(host, port)
+	cmd := exec.Command("cmd", append([]string{"/c", "start", ""}, args...)...)
+	if wt, err := exec.LookPath("wt.exe"); err == nil {
+		cmd = exec.Command(wt, args...)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open ssh: %w", err)
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// openTerminal opens Windows Terminal in dir, or a console window when it is not installed.
+// The console is started without cmd /c start so that nothing in dir is interpreted.
+func openTerminal(dir string) error {
+	cmd := exec.Command("cmd.exe")
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: windows.CREATE_NEW_CONSOLE}
+	if wt, err := exec.LookPath("wt.exe"); err == nil {
+		cmd = exec.Command(wt, "-d", dir)
+	}
+	cmd.Dir = dir
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open terminal: %w", err)
+	}
+	go cmd.Wait()
+	return nil
+}
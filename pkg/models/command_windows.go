@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"watools/pkg/xdg"
 )
 
 func (a *ApplicationCommand) IsUserApplication() bool {
@@ -32,3 +33,16 @@ func (a *ApplicationCommand) IsUserApplication() bool {
 
 	return false
 }
+
+// applicationAccepts lists the arguments an application at path can be started with,
+// only executables take them, shortcuts and other files are opened by the shell without arguments
+func applicationAccepts(path string) []ArgumentKind {
+	if !strings.EqualFold(filepath.Ext(path), ".exe") {
+		return []ArgumentKind{}
+	}
+	return []ArgumentKind{ArgumentText, ArgumentFiles}
+}
+
+func (a *ApplicationCommand) PackagingOrigin() xdg.PackagingOrigin {
+	return xdg.OriginNative
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+// IndexedFile is a file or directory found below one of the file index roots
+type IndexedFile struct {
+	Path       string    `json:"path"`
+	Name       string    `json:"name"`
+	Root       string    `json:"root"`
+	IsDir      bool      `json:"isDir"`
+	Size       int64     `json:"size"`
+	ModifiedAt time.Time `json:"modifiedAt"`
+}
+
+// FileAction is something the launcher can do with a file result
+type FileAction string
+
+const (
+	FileActionOpen     FileAction = "open"
+	FileActionReveal   FileAction = "reveal"
+	FileActionCopyPath FileAction = "copyPath"
+)
+
+// FileActions are the actions offered for every file result, the first one is the default
+var FileActions = []FileAction{FileActionOpen, FileActionReveal, FileActionCopyPath}
+
+// OpenFile opens a file or directory with its default application
+func OpenFile(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("cannot open '%s': %w", path, err)
+	}
+	return openURL(path, "")
+}
+
+const CategoryFile CommandCategory = "File"
+
+// FileCommand is an indexed file, triggering it opens the file with its default application
+type FileCommand struct {
+	Command
+	Path       string    `json:"path"`
+	Root       string    `json:"root"`
+	IsDir      bool      `json:"isDir"`
+	Size       int64     `json:"size"`
+	ModifiedAt time.Time `json:"modifiedAt"`
+	onAction   func(command *FileCommand, action FileAction) error
+}
+
+func (f *FileCommand) GetTriggerID() string {
+	return f.TriggerID
+}
+
+func (f *FileCommand) OnTrigger(_ CommandArguments) error {
+	return f.RunAction(FileActionOpen)
+}
+
+// RunAction runs one of FileActions on the file
+func (f *FileCommand) RunAction(action FileAction) error {
+	if !lo.Contains(FileActions, action) {
+		return fmt.Errorf("unknown file action '%s'", action)
+	}
+	return f.onAction(f, action)
+}
+
+func (f *FileCommand) GetMetadata() *Command {
+	return &f.Command
+}
+
+// FileTriggerID is the trigger ID of the file command of path
+func FileTriggerID(path string) string {
+	return fmt.Sprintf("%s-%s", CategoryFile, path)
+}
+
+// NewFileCommand create the command of an indexed file, onAction runs the actions that need the launcher
+func NewFileCommand(file IndexedFile, onAction func(command *FileCommand, action FileAction) error) *FileCommand {
+	return &FileCommand{
+		Command: Command{
+			TriggerID:   FileTriggerID(file.Path),
+			Name:        file.Name,
+			Description: mo.Some(file.Path),
+			Category:    CategoryFile,
+			Accepts:     []ArgumentKind{},
+			Actions:     lo.Map(FileActions, func(action FileAction, _ int) string { return string(action) }),
+		},
+		Path:       file.Path,
+		Root:       file.Root,
+		IsDir:      file.IsDir,
+		Size:       file.Size,
+		ModifiedAt: file.ModifiedAt,
+		onAction:   onAction,
+	}
+}
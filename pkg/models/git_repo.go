@@ -0,0 +1,126 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+const CategoryGitRepo CommandCategory = "GitRepo"
+
+// GitRepoAction is something the launcher can do with a repository
+type GitRepoAction string
+
+const (
+	GitRepoActionOpenEditor   GitRepoAction = "openEditor"
+	GitRepoActionOpenTerminal GitRepoAction = "openTerminal"
+	GitRepoActionReveal       GitRepoAction = "reveal"
+	GitRepoActionCopyPath     GitRepoAction = "copyPath"
+	// GitRepoActionOpenRemote is only offered when the remote has a web page
+	GitRepoActionOpenRemote GitRepoAction = "openRemote"
+)
+
+// GitRepoRoot is a directory searched for repositories, MaxDepth is how many levels below it are visited
+type GitRepoRoot struct {
+	Path     string `json:"path"`
+	MaxDepth int    `json:"maxDepth"`
+}
+
+// GitRepo is a repository found below a GitRepoRoot
+type GitRepo struct {
+	Path string
+	Root string
+	Name string
+	// RemoteURL is the url of the remote as written in the git config
+	RemoteURL string
+}
+
+// GitRepoCommand is a repository with its current branch, triggering it opens the repository in the editor
+type GitRepoCommand struct {
+	Command
+	Path string `json:"path"`
+	Root string `json:"root"`
+	// Branch is the short commit hash when HEAD is detached, empty when HEAD could not be read
+	Branch   string `json:"branch"`
+	Detached bool   `json:"detached"`
+	// Unstaged reports tracked files changed in the work tree and not staged, untracked files and staged changes are
+	// not counted
+	Unstaged bool `json:"unstaged"`
+	// RemoteURL is the web page of the remote, empty when the remote is not hosted on the web
+	RemoteURL string `json:"remoteUrl"`
+	onAction  func(command *GitRepoCommand, action GitRepoAction) error
+}
+
+func (g *GitRepoCommand) GetTriggerID() string {
+	return g.TriggerID
+}
+
+func (g *GitRepoCommand) OnTrigger(_ CommandArguments) error {
+	return g.RunAction(GitRepoActionOpenEditor)
+}
+
+// RunAction runs one of the Actions of the command
+func (g *GitRepoCommand) RunAction(action GitRepoAction) error {
+	if !lo.Contains(g.Actions, string(action)) {
+		return fmt.Errorf("cannot %s repository '%s'", action, g.Path)
+	}
+	return g.onAction(g, action)
+}
+
+func (g *GitRepoCommand) GetMetadata() *Command {
+	return &g.Command
+}
+
+// OpenWith opens the repository folder with the application at launcher
+func (g *GitRepoCommand) OpenWith(launcher string) error {
+	return openApplication(launcher, []string{g.Path})
+}
+
+// OpenTerminal opens a terminal in the repository folder
+func (g *GitRepoCommand) OpenTerminal() error {
+	return openTerminal(g.Path)
+}
+
+// OpenRemote opens the web page of the remote in the default browser
+func (g *GitRepoCommand) OpenRemote() error {
+	if g.RemoteURL == "" {
+		return fmt.Errorf("repository '%s' has no remote web page", g.Path)
+	}
+	return openURL(g.RemoteURL, "")
+}
+
+// NewGitRepoCommand create the command of a repository, onAction runs the actions that need the launcher.
+// remoteURL is the web page of the remote, the open remote action is only offered when it is set.
+func NewGitRepoCommand(repo GitRepo, remoteURL string, branch string, detached bool, unstaged bool, onAction func(command *GitRepoCommand, action GitRepoAction) error) *GitRepoCommand {
+	category := CategoryGitRepo
+	description := repo.Path
+	if branch != "" {
+		state := branch
+		if unstaged {
+			state += " (unstaged changes)"
+		}
+		description = fmt.Sprintf("%s · %s", state, repo.Path)
+	}
+	actions := []string{string(GitRepoActionOpenEditor), string(GitRepoActionOpenTerminal), string(GitRepoActionReveal), string(GitRepoActionCopyPath)}
+	if remoteURL != "" {
+		actions = append(actions, string(GitRepoActionOpenRemote))
+	}
+	return &GitRepoCommand{
+		Command: Command{
+			TriggerID:   fmt.Sprintf("%s-%s", category, repo.Path),
+			Name:        repo.Name,
+			Description: mo.Some(description),
+			Category:    category,
+			Accepts:     []ArgumentKind{},
+			Actions:     actions,
+		},
+		Path:      repo.Path,
+		Root:      repo.Root,
+		Branch:    branch,
+		Detached:  detached,
+		Unstaged:  unstaged,
+		RemoteURL: remoteURL,
+		onAction:  onAction,
+	}
+}
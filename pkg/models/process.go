@@ -0,0 +1,96 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+const CategoryProcess CommandCategory = "Process"
+
+// ProcessAction is something the launcher can do with a process
+type ProcessAction string
+
+const (
+	// ProcessActionCopyPID copies the PID to the clipboard, it is the trigger so that ending a process is always explicit
+	ProcessActionCopyPID ProcessAction = "copyPid"
+	// ProcessActionTerminate asks the process to exit, it may clean up or ignore the request
+	ProcessActionTerminate ProcessAction = "terminate"
+	// ProcessActionKill ends the process immediately
+	ProcessActionKill ProcessAction = "kill"
+)
+
+// ProcessSignals are the actions that end a process, they are not offered for processes the launcher refuses to signal
+var ProcessSignals = []ProcessAction{ProcessActionTerminate, ProcessActionKill}
+
+// ProcessCommand is a running process, triggering it copies its PID
+type ProcessCommand struct {
+	Command
+	PID         int     `json:"pid"`
+	CommandLine string  `json:"commandLine"`
+	CPUPercent  float64 `json:"cpuPercent"`
+	MemoryBytes uint64  `json:"memoryBytes"`
+	// Ports are the TCP ports the process listens on
+	Ports     []int `json:"ports"`
+	onTrigger func(action ProcessAction) error
+}
+
+func (p *ProcessCommand) GetTriggerID() string {
+	return p.TriggerID
+}
+
+func (p *ProcessCommand) OnTrigger(_ CommandArguments) error {
+	return p.RunAction(ProcessActionCopyPID)
+}
+
+// RunAction runs one of the Actions of the command on the process
+func (p *ProcessCommand) RunAction(action ProcessAction) error {
+	if !lo.Contains(p.Actions, string(action)) {
+		return fmt.Errorf("cannot %s process %d", action, p.PID)
+	}
+	return p.onTrigger(action)
+}
+
+func (p *ProcessCommand) GetMetadata() *Command {
+	return &p.Command
+}
+
+// Keywords lets a process be found by "port 3000", ":3000" or "pid 1234"
+func (p *ProcessCommand) Keywords() []string {
+	keywords := make([]string, 0, len(p.Ports)*2+1)
+	for _, port := range p.Ports {
+		keywords = append(keywords, fmt.Sprintf("port %d", port), fmt.Sprintf(":%d", port))
+	}
+	return append(keywords, "pid "+strconv.Itoa(p.PID))
+}
+
+// NewProcessCommand create the command of a process, onTrigger runs the action on it.
+// signals is nil for processes that must not be signalled, copying the PID is always offered.
+func NewProcessCommand(pid int, name string, commandLine string, cpuPercent float64, memoryBytes uint64, ports []int, signals []ProcessAction, onTrigger func(action ProcessAction) error) *ProcessCommand {
+	category := CategoryProcess
+	description := mo.None[string]()
+	if commandLine != "" {
+		description = mo.Some(commandLine)
+	}
+	if ports == nil {
+		ports = []int{}
+	}
+	return &ProcessCommand{
+		Command: Command{
+			TriggerID:   fmt.Sprintf("%s-%d", category, pid),
+			Name:        name,
+			Description: description,
+			Category:    category,
+			Accepts:     []ArgumentKind{},
+			Actions:     lo.Map(append([]ProcessAction{ProcessActionCopyPID}, signals...), func(action ProcessAction, _ int) string { return string(action) }),
+		},
+		PID:         pid,
+		CommandLine: commandLine,
+		CPUPercent:  cpuPercent,
+		MemoryBytes: memoryBytes,
+		Ports:       ports,
+		onTrigger:   onTrigger,
+	}
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/samber/mo"
+)
+
+const CategoryProject CommandCategory = "Project"
+
+// ProjectCommand reopens a project in the IDE it was last opened with
+type ProjectCommand struct {
+	Command
+	Path string `json:"path"`
+	IDE  string `json:"ide"`
+	// Launcher is the application the project is opened with, empty when the IDE was not found
+	Launcher string `json:"launcher"`
+	// OpenedAt is zero when the IDE does not record when the project was opened
+	OpenedAt time.Time `json:"openedAt"`
+}
+
+func (p *ProjectCommand) GetTriggerID() string {
+	return p.TriggerID
+}
+
+// OnTrigger opens the project folder or workspace file with the IDE
+func (p *ProjectCommand) OnTrigger(_ CommandArguments) error {
+	if p.Launcher == "" {
+		return fmt.Errorf("%s was not found to open '%s'", p.IDE, p.Path)
+	}
+	return openApplication(p.Launcher, []string{p.Path})
+}
+
+func (p *ProjectCommand) GetMetadata() *Command {
+	return &p.Command
+}
+
+func NewProjectCommand(path string, name string, ide string, launcher string, openedAt time.Time) *ProjectCommand {
+	category := CategoryProject
+	return &ProjectCommand{
+		Command: Command{
+			TriggerID:   fmt.Sprintf("%s-%s", category, path),
+			Name:        name,
+			Description: mo.Some(fmt.Sprintf("%s · %s", ide, path)),
+			Category:    category,
+			Accepts:     []ArgumentKind{},
+		},
+		Path:     path,
+		IDE:      ide,
+		Launcher: launcher,
+		OpenedAt: openedAt,
+	}
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"fmt"
+	"time"
+	"watools/pkg/placeholder"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+)
+
+const CategoryQuicklink CommandCategory = "Quicklink"
+
+type QuicklinkCommand struct {
+	Command
+	ID        string `json:"id"`
+	Keyword   string `json:"keyword"`
+	URL       string `json:"url"`
+	Icon      string `json:"icon"`
+	TargetApp string `json:"targetApp"`
+	// HasArgument is computed from the URL template, the UI asks for an argument when it is set
+	HasArgument bool `json:"hasArgument"`
+}
+
+func (q *QuicklinkCommand) GetTriggerID() string {
+	return q.TriggerID
+}
+
+// OnTrigger opens the quicklink with the text as argument, {clipboard} is expanded from the clipboard snapshot
+func (q *QuicklinkCommand) OnTrigger(arguments CommandArguments) error {
+	var clipboard func() (string, error)
+	if snapshot, ok := arguments.Clipboard.Get(); ok {
+		clipboard = func() (string, error) { return snapshot.Text, nil }
+	}
+	return q.Open(arguments.Text, clipboard)
+}
+
+func (q *QuicklinkCommand) GetMetadata() *Command {
+	return &q.Command
+}
+
+// Open expands the URL template and opens it in the target app, or the default handler when none is set
+func (q *QuicklinkCommand) Open(argument string, clipboard func() (string, error)) error {
+	target, err := q.Expand(argument, clipboard)
+	if err != nil {
+		return err
+	}
+	return openURL(target, q.TargetApp)
+}
+
+// Expand returns the URL the quicklink opens for argument
+func (q *QuicklinkCommand) Expand(argument string, clipboard func() (string, error)) (string, error) {
+	return placeholder.ExpandURL(q.URL, placeholder.URLValues{
+		Argument:  argument,
+		Clipboard: clipboard,
+		Now:       time.Now(),
+	})
+}
+
+func NewQuicklinkCommand(name string, keyword string, url string, icon string, targetApp string, id mo.Option[string]) *QuicklinkCommand {
+	category := CategoryQuicklink
+	if id.IsNone() {
+		id = mo.Some(uuid.New().String())
+	}
+	hasArgument := placeholder.HasArgument(url)
+	accepts := []ArgumentKind{}
+	if hasArgument {
+		accepts = append(accepts, ArgumentText)
+	}
+	if placeholder.Has(url, "clipboard") {
+		accepts = append(accepts, ArgumentClipboard)
+	}
+	return &QuicklinkCommand{
+		Command: Command{
+			TriggerID:   fmt.Sprintf("%s-%s-%s", category, name, id.MustGet()),
+			Name:        name,
+			Description: mo.Some(url),
+			Category:    category,
+			Accepts:     accepts,
+		},
+		ID:          id.MustGet(),
+		Keyword:     keyword,
+		URL:         url,
+		Icon:        icon,
+		TargetApp:   targetApp,
+		HasArgument: hasArgument,
+	}
+}
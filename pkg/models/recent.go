@@ -0,0 +1,51 @@
+package models
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/samber/mo"
+)
+
+const CategoryRecentDocument CommandCategory = "RecentDocument"
+
+// RecentDocumentCommand reopens a document from the recent documents of the platform
+type RecentDocumentCommand struct {
+	Command
+	Path     string `json:"path"`
+	MimeType string `json:"mimeType"`
+	// Application is the application that last opened the document, empty when the platform does not record it
+	Application string    `json:"application"`
+	VisitedAt   time.Time `json:"visitedAt"`
+}
+
+func (r *RecentDocumentCommand) GetTriggerID() string {
+	return r.TriggerID
+}
+
+// OnTrigger opens the document with its default application
+func (r *RecentDocumentCommand) OnTrigger(_ CommandArguments) error {
+	return OpenFile(r.Path)
+}
+
+func (r *RecentDocumentCommand) GetMetadata() *Command {
+	return &r.Command
+}
+
+func NewRecentDocumentCommand(path string, mimeType string, application string, visitedAt time.Time) *RecentDocumentCommand {
+	category := CategoryRecentDocument
+	return &RecentDocumentCommand{
+		Command: Command{
+			TriggerID:   fmt.Sprintf("%s-%s", category, path),
+			Name:        filepath.Base(path),
+			Description: mo.Some(filepath.Dir(path)),
+			Category:    category,
+			Accepts:     []ArgumentKind{},
+		},
+		Path:        path,
+		MimeType:    mimeType,
+		Application: application,
+		VisitedAt:   visitedAt,
+	}
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/samber/mo"
+)
+
+const CategoryScript CommandCategory = "Script"
+
+// ScriptMode tells what happens with the output of a script command
+type ScriptMode string
+
+const (
+	// ScriptModeSilent runs the script in the background and discards its output
+	ScriptModeSilent ScriptMode = "silent"
+	// ScriptModeOutput waits for the script and hands stdout, stderr and the exit code to the UI
+	ScriptModeOutput ScriptMode = "output"
+)
+
+type ScriptArgument struct {
+	Placeholder string `json:"placeholder"`
+	Optional    bool   `json:"optional"`
+}
+
+type ScriptResult struct {
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	ExitCode int    `json:"exitCode"`
+}
+
+type ScriptCommand struct {
+	Command
+	Path      string           `json:"path"`
+	Icon      string           `json:"icon"`
+	Mode      ScriptMode       `json:"mode"`
+	Arguments []ScriptArgument `json:"arguments"`
+	run       func(ctx context.Context, script *ScriptCommand, arguments []string) (*ScriptResult, error)
+}
+
+func (s *ScriptCommand) GetTriggerID() string {
+	return s.TriggerID
+}
+
+// OnTrigger runs the script with the text as its first argument
+func (s *ScriptCommand) OnTrigger(arguments CommandArguments) error {
+	_, err := s.Run(context.Background(), arguments.TextValues())
+	return err
+}
+
+func (s *ScriptCommand) GetMetadata() *Command {
+	return &s.Command
+}
+
+// ScriptActionRun runs the script with several arguments and returns its output in output mode
+const ScriptActionRun = "run"
+
+// Run runs the script, the result is nil in silent mode
+func (s *ScriptCommand) Run(ctx context.Context, arguments []string) (*ScriptResult, error) {
+	return s.run(ctx, s, arguments)
+}
+
+func NewScriptCommand(title string, description mo.Option[string], path string, icon string, mode ScriptMode, arguments []ScriptArgument,
+	run func(ctx context.Context, script *ScriptCommand, arguments []string) (*ScriptResult, error)) *ScriptCommand {
+	category := CategoryScript
+	accepts := []ArgumentKind{}
+	if len(arguments) > 0 {
+		accepts = append(accepts, ArgumentText)
+	}
+	return &ScriptCommand{
+		Command: Command{
+			TriggerID:   fmt.Sprintf("%s-%s-%s", category, title, filepath.Base(path)),
+			Name:        title,
+			Description: description,
+			Category:    category,
+			Accepts:     accepts,
+			Actions:     []string{ScriptActionRun},
+		},
+		Path:      path,
+		Icon:      icon,
+		Mode:      mode,
+		Arguments: arguments,
+		run:       run,
+	}
+}
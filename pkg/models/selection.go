@@ -0,0 +1,22 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// SelectionEvent is one launch of a command from the launcher
+type SelectionEvent struct {
+	TriggerID string `json:"triggerId"`
+	// Source is the command source, or the ranking source of the frontend such as "plugin"
+	Source string `json:"source"`
+	// Query is the normalized text typed when the command was selected
+	Query         string    `json:"query"`
+	ClipboardType string    `json:"clipboardType"`
+	SelectedAt    time.Time `json:"selectedAt"`
+}
+
+// NormalizeQuery lowercases a query, trims it and collapses runs of whitespace
+func NormalizeQuery(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"watools/pkg/placeholder"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+)
+
+const CategorySnippet CommandCategory = "Snippet"
+
+// SnippetCommand is a stored text snippet, triggering it renders the body and copies it to the clipboard
+type SnippetCommand struct {
+	Command
+	ID      string   `json:"id"`
+	Keyword string   `json:"keyword"`
+	Body    string   `json:"body"`
+	Tags    []string `json:"tags"`
+	// Prompts are computed from the body, the UI asks for their values before copying
+	Prompts        []placeholder.Prompt `json:"prompts"`
+	writeClipboard func(text string) error
+}
+
+func (s *SnippetCommand) GetTriggerID() string {
+	return s.TriggerID
+}
+
+// OnTrigger copies the snippet with the text as first prompt value, {clipboard} is expanded from the clipboard snapshot
+func (s *SnippetCommand) OnTrigger(arguments CommandArguments) error {
+	var clipboard func() (string, error)
+	if snapshot, ok := arguments.Clipboard.Get(); ok {
+		clipboard = func() (string, error) { return snapshot.Text, nil }
+	}
+	_, err := s.Copy(arguments.TextValues(), clipboard)
+	return err
+}
+
+func (s *SnippetCommand) GetMetadata() *Command {
+	return &s.Command
+}
+
+// SetClipboardWriter sets how Copy writes the rendered snippet
+func (s *SnippetCommand) SetClipboardWriter(writeClipboard func(text string) error) {
+	s.writeClipboard = writeClipboard
+}
+
+// Render expands the body with the prompt values in the order of Prompts
+func (s *SnippetCommand) Render(arguments []string, clipboard func() (string, error)) (placeholder.Snippet, error) {
+	return placeholder.ExpandSnippet(s.Body, placeholder.SnippetValues{
+		Arguments: arguments,
+		Clipboard: clipboard,
+		Now:       time.Now(),
+	})
+}
+
+// Copy renders the snippet and writes the text to the clipboard
+func (s *SnippetCommand) Copy(arguments []string, clipboard func() (string, error)) (placeholder.Snippet, error) {
+	if s.writeClipboard == nil {
+		return placeholder.Snippet{}, fmt.Errorf("clipboard is not writable")
+	}
+	rendered, err := s.Render(arguments, clipboard)
+	if err != nil {
+		return placeholder.Snippet{}, err
+	}
+	if err := s.writeClipboard(rendered.Text); err != nil {
+		return placeholder.Snippet{}, fmt.Errorf("failed to copy snippet %s: %w", s.Name, err)
+	}
+	return rendered, nil
+}
+
+// NormalizeSnippetTags trims tags and drops empty and repeated ones, tags compare case-insensitively
+func NormalizeSnippetTags(tags []string) []string {
+	normalized := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		key := strings.ToLower(tag)
+		if tag == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// SnippetActionCopy copies the snippet rendered with the values of its prompts and returns the text and the {cursor} offset
+const SnippetActionCopy = "copy"
+
+func NewSnippetCommand(name string, keyword string, body string, tags []string, id mo.Option[string]) *SnippetCommand {
+	category := CategorySnippet
+	if id.IsNone() {
+		id = mo.Some(uuid.New().String())
+	}
+	prompts := placeholder.Prompts(body)
+	accepts := []ArgumentKind{}
+	if len(prompts) > 0 {
+		accepts = append(accepts, ArgumentText)
+	}
+	if placeholder.Has(body, "clipboard") {
+		accepts = append(accepts, ArgumentClipboard)
+	}
+	if prompts == nil {
+		prompts = []placeholder.Prompt{}
+	}
+	return &SnippetCommand{
+		Command: Command{
+			TriggerID:   fmt.Sprintf("%s-%s-%s", category, name, id.MustGet()),
+			Name:        name,
+			Description: mo.Some(snippetPreview(body)),
+			Category:    category,
+			Accepts:     accepts,
+			Actions:     []string{SnippetActionCopy},
+		},
+		ID:      id.MustGet(),
+		Keyword: keyword,
+		Body:    body,
+		Tags:    NormalizeSnippetTags(tags),
+		Prompts: prompts,
+	}
+}
+
+// snippetPreview is the first line of the body shortened for the result list
+func snippetPreview(body string) string {
+	preview, _, _ := strings.Cut(strings.TrimSpace(body), "\n")
+	runes := []rune(preview)
+	if len(runes) > 80 {
+		return string(runes[:80]) + "…"
+	}
+	return preview
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/samber/mo"
+)
+
+const CategorySSHHost CommandCategory = "SSHHost"
+
+// SSHHostCommand connects to a host with ssh in a terminal emulator
+type SSHHostCommand struct {
+	Command
+	// Alias is passed to ssh, which applies the settings of the config for it
+	Alias    string `json:"alias"`
+	HostName string `json:"hostName"`
+	User     string `json:"user"`
+	// Port is 0 when ssh picks it
+	Port int `json:"port"`
+	// Configured is false for hosts only found in known_hosts
+	Configured bool `json:"configured"`
+	Known      bool `json:"known"`
+}
+
+func (s *SSHHostCommand) GetTriggerID() string {
+	return s.TriggerID
+}
+
+// OnTrigger opens the connection, configured hosts get their port and user from the config
+func (s *SSHHostCommand) OnTrigger(_ CommandArguments) error {
+	if s.Configured {
+		return openSSH(s.Alias, 0)
+	}
+	return openSSH(s.Alias, s.Port)
+}
+
+func (s *SSHHostCommand) GetMetadata() *Command {
+	return &s.Command
+}
+
+// sshArgs returns the ssh command line for host, port 0 leaves the port to ssh
+func sshArgs(host string, port int) []string {
+	if port == 0 {
+		return []string{"ssh", host}
+	}
+	return []string{"ssh", "-p", strconv.Itoa(port), host}
+}
+
+// NewSSHHostCommand create the command of a host, destination is shown as description such as "deploy@example.com:2222"
+func NewSSHHostCommand(alias string, hostName string, user string, port int, destination string, configured bool, known bool) *SSHHostCommand {
+	category := CategorySSHHost
+	triggerID := fmt.Sprintf("%s-%s", category, alias)
+	if port != 0 {
+		triggerID = fmt.Sprintf("%s:%d", triggerID, port)
+	}
+	return &SSHHostCommand{
+		Command: Command{
+			TriggerID:   triggerID,
+			Name:        alias,
+			Description: mo.Some(destination),
+			Category:    category,
+			Accepts:     []ArgumentKind{},
+		},
+		Alias:      alias,
+		HostName:   hostName,
+		User:       user,
+		Port:       port,
+		Configured: configured,
+		Known:      known,
+	}
+}
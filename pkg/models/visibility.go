@@ -0,0 +1,32 @@
+package models
+
+import (
+	"github.com/samber/mo"
+)
+
+// CommandVisibility is how the user wants a command to be listed
+type CommandVisibility struct {
+	Source CommandCategory `json:"source"`
+	// Key identifies the command across rescans, see VisibilityKey
+	Key    string `json:"key"`
+	Hidden bool   `json:"hidden"`
+	// PinOrder is set for pinned commands, they are listed first in ascending order
+	PinOrder mo.Option[int] `json:"pinOrder"`
+}
+
+// IsDefault reports whether the visibility changes nothing, such states are not stored
+func (v CommandVisibility) IsDefault() bool {
+	return !v.Hidden && v.PinOrder.IsAbsent()
+}
+
+// VisibilityKey returns the key the visibility of runner is stored under.
+// Applications get new IDs when they are rescanned, so they are keyed by path and action.
+func VisibilityKey(runner CommandRunner) string {
+	if application, ok := runner.(*ApplicationCommand); ok {
+		if application.ActionID != "" {
+			return application.Path + "#" + application.ActionID
+		}
+		return application.Path
+	}
+	return runner.GetTriggerID()
+}
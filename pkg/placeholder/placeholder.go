@@ -0,0 +1,161 @@
+package placeholder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Placeholder is one {name} or {name:option} token of a template
+type Placeholder struct {
+	Name   string
+	Option string
+	// Offset is the byte offset of the opening brace in the template
+	Offset int
+}
+
+// Resolver returns the text for a placeholder, ok=false leaves the token in the output unchanged
+type Resolver func(p Placeholder) (text string, ok bool, err error)
+
+// Expand replaces every placeholder resolved by resolve.
+// Names are case-insensitive, braces that do not form a placeholder are copied as they are.
+func Expand(template string, resolve Resolver) (string, error) {
+	var out strings.Builder
+	if err := expandTo(&out, template, resolve); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// expandTo writes the expansion of template to out, resolve may read out to know its position in the result
+func expandTo(out *strings.Builder, template string, resolve Resolver) error {
+	rest := template
+	offset := 0
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			out.WriteString(rest)
+			return nil
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			out.WriteString(rest)
+			return nil
+		}
+		end += start
+		token := rest[start : end+1]
+		p, valid := parse(token, offset+start)
+		if !valid {
+			// the brace may open a placeholder further on, as in "{{query}}"
+			out.WriteString(rest[:start+1])
+			offset += start + 1
+			rest = rest[start+1:]
+			continue
+		}
+		out.WriteString(rest[:start])
+		text, ok, err := resolve(p)
+		if err != nil {
+			return fmt.Errorf("failed to expand %s: %w", token, err)
+		}
+		if ok {
+			out.WriteString(text)
+		} else {
+			out.WriteString(token)
+		}
+		offset += end + 1
+		rest = rest[end+1:]
+	}
+}
+
+// Names returns the names of the placeholders in template in order of appearance
+func Names(template string) []string {
+	var names []string
+	_, _ = Expand(template, func(p Placeholder) (string, bool, error) {
+		names = append(names, p.Name)
+		return "", false, nil
+	})
+	return names
+}
+
+// Has reports whether template contains a placeholder with one of names
+func Has(template string, names ...string) bool {
+	for _, name := range Names(template) {
+		for _, candidate := range names {
+			if name == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parse(token string, offset int) (Placeholder, bool) {
+	body := token[1 : len(token)-1]
+	name, option, _ := strings.Cut(body, ":")
+	if name == "" {
+		return Placeholder{}, false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return Placeholder{}, false
+		}
+	}
+	return Placeholder{Name: strings.ToLower(name), Option: option, Offset: offset}, true
+}
+
+// dateTokens maps the date format tokens accepted in {date:...} to Go layout elements, longest first
+var dateTokens = []struct {
+	token  string
+	layout string
+}{
+	{"yyyy", "2006"},
+	{"yy", "06"},
+	{"MMMM", "January"},
+	{"MMM", "Jan"},
+	{"MM", "01"},
+	{"dddd", "Monday"},
+	{"ddd", "Mon"},
+	{"dd", "02"},
+	{"HH", "15"},
+	{"hh", "03"},
+	{"mm", "04"},
+	{"ss", "05"},
+}
+
+// FormatDate formats t with a format such as "yyyy-MM-dd HH:mm", other characters are copied
+func FormatDate(t time.Time, format string) string {
+	var out strings.Builder
+	for i := 0; i < len(format); {
+		matched := false
+		for _, token := range dateTokens {
+			if strings.HasPrefix(format[i:], token.token) {
+				out.WriteString(t.Format(token.layout))
+				i += len(token.token)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			out.WriteByte(format[i])
+			i++
+		}
+	}
+	return out.String()
+}
+
+// ResolveDate resolves the date placeholders {date}, {time}, {datetime} and {date:format}
+func ResolveDate(p Placeholder, now time.Time) (string, bool) {
+	switch p.Name {
+	case "date":
+		if p.Option != "" {
+			return FormatDate(now, p.Option), true
+		}
+		return now.Format("2006-01-02"), true
+	case "time":
+		return now.Format("15:04"), true
+	case "datetime":
+		return now.Format("2006-01-02 15:04"), true
+	default:
+		return "", false
+	}
+}
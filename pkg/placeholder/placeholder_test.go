@@ -0,0 +1,155 @@
+package placeholder
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestExpand(t *testing.T) {
+	t.Parallel()
+
+	resolve := func(p Placeholder) (string, bool, error) {
+		switch p.Name {
+		case "name":
+			return "world", true, nil
+		case "opt":
+			return "[" + p.Option + "]", true, nil
+		default:
+			return "", false, nil
+		}
+	}
+	testCases := map[string]string{
+		"hello {name}":         "hello world",
+		"hello {NAME}!":        "hello world!",
+		"{opt:a:b} {opt}":      "[a:b] []",
+		"{unknown} {name}":     "{unknown} world",
+		"{{name}}":             "{world}",
+		"{ name } {} {name":    "{ name } {} {name",
+		"func() { return 1 }":  "func() { return 1 }",
+		"no placeholders here": "no placeholders here",
+	}
+	for template, want := range testCases {
+		got, err := Expand(template, resolve)
+		if err != nil {
+			t.Fatalf("Expand(%q) error = %v", template, err)
+		}
+		if got != want {
+			t.Fatalf("Expand(%q) = %q, want %q", template, got, want)
+		}
+	}
+
+	_, err := Expand("{name}", func(p Placeholder) (string, bool, error) {
+		return "", false, errors.New("boom")
+	})
+	if err == nil {
+		t.Fatalf("Expand() did not return the resolver error")
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC)
+	testCases := map[string]string{
+		"yyyy-MM-dd":          "2024-03-05",
+		"dd.MM.yy HH:mm:ss":   "05.03.24 14:07:09",
+		"ddd, dd MMM yyyy":    "Tue, 05 Mar 2024",
+		"dddd MMMM":           "Tuesday March",
+		"week of yyyy (hh)":   "week of 2024 (02)",
+		"literal text / 2024": "literal text / 2024",
+	}
+	for format, want := range testCases {
+		if got := FormatDate(now, format); got != want {
+			t.Fatalf("FormatDate(%q) = %q, want %q", format, got, want)
+		}
+	}
+}
+
+func TestExpandURL(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC)
+	clipboard := func() (string, error) { return " a&b c \n", nil }
+	testCases := []struct {
+		template string
+		argument string
+		want     string
+	}{
+		{"pkg.go.dev/search?q={query}", "http client", "https://pkg.go.dev/search?q=http+client"},
+		{"jira.corp/browse/{query}", "PROJ 12/3", "https://jira.corp/browse/PROJ%2012%2F3"},
+		{"https://grafana/d/x?var-host={argument}&from={date:yyyy-MM-dd}", "web-1", "https://grafana/d/x?var-host=web-1&from=2024-03-05"},
+		{"https://translate.example/#en/de/{clipboard}", "", "https://translate.example/#en/de/a%26b+c"},
+		{"https://example.com/{argument:latest}", "", "https://example.com/latest"},
+		{"localhost:3000/{query}", "x", "https://localhost:3000/x"},
+		{"mailto:team@example.com?subject={query}", "hi there", "mailto:team@example.com?subject=hi+there"},
+		{"https://example.com/{unknown}", "", "https://example.com/{unknown}"},
+	}
+	for _, testCase := range testCases {
+		got, err := ExpandURL(testCase.template, URLValues{Argument: testCase.argument, Clipboard: clipboard, Now: now})
+		if err != nil {
+			t.Fatalf("ExpandURL(%q) error = %v", testCase.template, err)
+		}
+		if got != testCase.want {
+			t.Fatalf("ExpandURL(%q) = %q, want %q", testCase.template, got, testCase.want)
+		}
+	}
+
+	if _, err := ExpandURL("https://example.com/{clipboard}", URLValues{Now: now}); err == nil {
+		t.Fatalf("ExpandURL() without a clipboard succeeded")
+	}
+	if !HasArgument("https://example.com/?q={Query}") || HasArgument("https://example.com/{date}") {
+		t.Fatalf("HasArgument() misdetected the argument placeholder")
+	}
+}
+
+func TestPrompts(t *testing.T) {
+	t.Parallel()
+
+	got := Prompts("Hi {argument:Name}, {query} {argument:Team=core} {argument:Name=you} {date}")
+	want := []Prompt{{Name: "Name", Default: "you"}, {Name: ""}, {Name: "Team", Default: "core"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Prompts() = %#v, want %#v", got, want)
+	}
+}
+
+func TestExpandSnippet(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC)
+	clipboard := func() (string, error) { return "a&b\n", nil }
+	testCases := []struct {
+		body       string
+		arguments  []string
+		want       string
+		wantCursor int
+	}{
+		{"Dear {argument:Name},\n{cursor}\nBest", []string{"Ann"}, "Dear Ann,\n\nBest", 10},
+		{"{argument:Who=team} and {argument:Who}", nil, "team and team", -1},
+		{"ticket: {clipboard}", nil, "ticket: a&b\n", -1},
+		{"{date:dd.MM.yyyy} {time} é{cursor}x{cursor}", nil, "05.03.2024 14:07 éx", 18},
+		{"{unknown} {query}", []string{"x"}, "{unknown} x", -1},
+	}
+	for _, testCase := range testCases {
+		got, err := ExpandSnippet(testCase.body, SnippetValues{Arguments: testCase.arguments, Clipboard: clipboard, Now: now})
+		if err != nil {
+			t.Fatalf("ExpandSnippet(%q) error = %v", testCase.body, err)
+		}
+		if got.Text != testCase.want || got.Cursor != testCase.wantCursor {
+			t.Fatalf("ExpandSnippet(%q) = %q at %d, want %q at %d", testCase.body, got.Text, got.Cursor, testCase.want, testCase.wantCursor)
+		}
+	}
+
+	got, err := ExpandSnippet("{uuid} {uuid}", SnippetValues{Now: now})
+	if err != nil {
+		t.Fatalf("ExpandSnippet() error = %v", err)
+	}
+	if !regexp.MustCompile(`^[0-9a-f-]{36} [0-9a-f-]{36}$`).MatchString(got.Text) || got.Text[:36] == got.Text[37:] {
+		t.Fatalf("ExpandSnippet() = %q, want two different uuids", got.Text)
+	}
+	if _, err := ExpandSnippet("{clipboard}", SnippetValues{Now: now}); err == nil {
+		t.Fatalf("ExpandSnippet() without a clipboard succeeded")
+	}
+}
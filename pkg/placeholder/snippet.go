@@ -0,0 +1,110 @@
+package placeholder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Prompt is an argument a snippet asks for before it is rendered
+type Prompt struct {
+	// Name is empty for the unnamed {argument}
+	Name    string `json:"name"`
+	Default string `json:"default"`
+}
+
+// SnippetValues are the values available to a snippet body
+type SnippetValues struct {
+	// Arguments are the prompt values in the order Prompts returns them, empty values fall back to the prompt default
+	Arguments []string
+	// Clipboard is only called when the body contains {clipboard}
+	Clipboard func() (string, error)
+	Now       time.Time
+}
+
+// Snippet is a rendered snippet body
+type Snippet struct {
+	Text string `json:"text"`
+	// Cursor is the offset in runes of the first {cursor}, -1 when the body has none
+	Cursor int `json:"cursor"`
+}
+
+// Prompts returns the argument prompts of a snippet body in order of first appearance.
+// {argument:Name} and {argument:Name=default} are named prompts, every occurrence of a name shares one value.
+func Prompts(body string) []Prompt {
+	var prompts []Prompt
+	_, _ = Expand(body, func(p Placeholder) (string, bool, error) {
+		if !isArgumentName(p.Name) {
+			return "", false, nil
+		}
+		prompt := parsePrompt(p.Option)
+		for i := range prompts {
+			if prompts[i].Name == prompt.Name {
+				if prompts[i].Default == "" {
+					prompts[i].Default = prompt.Default
+				}
+				return "", false, nil
+			}
+		}
+		prompts = append(prompts, prompt)
+		return "", false, nil
+	})
+	return prompts
+}
+
+// ExpandSnippet renders a snippet body.
+// Besides the argument prompts it expands {clipboard}, {uuid}, {cursor} and the date placeholders, unknown placeholders are kept.
+func ExpandSnippet(body string, values SnippetValues) (Snippet, error) {
+	prompts := Prompts(body)
+	arguments := make(map[string]string, len(prompts))
+	for i, prompt := range prompts {
+		value := ""
+		if i < len(values.Arguments) {
+			value = values.Arguments[i]
+		}
+		if value == "" {
+			value = prompt.Default
+		}
+		arguments[prompt.Name] = value
+	}
+
+	var out strings.Builder
+	cursor := -1
+	err := expandTo(&out, body, func(p Placeholder) (string, bool, error) {
+		switch {
+		case isArgumentName(p.Name):
+			return arguments[parsePrompt(p.Option).Name], true, nil
+		case p.Name == "clipboard":
+			if values.Clipboard == nil {
+				return "", false, fmt.Errorf("clipboard is not available")
+			}
+			text, err := values.Clipboard()
+			if err != nil {
+				return "", false, err
+			}
+			return text, true, nil
+		case p.Name == "uuid":
+			return uuid.New().String(), true, nil
+		case p.Name == "cursor":
+			if cursor < 0 {
+				cursor = utf8.RuneCountInString(out.String())
+			}
+			return "", true, nil
+		default:
+			text, ok := ResolveDate(p, values.Now)
+			return text, ok, nil
+		}
+	})
+	if err != nil {
+		return Snippet{}, err
+	}
+	return Snippet{Text: out.String(), Cursor: cursor}, nil
+}
+
+func parsePrompt(option string) Prompt {
+	name, defaultValue, _ := strings.Cut(option, "=")
+	return Prompt{Name: strings.TrimSpace(name), Default: defaultValue}
+}
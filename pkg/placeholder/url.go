@@ -0,0 +1,88 @@
+package placeholder
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ArgumentNames are the placeholder names replaced by the text typed after a quicklink
+var ArgumentNames = []string{"query", "argument"}
+
+// schemePattern matches a URL scheme, "host:8080" is a port and not a scheme
+var schemePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:([^0-9]|$)`)
+
+// URLValues are the values available to a URL template
+type URLValues struct {
+	Argument string
+	// Clipboard is only called when the template contains {clipboard}
+	Clipboard func() (string, error)
+	Now       time.Time
+}
+
+// ExpandURL expands a URL template such as "pkg.go.dev/search?q={query}".
+// Values are query-escaped after "?" or "#" and path-escaped before, {argument:default} falls back to default when the argument is empty.
+// Templates without a scheme are opened over https.
+func ExpandURL(template string, values URLValues) (string, error) {
+	template = strings.TrimSpace(template)
+	if template == "" {
+		return "", fmt.Errorf("url template is empty")
+	}
+	queryStart := strings.IndexAny(template, "?#")
+
+	expanded, err := Expand(template, func(p Placeholder) (string, bool, error) {
+		var text string
+		switch {
+		case isArgumentName(p.Name):
+			text = values.Argument
+			if text == "" {
+				text = p.Option
+			}
+		case p.Name == "clipboard":
+			if values.Clipboard == nil {
+				return "", false, fmt.Errorf("clipboard is not available")
+			}
+			clipboardText, err := values.Clipboard()
+			if err != nil {
+				return "", false, err
+			}
+			text = strings.TrimSpace(clipboardText)
+		default:
+			dateText, ok := ResolveDate(p, values.Now)
+			if !ok {
+				return "", false, nil
+			}
+			text = dateText
+		}
+		if queryStart >= 0 && p.Offset > queryStart {
+			return url.QueryEscape(text), true, nil
+		}
+		return url.PathEscape(text), true, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if !schemePattern.MatchString(expanded) {
+		expanded = "https://" + expanded
+	}
+	if _, err := url.Parse(expanded); err != nil {
+		return "", fmt.Errorf("invalid url '%s': %w", expanded, err)
+	}
+	return expanded, nil
+}
+
+// HasArgument reports whether a URL template takes an argument
+func HasArgument(template string) bool {
+	return Has(template, ArgumentNames...)
+}
+
+func isArgumentName(name string) bool {
+	for _, candidate := range ArgumentNames {
+		if name == candidate {
+			return true
+		}
+	}
+	return false
+}
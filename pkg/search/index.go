@@ -0,0 +1,190 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Document is a searchable item, ID must be unique within its source
+type Document struct {
+	ID     string
+	Source string
+	Title  string
+	// Keywords are aliases and keywords set by the user, typing one exactly ranks the document first
+	Keywords    []string
+	Description string
+}
+
+// FieldKind tells which text of a document matched
+type FieldKind string
+
+const (
+	FieldTitle       FieldKind = "title"
+	FieldKeyword     FieldKind = "keyword"
+	FieldDescription FieldKind = "description"
+)
+
+const (
+	weightKeyword     = 0.9
+	weightDescription = 0.4
+	bonusExactKeyword = 1000
+	// maxDescriptionRuns keeps scattered subsequence matches in long descriptions out of the results
+	maxDescriptionRuns = 2
+)
+
+// Result is a matched document with the field that matched best
+type Result struct {
+	Document
+	Score float64
+	Field FieldKind
+	// Text is the matched text, Highlights are rune offsets into it
+	Text       string
+	Highlights []Range
+}
+
+type indexedDocument struct {
+	Document
+	title       Field
+	keywords    []Field
+	description Field
+}
+
+// Index is an in-memory search index over documents grouped by source
+type Index struct {
+	mu      sync.RWMutex
+	sources map[string]map[string]*indexedDocument
+}
+
+func NewIndex() *Index {
+	return &Index{sources: make(map[string]map[string]*indexedDocument)}
+}
+
+// Replace sets the documents of a source.
+// Documents whose texts did not change keep their prepared fields, so only new and edited documents are tokenized.
+func (x *Index) Replace(source string, documents []Document) {
+	x.mu.RLock()
+	previous := x.sources[source]
+	x.mu.RUnlock()
+
+	next := make(map[string]*indexedDocument, len(documents))
+	for _, document := range documents {
+		document.Source = source
+		if old, ok := previous[document.ID]; ok && sameTexts(old.Document, document) {
+			next[document.ID] = old
+			continue
+		}
+		next[document.ID] = prepare(document)
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.sources[source] = next
+}
+
+// Remove drops every document of a source
+func (x *Index) Remove(source string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	delete(x.sources, source)
+}
+
+// Len returns the number of indexed documents
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	count := 0
+	for _, documents := range x.sources {
+		count += len(documents)
+	}
+	return count
+}
+
+// Search returns at most limit documents matching query, best first. A limit <= 0 returns every match.
+func (x *Index) Search(query string, limit int) []Result {
+	if len(normalizeQuery(query)) == 0 {
+		return []Result{}
+	}
+	x.mu.RLock()
+	results := make([]Result, 0)
+	for _, documents := range x.sources {
+		for _, document := range documents {
+			if result, ok := document.match(query); ok {
+				results = append(results, result)
+			}
+		}
+	}
+	x.mu.RUnlock()
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if len(results[i].Title) != len(results[j].Title) {
+			return len(results[i].Title) < len(results[j].Title)
+		}
+		if results[i].Title != results[j].Title {
+			return results[i].Title < results[j].Title
+		}
+		return results[i].Source+results[i].ID < results[j].Source+results[j].ID
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+func prepare(document Document) *indexedDocument {
+	indexed := &indexedDocument{
+		Document:    document,
+		title:       NewField(document.Title),
+		description: NewField(document.Description),
+	}
+	for _, keyword := range document.Keywords {
+		if keyword != "" {
+			indexed.keywords = append(indexed.keywords, NewField(keyword))
+		}
+	}
+	return indexed
+}
+
+func sameTexts(a Document, b Document) bool {
+	if a.Title != b.Title || a.Description != b.Description || len(a.Keywords) != len(b.Keywords) {
+		return false
+	}
+	for i := range a.Keywords {
+		if a.Keywords[i] != b.Keywords[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *indexedDocument) match(query string) (Result, bool) {
+	result := Result{Document: d.Document}
+	found := false
+	consider := func(kind FieldKind, field Field, score float64, highlights []Range) {
+		if !found || score > result.Score {
+			result.Score, result.Field, result.Text, result.Highlights = score, kind, field.Text, highlights
+			found = true
+		}
+	}
+
+	if score, highlights, ok := Match(d.title, query); ok {
+		consider(FieldTitle, d.title, score, highlights)
+	}
+	trimmed := strings.TrimSpace(query)
+	for _, keyword := range d.keywords {
+		if strings.EqualFold(keyword.Text, trimmed) {
+			consider(FieldKeyword, keyword, bonusExactKeyword, []Range{{Start: 0, End: len([]rune(keyword.Text))}})
+			continue
+		}
+		if score, highlights, ok := Match(keyword, query); ok {
+			consider(FieldKeyword, keyword, score*weightKeyword, highlights)
+		}
+	}
+	if score, highlights, ok := Match(d.description, query); ok && len(highlights) <= maxDescriptionRuns {
+		consider(FieldDescription, d.description, score*weightDescription, highlights)
+	}
+	return result, found
+}
@@ -0,0 +1,206 @@
+package search
+
+import (
+	"math"
+)
+
+// Range is a highlighted run of a text, Start and End are rune offsets with End exclusive
+type Range struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+const (
+	scoreMatch        = 16
+	scoreBoundary     = 24
+	scoreConsecutive  = 16
+	scoreFirstUnit    = 8
+	penaltyPartial    = 4
+	penaltyLeadingGap = 1
+	penaltyGapStart   = 3
+	penaltyGap        = 1
+	penaltyQuerySkip  = 2
+	bonusPrefix       = 100
+	bonusExact        = 200
+	// maxUnits bounds the work spent on long texts such as descriptions
+	maxUnits = 256
+)
+
+// step is how the matcher reached a state, it is followed back to find the highlights
+type step struct {
+	prev    int32
+	matched bool
+	partial bool
+}
+
+// Match scores how well query matches field.
+// Runes of the query are matched in order against the runes of the field, and Han characters also match
+// their pinyin or a prefix of it, so "wx", "weixin" and "微信" all match "微信".
+// Matches at word boundaries, consecutive runs, prefixes and whole-text matches score higher.
+func Match(field Field, query string) (score float64, highlights []Range, ok bool) {
+	q := normalizeQuery(query)
+	if len(q) == 0 {
+		return 0, nil, false
+	}
+	units := field.units
+	if len(units) > maxUnits {
+		units = units[:maxUnits]
+	}
+	n, m := len(units), len(q)
+
+	// a state is (units consumed, query runes consumed, whether the last unit matched)
+	index := func(i, j, c int) int { return (i*(m+1)+j)*2 + c }
+	size := (n + 1) * (m + 1) * 2
+	scores := make([]float64, size)
+	steps := make([]step, size)
+	for k := range scores {
+		scores[k] = math.Inf(-1)
+	}
+	scores[index(0, 0, 0)] = 0
+
+	relax := func(from int, to int, value float64, matched bool, partial bool) {
+		if value > scores[to] {
+			scores[to] = value
+			steps[to] = step{prev: int32(from), matched: matched, partial: partial}
+		}
+	}
+
+	best, bestState := math.Inf(-1), -1
+	for i := 0; i <= n; i++ {
+		for j := 0; j <= m; j++ {
+			for c := 0; c < 2; c++ {
+				from := index(i, j, c)
+				value := scores[from]
+				if math.IsInf(value, -1) {
+					continue
+				}
+				if j == m {
+					// shorter texts win ties
+					if final := value - 0.1*float64(n-i); final > best {
+						best, bestState = final, from
+					}
+					continue
+				}
+				if isSeparator(q[j]) {
+					relax(from, index(i, j+1, c), value-penaltyQuerySkip, false, false)
+				}
+				if i == n {
+					continue
+				}
+				u := units[i]
+
+				skip := 0.0
+				switch {
+				case j == 0 && !u.separator:
+					skip = penaltyLeadingGap
+				case j > 0 && c == 1:
+					skip = penaltyGapStart
+				case j > 0:
+					skip = penaltyGap
+				}
+				relax(from, index(i+1, j, 0), value-skip, false, false)
+
+				gain := func(consumed int) float64 {
+					g := float64(scoreMatch * consumed)
+					if u.boundary {
+						g += scoreBoundary
+					}
+					if c == 1 {
+						g += scoreConsecutive
+					}
+					if i == 0 {
+						g += scoreFirstUnit
+					}
+					return g
+				}
+				if q[j] == u.r {
+					relax(from, index(i+1, j+1, 1), value+gain(1), true, false)
+				}
+				for _, syllable := range u.syllables {
+					for k := 1; k <= len(syllable) && j+k <= m; k++ {
+						if rune(syllable[k-1]) != q[j+k-1] {
+							break
+						}
+						partial := k < len(syllable)
+						g := gain(k)
+						if partial {
+							g -= penaltyPartial
+						}
+						relax(from, index(i+1, j+k, 1), value+g, true, partial)
+					}
+				}
+			}
+		}
+	}
+	if bestState < 0 {
+		return 0, nil, false
+	}
+
+	matched := make([]bool, n)
+	partial := false
+	for state := bestState; state != index(0, 0, 0); {
+		s := steps[state]
+		unitIndex := state/2/(m+1) - 1
+		if s.matched {
+			matched[unitIndex] = true
+			partial = partial || s.partial
+		}
+		state = int(s.prev)
+	}
+	return best + tierBonus(units, matched, partial), toRanges(matched), true
+}
+
+// tierBonus rewards matches of the whole text and of its beginning without gaps
+func tierBonus(units []unit, matched []bool, partial bool) float64 {
+	first, last := -1, -1
+	for i, ok := range matched {
+		if ok {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	if first < 0 {
+		return 0
+	}
+	leading, gaps, trailing := false, false, false
+	for i, u := range units {
+		if u.separator || matched[i] {
+			continue
+		}
+		switch {
+		case i < first:
+			leading = true
+		case i > last:
+			trailing = true
+		default:
+			gaps = true
+		}
+	}
+	switch {
+	case leading || gaps:
+		return 0
+	case !trailing && !partial:
+		return bonusExact
+	case !trailing:
+		return bonusExact - bonusPrefix/2
+	default:
+		return bonusPrefix
+	}
+}
+
+func toRanges(matched []bool) []Range {
+	var ranges []Range
+	for i, ok := range matched {
+		if !ok {
+			continue
+		}
+		if len(ranges) > 0 && ranges[len(ranges)-1].End == i {
+			ranges[len(ranges)-1].End = i + 1
+			continue
+		}
+		ranges = append(ranges, Range{Start: i, End: i + 1})
+	}
+	return ranges
+}
@@ -0,0 +1,148 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokens(t *testing.T) {
+	t.Parallel()
+
+	got := Tokens("VSCodium 2024 微信App-beta camelCase")
+	want := []string{"VSCodium", "2024", "微", "信", "App", "beta", "camel", "Case"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Tokens() = %q, want %q", got, want)
+	}
+}
+
+func TestPinyin(t *testing.T) {
+	t.Parallel()
+
+	full, initials := Pinyin("微信 App")
+	if full != "weixinapp" || initials != "wxapp" {
+		t.Fatalf("Pinyin() = %q, %q, want %q, %q", full, initials, "weixinapp", "wxapp")
+	}
+	if syllables := Syllables('重'); !contains(syllables, "zhong") || !contains(syllables, "chong") {
+		t.Fatalf("Syllables('重') = %v, want both readings", syllables)
+	}
+	if syllables := Syllables('a'); syllables != nil {
+		t.Fatalf("Syllables('a') = %v, want nil", syllables)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		text       string
+		query      string
+		highlights []Range
+	}{
+		{"Visual Studio Code", "vsc", []Range{{0, 1}, {7, 8}, {14, 15}}},
+		{"Visual Studio Code", "studio code", []Range{{7, 13}, {14, 18}}},
+		{"Calculator", "clt", []Range{{0, 1}, {2, 3}, {7, 8}}},
+		{"微信", "wx", []Range{{0, 2}}},
+		{"微信", "weixin", []Range{{0, 2}}},
+		{"微信", "weix", []Range{{0, 2}}},
+		{"网易云音乐", "yinyue", []Range{{3, 5}}},
+		{"重庆地图", "chongqing", []Range{{0, 2}}},
+		{"Node.js", "node.js", []Range{{0, 7}}},
+	}
+	for _, tc := range cases {
+		_, highlights, ok := Match(NewField(tc.text), tc.query)
+		if !ok {
+			t.Fatalf("Match(%q, %q) did not match", tc.text, tc.query)
+		}
+		if !reflect.DeepEqual(highlights, tc.highlights) {
+			t.Fatalf("Match(%q, %q) highlights = %v, want %v", tc.text, tc.query, highlights, tc.highlights)
+		}
+	}
+	if _, _, ok := Match(NewField("Safari"), "xyz"); ok {
+		t.Fatalf("Match() matched unrelated text")
+	}
+	if _, _, ok := Match(NewField("Safari"), "  "); ok {
+		t.Fatalf("Match() matched an empty query")
+	}
+}
+
+func TestMatchRanksPrefixesAndBoundaries(t *testing.T) {
+	t.Parallel()
+
+	score := func(text string, query string) float64 {
+		s, _, ok := Match(NewField(text), query)
+		if !ok {
+			t.Fatalf("Match(%q, %q) did not match", text, query)
+		}
+		return s
+	}
+	if exact, prefix := score("Code", "code"), score("Code Writer", "code"); exact <= prefix {
+		t.Fatalf("exact match scored %v, prefix %v", exact, prefix)
+	}
+	if prefix, word := score("Code Writer", "code"), score("Visual Studio Code", "code"); prefix <= word {
+		t.Fatalf("prefix match scored %v, later word %v", prefix, word)
+	}
+	if acronym, scattered := score("System Settings", "ss"), score("Visual Studio Code", "ss"); acronym <= scattered {
+		t.Fatalf("acronym scored %v, scattered match %v", acronym, scattered)
+	}
+}
+
+func TestIndexSearch(t *testing.T) {
+	t.Parallel()
+
+	index := NewIndex()
+	index.Replace("Application", []Document{
+		{ID: "code", Title: "Visual Studio Code"},
+		{ID: "codium", Title: "VSCodium", Keywords: []string{"vsc"}},
+		{ID: "wechat", Title: "微信"},
+		{ID: "firefox", Title: "Firefox", Description: "Browse the World Wide Web"},
+	})
+	index.Replace("Operation", []Document{{ID: "lock", Title: "Lock Screen"}})
+
+	results := index.Search("vsc", 0)
+	if len(results) != 2 || results[0].ID != "codium" || results[0].Field != FieldKeyword {
+		t.Fatalf("Search(vsc) = %+v, want the exact keyword first", results)
+	}
+	results = index.Search("wx", 10)
+	if len(results) != 1 || results[0].ID != "wechat" || results[0].Source != "Application" {
+		t.Fatalf("Search(wx) = %+v", results)
+	}
+	results = index.Search("web", 10)
+	if len(results) != 1 || results[0].Field != FieldDescription || results[0].Text != "Browse the World Wide Web" {
+		t.Fatalf("Search(web) = %+v", results)
+	}
+	if results := index.Search("c", 1); len(results) != 1 {
+		t.Fatalf("Search() returned %d results, want limit 1", len(results))
+	}
+	if results := index.Search("", 10); len(results) != 0 {
+		t.Fatalf("Search() of an empty query = %+v", results)
+	}
+}
+
+func TestIndexReplaceIsIncremental(t *testing.T) {
+	t.Parallel()
+
+	index := NewIndex()
+	index.Replace("Application", []Document{{ID: "a", Title: "Alpha"}, {ID: "b", Title: "Beta"}})
+	before := index.sources["Application"]
+
+	index.Replace("Application", []Document{{ID: "a", Title: "Alpha"}, {ID: "b", Title: "Beta 2"}, {ID: "c", Title: "Gamma"}})
+	after := index.sources["Application"]
+	if after["a"] != before["a"] {
+		t.Fatalf("unchanged document was prepared again")
+	}
+	if after["b"] == before["b"] || after["b"].Title != "Beta 2" {
+		t.Fatalf("edited document was not prepared again")
+	}
+	if index.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3", index.Len())
+	}
+
+	index.Replace("Application", []Document{{ID: "c", Title: "Gamma"}})
+	if results := index.Search("alpha", 10); len(results) != 0 {
+		t.Fatalf("removed document is still found: %+v", results)
+	}
+	index.Remove("Application")
+	if index.Len() != 0 {
+		t.Fatalf("Len() = %d after Remove, want 0", index.Len())
+	}
+}
@@ -0,0 +1,156 @@
+package search
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/mozillazg/go-pinyin"
+)
+
+// unit is one rune of an indexed text prepared for matching
+type unit struct {
+	// r is the lowercased rune, separators have no match candidates
+	r rune
+	// syllables are the pinyin readings of a Han character, all of them for polyphones
+	syllables []string
+	// boundary marks the first rune of a word, a camel case hump, a digit run or a CJK character
+	boundary  bool
+	separator bool
+}
+
+// Field is a text prepared for matching
+type Field struct {
+	Text  string
+	units []unit
+}
+
+var pinyinArgs = func() pinyin.Args {
+	args := pinyin.NewArgs()
+	args.Heteronym = true
+	return args
+}()
+
+var pinyinCache sync.Map
+
+// Syllables returns the toneless pinyin readings of r, or nil when r is not a Han character
+func Syllables(r rune) []string {
+	if !unicode.Is(unicode.Han, r) {
+		return nil
+	}
+	if cached, ok := pinyinCache.Load(r); ok {
+		return cached.([]string)
+	}
+	syllables := pinyin.SinglePinyin(r, pinyinArgs)
+	// polyphones may repeat once the tones are dropped
+	unique := make([]string, 0, len(syllables))
+	for _, syllable := range syllables {
+		if syllable != "" && !contains(unique, syllable) {
+			unique = append(unique, syllable)
+		}
+	}
+	pinyinCache.Store(r, unique)
+	return unique
+}
+
+// Pinyin returns the full pinyin and the pinyin initials of text, other characters are kept lowercased.
+// Only the first reading of polyphones is used, e.g. "微信 App" gives "weixinapp" and "wxapp".
+func Pinyin(text string) (full string, initials string) {
+	var fullBuilder, initialsBuilder strings.Builder
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		if syllables := Syllables(r); len(syllables) > 0 {
+			fullBuilder.WriteString(syllables[0])
+			initialsBuilder.WriteByte(syllables[0][0])
+			continue
+		}
+		lower := unicode.ToLower(r)
+		fullBuilder.WriteRune(lower)
+		initialsBuilder.WriteRune(lower)
+	}
+	return fullBuilder.String(), initialsBuilder.String()
+}
+
+// isCJK reports whether r is written without spaces between words, each such rune is a word of its own
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// NewField splits text into units, word boundaries are found at separators, camel case humps,
+// letter/digit changes and around CJK characters
+func NewField(text string) Field {
+	runes := []rune(text)
+	units := make([]unit, len(runes))
+	for i, r := range runes {
+		if isSeparator(r) {
+			units[i] = unit{r: r, separator: true}
+			continue
+		}
+		u := unit{r: unicode.ToLower(r), syllables: Syllables(r)}
+		if i == 0 {
+			u.boundary = true
+		} else {
+			prev := runes[i-1]
+			switch {
+			case isSeparator(prev), isCJK(r), isCJK(prev):
+				u.boundary = true
+			case unicode.IsUpper(r) && unicode.IsLower(prev):
+				u.boundary = true
+			case unicode.IsDigit(r) != unicode.IsDigit(prev):
+				u.boundary = true
+			}
+		}
+		units[i] = u
+	}
+	return Field{Text: text, units: units}
+}
+
+// Tokens returns the words of text, each CJK character is a word
+func Tokens(text string) []string {
+	field := NewField(text)
+	runes := []rune(text)
+	var tokens []string
+	start := -1
+	for i, u := range field.units {
+		if u.separator || u.boundary {
+			if start >= 0 {
+				tokens = append(tokens, string(runes[start:i]))
+			}
+			start = -1
+		}
+		if !u.separator && start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, string(runes[start:]))
+	}
+	return tokens
+}
+
+// normalizeQuery lowercases the query and drops whitespace, words of a query are matched as one run
+func normalizeQuery(query string) []rune {
+	runes := make([]rune, 0, len(query))
+	for _, r := range query {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		runes = append(runes, unicode.ToLower(r))
+	}
+	return runes
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
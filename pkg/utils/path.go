@@ -6,6 +6,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"strings"
 )
 
@@ -60,3 +61,70 @@ func ResolvePathWithinBase(baseDir string, relativePath string) (string, error)
 
 	return absTarget, nil
 }
+
+// ValidatePathGlob checks that pattern can be used with MatchPathGlob
+func ValidatePathGlob(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return fmt.Errorf("pattern cannot be empty")
+	}
+	for _, segment := range strings.Split(filepath.ToSlash(pattern), "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// MatchPathGlob reports whether filePath matches a glob pattern.
+// Patterns use path.Match syntax per segment, "**" matches any number of segments and a leading "~/" is the home directory.
+// Patterns without a separator are matched against the base name, e.g. "*Uninstall*".
+// Matching is case-insensitive on Windows and macOS.
+func MatchPathGlob(pattern string, filePath string) bool {
+	if strings.HasPrefix(pattern, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			pattern = filepath.Join(home, pattern[2:])
+		}
+	}
+	pattern = filepath.ToSlash(pattern)
+	filePath = filepath.ToSlash(filePath)
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		pattern = strings.ToLower(pattern)
+		filePath = strings.ToLower(filePath)
+	}
+	if !strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, path.Base(filePath))
+		return matched
+	}
+	return matchSegments(strings.Split(strings.Trim(pattern, "/"), "/"), strings.Split(strings.Trim(filePath, "/"), "/"))
+}
+
+// MatchAnyPathGlob reports whether filePath matches one of patterns
+func MatchAnyPathGlob(patterns []string, filePath string) bool {
+	for _, pattern := range patterns {
+		if MatchPathGlob(pattern, filePath) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSegments(pattern []string, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for skip := 0; skip <= len(segments); skip++ {
+				if matchSegments(pattern[1:], segments[skip:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
@@ -59,3 +59,34 @@ func TestResolvePathWithinBase(t *testing.T) {
 		}
 	}
 }
+
+func TestMatchPathGlob(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*uninstall*", "/usr/share/applications/foo-uninstall.desktop", true},
+		{"*uninstall*", "/usr/share/applications/foo.desktop", false},
+		{"/System/Library/CoreServices/*", "/System/Library/CoreServices/Finder.app", true},
+		{"/System/Library/CoreServices/*", "/System/Library/CoreServices/Sub/Helper.app", false},
+		{"/System/Library/CoreServices/**", "/System/Library/CoreServices/Sub/Helper.app", true},
+		{"/opt/**/*.desktop", "/opt/app.desktop", true},
+		{"/opt/**/*.desktop", "/opt/a/b/app.desktop", true},
+		{"/opt/**/*.desktop", "/usr/opt/app.desktop", false},
+	}
+	for _, tc := range cases {
+		if got := MatchPathGlob(tc.pattern, tc.path); got != tc.want {
+			t.Fatalf("MatchPathGlob(%q, %q) = %v, want %v", tc.pattern, tc.path, got, tc.want)
+		}
+	}
+
+	if err := ValidatePathGlob("/opt/[a-"); err == nil {
+		t.Fatalf("expected malformed pattern to be invalid")
+	}
+	if err := ValidatePathGlob(" "); err == nil {
+		t.Fatalf("expected empty pattern to be invalid")
+	}
+}
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "modernc.org/sqlite"
+)
+
+// OpenSQLiteCopy copies a SQLite database with its write-ahead log and shared memory files into dir and opens the copy read-only.
+// Databases of running apps are read from the copy, so the app never sees a lock and the rows still in the log are included.
+func OpenSQLiteCopy(path string, dir string) (*sql.DB, error) {
+	target := filepath.Join(dir, filepath.Base(path))
+	if err := copyFile(path, target); err != nil {
+		return nil, err
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := copyFile(path+suffix, target+suffix); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	// A URI path starts with a slash, SQLite drops it again before a Windows drive letter
+	uriPath := filepath.ToSlash(target)
+	if !strings.HasPrefix(uriPath, "/") {
+		uriPath = "/" + uriPath
+	}
+	db, err := sql.Open("sqlite", (&url.URL{Scheme: "file", Path: uriPath, RawQuery: "mode=ro"}).String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	return db, nil
+}
+
+func copyFile(source string, target string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to copy %s: %w", source, err)
+	}
+	return out.Close()
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenSQLiteCopyReadsWriteAheadLog(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "source.db")
+	source, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer source.Close()
+	source.SetMaxOpenConns(1)
+	for _, statement := range []string{
+		"PRAGMA journal_mode=WAL",
+		"PRAGMA wal_autocheckpoint=0",
+		"CREATE TABLE item (name TEXT)",
+		"INSERT INTO item (name) VALUES ('only in the log')",
+	} {
+		if _, err := source.Exec(statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if info, err := os.Stat(path + "-wal"); err != nil || info.Size() == 0 {
+		t.Fatalf("expected the insert to stay in the write-ahead log: %v", err)
+	}
+
+	db, err := OpenSQLiteCopy(path, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var name string
+	if err := db.QueryRow("SELECT name FROM item").Scan(&name); err != nil {
+		t.Fatal(err)
+	}
+	if name != "only in the log" {
+		t.Fatalf("expected the row of the write-ahead log, got %q", name)
+	}
+	if _, err := db.Exec("INSERT INTO item (name) VALUES ('written')"); err == nil {
+		t.Fatal("expected the copy to be read-only")
+	}
+}
@@ -0,0 +1,174 @@
+package xdg
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DataHome returns $XDG_DATA_HOME, falling back to ~/.local/share
+func DataHome() string {
+	if dir := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dir) {
+		return filepath.Clean(dir)
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".local", "share")
+}
+
+// ConfigHome returns $XDG_CONFIG_HOME, falling back to ~/.config
+func ConfigHome() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(dir) {
+		return filepath.Clean(dir)
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".config")
+}
+
+// DataDirs returns $XDG_DATA_DIRS, falling back to /usr/local/share:/usr/share
+func DataDirs() []string {
+	value := os.Getenv("XDG_DATA_DIRS")
+	if strings.TrimSpace(value) == "" {
+		value = "/usr/local/share:/usr/share"
+	}
+	var dirs []string
+	seen := make(map[string]struct{})
+	for _, dir := range filepath.SplitList(value) {
+		dir = strings.TrimSpace(dir)
+		if !filepath.IsAbs(dir) {
+			continue
+		}
+		dir = filepath.Clean(dir)
+		if _, exists := seen[dir]; exists {
+			continue
+		}
+		seen[dir] = struct{}{}
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// SnapDataDir is where snapd exports the desktop files and icons of installed snaps
+const SnapDataDir = "/var/lib/snapd/desktop"
+
+// FlatpakSystemDataDir is where Flatpak exports the desktop files and icons of system wide installations
+const FlatpakSystemDataDir = "/var/lib/flatpak/exports/share"
+
+var (
+	systemSandboxDirsMu sync.RWMutex
+	systemSandboxDirs   = map[PackagingOrigin]string{
+		OriginFlatpak: FlatpakSystemDataDir,
+		OriginSnap:    SnapDataDir,
+	}
+)
+
+// PackagingOrigin tells how an application was installed
+type PackagingOrigin string
+
+const (
+	OriginNative  PackagingOrigin = "native"
+	OriginFlatpak PackagingOrigin = "flatpak"
+	OriginSnap    PackagingOrigin = "snap"
+)
+
+// SetSystemSandboxDataDir overrides the system export directory of a packaging origin, an empty dir disables it
+func SetSystemSandboxDataDir(origin PackagingOrigin, dir string) {
+	systemSandboxDirsMu.Lock()
+	defer systemSandboxDirsMu.Unlock()
+	systemSandboxDirs[origin] = dir
+}
+
+func systemSandboxDataDir(origin PackagingOrigin) string {
+	systemSandboxDirsMu.RLock()
+	defer systemSandboxDirsMu.RUnlock()
+	return systemSandboxDirs[origin]
+}
+
+// FlatpakDataDirs returns the user and system Flatpak export directories
+func FlatpakDataDirs() []string {
+	var dirs []string
+	if dataHome := DataHome(); dataHome != "" {
+		dirs = append(dirs, filepath.Join(dataHome, "flatpak", "exports", "share"))
+	}
+	if dir := systemSandboxDataDir(OriginFlatpak); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// SandboxDataDirs returns the Flatpak and Snap export directories
+func SandboxDataDirs() []string {
+	dirs := FlatpakDataDirs()
+	if dir := systemSandboxDataDir(OriginSnap); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// DataDirOrigin returns the packaging origin of a file below one of the data directories
+func DataDirOrigin(path string) PackagingOrigin {
+	path = filepath.Clean(path)
+	for _, dir := range FlatpakDataDirs() {
+		if strings.HasPrefix(path, dir+string(os.PathSeparator)) {
+			return OriginFlatpak
+		}
+	}
+	if dir := systemSandboxDataDir(OriginSnap); dir != "" && strings.HasPrefix(path, dir+string(os.PathSeparator)) {
+		return OriginSnap
+	}
+	return OriginNative
+}
+
+// SearchDataDirs returns the data home followed by the data dirs, in lookup precedence order.
+// Flatpak and Snap exports are appended when the session did not add them to $XDG_DATA_DIRS,
+// which happens when the launcher is started outside a login shell.
+func SearchDataDirs() []string {
+	var dirs []string
+	seen := make(map[string]struct{})
+	add := func(dir string) {
+		if _, exists := seen[dir]; exists {
+			return
+		}
+		seen[dir] = struct{}{}
+		dirs = append(dirs, dir)
+	}
+	if dataHome := DataHome(); dataHome != "" {
+		add(dataHome)
+	}
+	for _, dir := range DataDirs() {
+		add(dir)
+	}
+	for _, dir := range SandboxDataDirs() {
+		add(dir)
+	}
+	return dirs
+}
+
+// ApplicationDirs returns every "applications" directory in lookup precedence order.
+// A desktop file found in an earlier directory shadows one with the same desktop file ID in a later one.
+func ApplicationDirs() []string {
+	dataDirs := SearchDataDirs()
+	dirs := make([]string, 0, len(dataDirs))
+	for _, dir := range dataDirs {
+		dirs = append(dirs, filepath.Join(dir, "applications"))
+	}
+	return dirs
+}
+
+// CurrentDesktops returns the desktop environment names from $XDG_CURRENT_DESKTOP
+func CurrentDesktops() []string {
+	var desktops []string
+	for _, desktop := range strings.Split(os.Getenv("XDG_CURRENT_DESKTOP"), ":") {
+		desktop = strings.TrimSpace(desktop)
+		if desktop != "" {
+			desktops = append(desktops, desktop)
+		}
+	}
+	return desktops
+}
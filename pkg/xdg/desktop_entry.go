@@ -0,0 +1,265 @@
+package xdg
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	GroupDesktopEntry  = "Desktop Entry"
+	DesktopEntrySuffix = ".desktop"
+)
+
+// DesktopEntry is a parsed freedesktop desktop entry file
+type DesktopEntry struct {
+	groups     map[string]map[string]string
+	groupOrder []string
+}
+
+// ParseDesktopEntry parses a desktop entry from reader.
+// Comments, blank lines and duplicate groups are tolerated, the first occurrence of a key wins.
+func ParseDesktopEntry(r io.Reader) (*DesktopEntry, error) {
+	entry, err := parseKeyFile(r)
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := entry.groups[GroupDesktopEntry]; !exists {
+		return nil, fmt.Errorf("missing [%s] group", GroupDesktopEntry)
+	}
+	return entry, nil
+}
+
+// parseKeyFile parses the ini-like key file format shared by desktop entries and icon theme indexes
+func parseKeyFile(r io.Reader) (*DesktopEntry, error) {
+	entry := &DesktopEntry{groups: make(map[string]map[string]string)}
+	var current map[string]string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if lineNo == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("invalid group header at line %d: %s", lineNo, line)
+			}
+			name := line[1 : len(line)-1]
+			if _, exists := entry.groups[name]; !exists {
+				entry.groups[name] = make(map[string]string)
+				entry.groupOrder = append(entry.groupOrder, name)
+			}
+			current = entry.groups[name]
+			continue
+		}
+		if current == nil {
+			return nil, fmt.Errorf("key outside of group at line %d", lineNo)
+		}
+		idx := strings.Index(line, "=")
+		if idx <= 0 {
+			continue
+		}
+		key := strings.TrimSpace(line[:idx])
+		value := strings.TrimSpace(line[idx+1:])
+		if _, exists := current[key]; !exists {
+			current[key] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	return entry, nil
+}
+
+// ParseDesktopEntryFile parses the desktop entry file at path
+func ParseDesktopEntryFile(path string) (*DesktopEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entry, err := ParseDesktopEntry(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse desktop entry '%s': %w", path, err)
+	}
+	return entry, nil
+}
+
+// Groups returns group names in file order
+func (e *DesktopEntry) Groups() []string {
+	groups := make([]string, len(e.groupOrder))
+	copy(groups, e.groupOrder)
+	return groups
+}
+
+// HasGroup reports whether the group exists
+func (e *DesktopEntry) HasGroup(group string) bool {
+	_, exists := e.groups[group]
+	return exists
+}
+
+// Raw returns the unprocessed value of key in group
+func (e *DesktopEntry) Raw(group string, key string) (string, bool) {
+	values, exists := e.groups[group]
+	if !exists {
+		return "", false
+	}
+	value, exists := values[key]
+	return value, exists
+}
+
+// String returns the unescaped value of key in group
+func (e *DesktopEntry) String(group string, key string) string {
+	value, _ := e.Raw(group, key)
+	return unescapeValue(value)
+}
+
+// LocaleString returns the value of key in group that best matches the current locale
+func (e *DesktopEntry) LocaleString(group string, key string) string {
+	for _, candidate := range localeKeys(key, currentLocale()) {
+		if value, exists := e.Raw(group, candidate); exists {
+			return unescapeValue(value)
+		}
+	}
+	return ""
+}
+
+// Bool returns the boolean value of key in group, false when absent
+func (e *DesktopEntry) Bool(group string, key string) bool {
+	value, _ := e.Raw(group, key)
+	return strings.EqualFold(strings.TrimSpace(value), "true")
+}
+
+// StringList returns the semicolon separated values of key in group
+func (e *DesktopEntry) StringList(group string, key string) []string {
+	value, _ := e.Raw(group, key)
+	return splitList(value)
+}
+
+// DesktopFileID returns the desktop file ID of path relative to its applications directory
+func DesktopFileID(applicationsDir string, path string) (string, error) {
+	rel, err := filepath.Rel(applicationsDir, path)
+	if err != nil {
+		return "", err
+	}
+	if rel == "." || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("path '%s' is not inside '%s'", path, applicationsDir)
+	}
+	return strings.ReplaceAll(filepath.ToSlash(rel), "/", "-"), nil
+}
+
+func unescapeValue(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	var builder strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i == len(value)-1 {
+			builder.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 's':
+			builder.WriteByte(' ')
+		case 'n':
+			builder.WriteByte('\n')
+		case 't':
+			builder.WriteByte('\t')
+		case 'r':
+			builder.WriteByte('\r')
+		case '\\':
+			builder.WriteByte('\\')
+		default:
+			builder.WriteByte('\\')
+			builder.WriteByte(value[i])
+		}
+	}
+	return builder.String()
+}
+
+func splitList(value string) []string {
+	var items []string
+	var current strings.Builder
+	flush := func() {
+		if item := unescapeValue(current.String()); item != "" {
+			items = append(items, item)
+		}
+		current.Reset()
+	}
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value) && value[i+1] == ';':
+			current.WriteByte(';')
+			i++
+		case value[i] == '\\' && i+1 < len(value):
+			current.WriteByte(value[i])
+			current.WriteByte(value[i+1])
+			i++
+		case value[i] == ';':
+			flush()
+		default:
+			current.WriteByte(value[i])
+		}
+	}
+	flush()
+	return items
+}
+
+func currentLocale() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(env); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// localeKeys returns the localized key candidates in the matching order of the desktop entry spec:
+// lang_COUNTRY@MODIFIER, lang_COUNTRY, lang@MODIFIER, lang and finally the default key
+func localeKeys(key string, locale string) []string {
+	if idx := strings.Index(locale, "."); idx >= 0 {
+		rest := locale[idx:]
+		locale = locale[:idx]
+		if at := strings.Index(rest, "@"); at >= 0 {
+			locale += rest[at:]
+		}
+	}
+	if locale == "" || locale == "C" || locale == "POSIX" {
+		return []string{key}
+	}
+
+	lang, country, modifier := locale, "", ""
+	if idx := strings.Index(lang, "@"); idx >= 0 {
+		modifier = lang[idx+1:]
+		lang = lang[:idx]
+	}
+	if idx := strings.Index(lang, "_"); idx >= 0 {
+		country = lang[idx+1:]
+		lang = lang[:idx]
+	}
+
+	var keys []string
+	if country != "" && modifier != "" {
+		keys = append(keys, fmt.Sprintf("%s[%s_%s@%s]", key, lang, country, modifier))
+	}
+	if country != "" {
+		keys = append(keys, fmt.Sprintf("%s[%s_%s]", key, lang, country))
+	}
+	if modifier != "" {
+		keys = append(keys, fmt.Sprintf("%s[%s@%s]", key, lang, modifier))
+	}
+	keys = append(keys, fmt.Sprintf("%s[%s]", key, lang), key)
+	return keys
+}
@@ -0,0 +1,110 @@
+package xdg
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleDesktopEntry = `# comment
+[Desktop Entry]
+Type=Application
+Name=Text Editor
+Name[de]=Texteditor
+Name[sr@latin]=Uređivač teksta
+Comment=Edit\stext files
+Categories=Utility;TextEditor;
+Keywords=semi\;colon;plain;
+Exec=gedit %U
+NoDisplay=true
+
+[Desktop Action new-window]
+Name=New Window
+Exec=gedit --new-window
+`
+
+func TestParseDesktopEntry(t *testing.T) {
+	entry, err := ParseDesktopEntry(strings.NewReader(sampleDesktopEntry))
+	if err != nil {
+		t.Fatalf("expected desktop entry to parse: %v", err)
+	}
+
+	if got := entry.String(GroupDesktopEntry, "Comment"); got != "Edit text files" {
+		t.Fatalf("unexpected comment %q", got)
+	}
+	if !entry.Bool(GroupDesktopEntry, "NoDisplay") {
+		t.Fatal("expected NoDisplay to be true")
+	}
+	if got := entry.StringList(GroupDesktopEntry, "Categories"); !reflect.DeepEqual(got, []string{"Utility", "TextEditor"}) {
+		t.Fatalf("unexpected categories %v", got)
+	}
+	if got := entry.StringList(GroupDesktopEntry, "Keywords"); !reflect.DeepEqual(got, []string{"semi;colon", "plain"}) {
+		t.Fatalf("unexpected keywords %v", got)
+	}
+	if got := entry.String("Desktop Action new-window", "Exec"); got != "gedit --new-window" {
+		t.Fatalf("unexpected action exec %q", got)
+	}
+	if got := entry.Groups(); !reflect.DeepEqual(got, []string{GroupDesktopEntry, "Desktop Action new-window"}) {
+		t.Fatalf("unexpected groups %v", got)
+	}
+}
+
+func TestParseDesktopEntryRequiresMainGroup(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ParseDesktopEntry(strings.NewReader("[Other]\nName=x\n")); err == nil {
+		t.Fatal("expected missing [Desktop Entry] group to be rejected")
+	}
+	if _, err := ParseDesktopEntry(strings.NewReader("Name=x\n[Desktop Entry]\n")); err == nil {
+		t.Fatal("expected key outside of group to be rejected")
+	}
+}
+
+func TestLocaleString(t *testing.T) {
+	entry, err := ParseDesktopEntry(strings.NewReader(sampleDesktopEntry))
+	if err != nil {
+		t.Fatalf("expected desktop entry to parse: %v", err)
+	}
+
+	testCases := []struct {
+		locale string
+		want   string
+	}{
+		{locale: "de_DE.UTF-8", want: "Texteditor"},
+		{locale: "sr_RS.UTF-8@latin", want: "Uređivač teksta"},
+		{locale: "en_US.UTF-8", want: "Text Editor"},
+		{locale: "C", want: "Text Editor"},
+	}
+	for _, testCase := range testCases {
+		t.Setenv("LC_ALL", testCase.locale)
+		if got := entry.LocaleString(GroupDesktopEntry, "Name"); got != testCase.want {
+			t.Fatalf("locale %s: expected %q, got %q", testCase.locale, testCase.want, got)
+		}
+	}
+}
+
+func TestDesktopFileID(t *testing.T) {
+	t.Parallel()
+
+	id, err := DesktopFileID("/usr/share/applications", "/usr/share/applications/kde4/konsole.desktop")
+	if err != nil {
+		t.Fatalf("expected desktop file ID: %v", err)
+	}
+	if id != "kde4-konsole.desktop" {
+		t.Fatalf("unexpected desktop file ID %q", id)
+	}
+	if _, err := DesktopFileID("/usr/share/applications", "/opt/app.desktop"); err == nil {
+		t.Fatal("expected path outside of applications dir to be rejected")
+	}
+}
+
+func TestDataDirsFallback(t *testing.T) {
+	t.Setenv("XDG_DATA_DIRS", "")
+	if got := DataDirs(); !reflect.DeepEqual(got, []string{"/usr/local/share", "/usr/share"}) {
+		t.Fatalf("unexpected default data dirs %v", got)
+	}
+	t.Setenv("XDG_DATA_DIRS", "/a:relative:/b:/a")
+	if got := DataDirs(); !reflect.DeepEqual(got, []string{"/a", "/b"}) {
+		t.Fatalf("unexpected data dirs %v", got)
+	}
+}
@@ -0,0 +1,147 @@
+package xdg
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SplitExec splits an Exec value into arguments following the quoting rules of the desktop entry spec.
+// The value must already be unescaped at the key file level, see DesktopEntry.String.
+func SplitExec(value string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inQuotes := false
+	hasArg := false
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case inQuotes && c == '\\':
+			if i+1 < len(value) && strings.IndexByte("\"`$\\", value[i+1]) >= 0 {
+				i++
+				current.WriteByte(value[i])
+			} else {
+				current.WriteByte(c)
+			}
+		case c == '"':
+			inQuotes = !inQuotes
+			hasArg = true
+		case !inQuotes && (c == ' ' || c == '\t'):
+			if hasArg {
+				args = append(args, current.String())
+				current.Reset()
+				hasArg = false
+			}
+		default:
+			current.WriteByte(c)
+			hasArg = true
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote in Exec '%s'", value)
+	}
+	if hasArg {
+		args = append(args, current.String())
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty Exec")
+	}
+	return args, nil
+}
+
+// ActionGroup returns the group name of a desktop action
+func ActionGroup(actionID string) string {
+	return "Desktop Action " + actionID
+}
+
+// ExpandExec builds the command line of the Exec key in group, either GroupDesktopEntry or an ActionGroup,
+// replacing the field codes %f %F %u %U with targets and %i %c %k with the icon, name and desktop file location.
+// Deprecated field codes are removed, targets that no field code accepts are dropped.
+func ExpandExec(entry *DesktopEntry, group string, desktopFilePath string, targets []string) ([]string, error) {
+	args, err := SplitExec(entry.String(group, "Exec"))
+	if err != nil {
+		return nil, err
+	}
+
+	var expanded []string
+	targetsUsed := false
+	for _, arg := range args {
+		switch arg {
+		case "%f", "%u":
+			if !targetsUsed && len(targets) > 0 {
+				expanded = append(expanded, execTarget(targets[0], arg == "%f"))
+				targetsUsed = true
+			}
+			continue
+		case "%F", "%U":
+			if !targetsUsed {
+				for _, target := range targets {
+					expanded = append(expanded, execTarget(target, arg == "%F"))
+				}
+				targetsUsed = true
+			}
+			continue
+		case "%i":
+			if icon := groupValue(entry, group, "Icon"); icon != "" {
+				expanded = append(expanded, "--icon", icon)
+			}
+			continue
+		}
+		value := expandFieldCodes(arg, entry, group, desktopFilePath)
+		if value == "" && arg != "" {
+			// the argument only held removed field codes
+			continue
+		}
+		expanded = append(expanded, value)
+	}
+	if len(expanded) == 0 {
+		return nil, fmt.Errorf("empty Exec after field code expansion")
+	}
+	return expanded, nil
+}
+
+// expandFieldCodes replaces the field codes embedded in a single argument
+func expandFieldCodes(arg string, entry *DesktopEntry, group string, desktopFilePath string) string {
+	if !strings.Contains(arg, "%") {
+		return arg
+	}
+	var b strings.Builder
+	for i := 0; i < len(arg); i++ {
+		if arg[i] != '%' || i+1 >= len(arg) {
+			b.WriteByte(arg[i])
+			continue
+		}
+		i++
+		switch arg[i] {
+		case '%':
+			b.WriteByte('%')
+		case 'c':
+			b.WriteString(groupValue(entry, group, "Name"))
+		case 'k':
+			b.WriteString(desktopFilePath)
+		default:
+			// %f %u and friends inside a larger argument and the deprecated %d %D %n %N %v %m are dropped
+		}
+	}
+	return b.String()
+}
+
+// execTarget converts a target for a file (%f %F) or URL (%u %U) field code
+func execTarget(target string, wantFile bool) string {
+	if !wantFile {
+		return target
+	}
+	if parsed, err := url.Parse(target); err == nil && parsed.Scheme == "file" && parsed.Path != "" {
+		return parsed.Path
+	}
+	return target
+}
+
+// groupValue reads a localized key of group, actions fall back to the values of the application
+func groupValue(entry *DesktopEntry, group string, key string) string {
+	if value := entry.LocaleString(group, key); value != "" {
+		return value
+	}
+	return entry.LocaleString(GroupDesktopEntry, key)
+}
@@ -0,0 +1,69 @@
+package xdg
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitExec(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{value: "firefox %u", want: []string{"firefox", "%u"}},
+		{value: `"/opt/My App/run" --flag`, want: []string{"/opt/My App/run", "--flag"}},
+		{value: `sh -c "echo \"\$HOME\" \\ done"`, want: []string{"sh", "-c", `echo "$HOME" \ done`}},
+		{value: `app ""`, want: []string{"app", ""}},
+		{value: `app "unterminated`, wantErr: true},
+		{value: "   ", wantErr: true},
+	}
+	for _, tc := range cases {
+		got, err := SplitExec(tc.value)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("SplitExec(%q) error = %v, wantErr %v", tc.value, err, tc.wantErr)
+		}
+		if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("SplitExec(%q) = %q, want %q", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestExpandExec(t *testing.T) {
+	t.Parallel()
+
+	newEntry := func(exec string) *DesktopEntry {
+		entry, err := ParseDesktopEntry(strings.NewReader("[Desktop Entry]\nType=Application\nName=Editor\nIcon=editor\nExec=" + exec + "\n"))
+		if err != nil {
+			t.Fatalf("ParseDesktopEntry: %v", err)
+		}
+		return entry
+	}
+	targets := []string{"file:///tmp/a%20b.txt", "/tmp/c.txt"}
+
+	cases := []struct {
+		exec    string
+		targets []string
+		want    []string
+	}{
+		{exec: "editor %f", targets: targets, want: []string{"editor", "/tmp/a b.txt"}},
+		{exec: "editor %F", targets: targets, want: []string{"editor", "/tmp/a b.txt", "/tmp/c.txt"}},
+		{exec: "editor %u", targets: targets, want: []string{"editor", "file:///tmp/a%20b.txt"}},
+		{exec: "editor %U --new", targets: targets, want: []string{"editor", "file:///tmp/a%20b.txt", "/tmp/c.txt", "--new"}},
+		{exec: "editor %F", want: []string{"editor"}},
+		{exec: "editor %i --name=%c --desktop %k 100%%", want: []string{"editor", "--icon", "editor", "--name=Editor", "--desktop", "/apps/editor.desktop", "100%"}},
+		{exec: "editor %d %m \"\"", want: []string{"editor", ""}},
+	}
+	for _, tc := range cases {
+		got, err := ExpandExec(newEntry(tc.exec), GroupDesktopEntry, "/apps/editor.desktop", tc.targets)
+		if err != nil {
+			t.Fatalf("ExpandExec(%q) returned error: %v", tc.exec, err)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("ExpandExec(%q) = %q, want %q", tc.exec, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,370 @@
+package xdg
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultIconTheme = "hicolor"
+	groupIconTheme   = "Icon Theme"
+)
+
+// IconExtensions are the icon file formats allowed by the icon theme spec, in lookup order
+var IconExtensions = []string{".png", ".svg", ".xpm"}
+
+type iconDirectory struct {
+	name      string
+	size      int
+	scale     int
+	minSize   int
+	maxSize   int
+	threshold int
+	dirType   string
+}
+
+type iconTheme struct {
+	name        string
+	inherits    []string
+	directories []iconDirectory
+}
+
+// IconResolver looks up themed icons following the freedesktop Icon Theme Specification
+type IconResolver struct {
+	baseDirs  []string
+	themeName func() string
+
+	mu     sync.Mutex
+	themes map[string]*iconTheme
+}
+
+// IconBaseDirs returns ~/.icons, every $XDG_DATA_DIRS/icons and /usr/share/pixmaps, in lookup order
+func IconBaseDirs() []string {
+	var dirs []string
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(homeDir, ".icons"))
+	}
+	for _, dir := range SearchDataDirs() {
+		dirs = append(dirs, filepath.Join(dir, "icons"))
+	}
+	return append(dirs, "/usr/share/pixmaps")
+}
+
+// NewIconResolver create an icon resolver, themeName is consulted on every lookup
+func NewIconResolver(baseDirs []string, themeName func() string) *IconResolver {
+	return &IconResolver{
+		baseDirs:  baseDirs,
+		themeName: themeName,
+		themes:    make(map[string]*iconTheme),
+	}
+}
+
+var (
+	defaultIconResolver     *IconResolver
+	defaultIconResolverOnce sync.Once
+)
+
+// DefaultIconResolver returns the resolver for the desktop's current icon theme
+func DefaultIconResolver() *IconResolver {
+	defaultIconResolverOnce.Do(func() {
+		defaultIconResolver = NewIconResolver(IconBaseDirs(), CurrentIconThemeName)
+	})
+	return defaultIconResolver
+}
+
+// LookupIcon returns the file of icon name closest to size, searching the current theme,
+// the themes it inherits from, hicolor and finally the unthemed base directories
+func (r *IconResolver) LookupIcon(name string, size int) (string, error) {
+	name = strings.TrimSpace(name)
+	for _, ext := range IconExtensions {
+		name = strings.TrimSuffix(name, ext)
+	}
+	if name == "" || strings.ContainsRune(name, os.PathSeparator) {
+		return "", fmt.Errorf("invalid icon name '%s'", name)
+	}
+
+	visited := make(map[string]struct{})
+	themeName := DefaultIconTheme
+	if r.themeName != nil {
+		if current := r.themeName(); current != "" {
+			themeName = current
+		}
+	}
+	if path := r.findIconInTheme(name, size, themeName, visited); path != "" {
+		return path, nil
+	}
+	if path := r.findIconInTheme(name, size, DefaultIconTheme, visited); path != "" {
+		return path, nil
+	}
+	for _, dir := range r.baseDirs {
+		for _, ext := range IconExtensions {
+			path := filepath.Join(dir, name+ext)
+			if isRegularFile(path) {
+				return path, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("icon '%s' not found in theme '%s'", name, themeName)
+}
+
+func (r *IconResolver) findIconInTheme(name string, size int, themeName string, visited map[string]struct{}) string {
+	if _, exists := visited[themeName]; exists {
+		return ""
+	}
+	visited[themeName] = struct{}{}
+
+	theme := r.loadTheme(themeName)
+	if theme == nil {
+		return ""
+	}
+	if path := r.lookupIconInTheme(name, size, theme); path != "" {
+		return path
+	}
+	for _, parent := range theme.inherits {
+		if path := r.findIconInTheme(name, size, parent, visited); path != "" {
+			return path
+		}
+	}
+	return ""
+}
+
+func (r *IconResolver) lookupIconInTheme(name string, size int, theme *iconTheme) string {
+	for _, dir := range theme.directories {
+		if !dir.matchesSize(size) {
+			continue
+		}
+		if path := r.findIconFile(theme.name, dir.name, name); path != "" {
+			return path
+		}
+	}
+
+	closestPath := ""
+	minDistance := math.MaxInt
+	for _, dir := range theme.directories {
+		distance := dir.sizeDistance(size)
+		if distance >= minDistance {
+			continue
+		}
+		if path := r.findIconFile(theme.name, dir.name, name); path != "" {
+			closestPath = path
+			minDistance = distance
+		}
+	}
+	return closestPath
+}
+
+func (r *IconResolver) findIconFile(themeName string, subdir string, name string) string {
+	for _, baseDir := range r.baseDirs {
+		for _, ext := range IconExtensions {
+			path := filepath.Join(baseDir, themeName, subdir, name+ext)
+			if isRegularFile(path) {
+				return path
+			}
+		}
+	}
+	return ""
+}
+
+func (r *IconResolver) loadTheme(themeName string) *iconTheme {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if theme, exists := r.themes[themeName]; exists {
+		return theme
+	}
+	var theme *iconTheme
+	for _, baseDir := range r.baseDirs {
+		file, err := os.Open(filepath.Join(baseDir, themeName, "index.theme"))
+		if err != nil {
+			continue
+		}
+		index, err := parseKeyFile(file)
+		file.Close()
+		if err != nil || !index.HasGroup(groupIconTheme) {
+			continue
+		}
+		theme = newIconTheme(themeName, index)
+		break
+	}
+	// cache misses as well so unknown themes are not searched for again
+	r.themes[themeName] = theme
+	return theme
+}
+
+func newIconTheme(name string, index *DesktopEntry) *iconTheme {
+	theme := &iconTheme{
+		name:     name,
+		inherits: index.StringList(groupIconTheme, "Inherits"),
+	}
+	dirNames := index.StringList(groupIconTheme, "Directories")
+	dirNames = append(dirNames, index.StringList(groupIconTheme, "ScaledDirectories")...)
+	seen := make(map[string]struct{})
+	for _, dirName := range splitCommaList(dirNames) {
+		if _, exists := seen[dirName]; exists || !index.HasGroup(dirName) {
+			continue
+		}
+		seen[dirName] = struct{}{}
+		size := keyFileInt(index, dirName, "Size", 0)
+		if size <= 0 {
+			continue
+		}
+		theme.directories = append(theme.directories, iconDirectory{
+			name:      dirName,
+			size:      size,
+			scale:     keyFileInt(index, dirName, "Scale", 1),
+			minSize:   keyFileInt(index, dirName, "MinSize", size),
+			maxSize:   keyFileInt(index, dirName, "MaxSize", size),
+			threshold: keyFileInt(index, dirName, "Threshold", 2),
+			dirType:   index.String(dirName, "Type"),
+		})
+	}
+	return theme
+}
+
+// splitCommaList splits list values, index.theme files use commas where desktop entries use semicolons
+func splitCommaList(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+func keyFileInt(index *DesktopEntry, group string, key string, fallback int) int {
+	value, exists := index.Raw(group, key)
+	if !exists {
+		return fallback
+	}
+	parsed, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return fallback
+	}
+	return parsed
+}
+
+func (d iconDirectory) matchesSize(size int) bool {
+	if d.scale != 1 {
+		return false
+	}
+	switch d.dirType {
+	case "Fixed":
+		return d.size == size
+	case "Scalable":
+		return d.minSize <= size && size <= d.maxSize
+	default:
+		return d.size-d.threshold <= size && size <= d.size+d.threshold
+	}
+}
+
+func (d iconDirectory) sizeDistance(size int) int {
+	scaled := size
+	dirSize := d.size * d.scale
+	switch d.dirType {
+	case "Fixed":
+		return absInt(dirSize - scaled)
+	case "Scalable":
+		if scaled < d.minSize*d.scale {
+			return d.minSize*d.scale - scaled
+		}
+		if scaled > d.maxSize*d.scale {
+			return scaled - d.maxSize*d.scale
+		}
+		return 0
+	default:
+		if scaled < (d.size-d.threshold)*d.scale {
+			return d.minSize*d.scale - scaled
+		}
+		if scaled > (d.size+d.threshold)*d.scale {
+			return scaled - d.maxSize*d.scale
+		}
+		return 0
+	}
+}
+
+func absInt(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+func isRegularFile(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.Mode().IsRegular()
+}
+
+var (
+	iconThemeNameMu        sync.Mutex
+	iconThemeNameCache     string
+	iconThemeNameCheckedAt time.Time
+)
+
+// CurrentIconThemeName returns the icon theme configured by the desktop environment.
+// The value is cached for a short time since it is consulted for every icon lookup.
+func CurrentIconThemeName() string {
+	iconThemeNameMu.Lock()
+	defer iconThemeNameMu.Unlock()
+
+	if !iconThemeNameCheckedAt.IsZero() && time.Since(iconThemeNameCheckedAt) < 30*time.Second {
+		return iconThemeNameCache
+	}
+	iconThemeNameCache = detectIconThemeName()
+	iconThemeNameCheckedAt = time.Now()
+	return iconThemeNameCache
+}
+
+func detectIconThemeName() string {
+	if _, err := exec.LookPath("gsettings"); err == nil {
+		output, err := exec.Command("gsettings", "get", "org.gnome.desktop.interface", "icon-theme").Output()
+		if err == nil {
+			if name := strings.Trim(strings.TrimSpace(string(output)), "'\""); name != "" {
+				return name
+			}
+		}
+	}
+	configHome := ConfigHome()
+	if name := readIniValue(filepath.Join(configHome, "gtk-3.0", "settings.ini"), "Settings", "gtk-icon-theme-name"); name != "" {
+		return name
+	}
+	if name := readIniValue(filepath.Join(configHome, "kdeglobals"), "Icons", "Theme"); name != "" {
+		return name
+	}
+	return DefaultIconTheme
+}
+
+func readIniValue(path string, group string, key string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	currentGroup := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			currentGroup = line[1 : len(line)-1]
+			continue
+		}
+		if currentGroup != group {
+			continue
+		}
+		if k, v, found := strings.Cut(line, "="); found && strings.TrimSpace(k) == key {
+			return strings.Trim(strings.TrimSpace(v), "\"")
+		}
+	}
+	return ""
+}
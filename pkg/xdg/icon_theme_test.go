@@ -0,0 +1,98 @@
+package xdg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeIconFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("mkdir %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestIconResolverLookupIcon(t *testing.T) {
+	t.Parallel()
+
+	iconsDir := t.TempDir()
+	pixmapsDir := t.TempDir()
+
+	writeIconFile(t, filepath.Join(iconsDir, "Custom", "index.theme"), `[Icon Theme]
+Name=Custom
+Inherits=Parent
+Directories=48x48/apps,scalable/apps
+
+[48x48/apps]
+Size=48
+Type=Fixed
+
+[scalable/apps]
+Size=64
+MinSize=16
+MaxSize=512
+Type=Scalable
+`)
+	writeIconFile(t, filepath.Join(iconsDir, "Parent", "index.theme"), `[Icon Theme]
+Name=Parent
+Inherits=Custom
+Directories=32x32/apps
+
+[32x32/apps]
+Size=32
+`)
+	writeIconFile(t, filepath.Join(iconsDir, "hicolor", "index.theme"), `[Icon Theme]
+Name=Hicolor
+Directories=16x16/apps,128x128/apps
+
+[16x16/apps]
+Size=16
+Type=Threshold
+
+[128x128/apps]
+Size=128
+Type=Threshold
+`)
+
+	writeIconFile(t, filepath.Join(iconsDir, "Custom", "48x48", "apps", "editor.png"), "png")
+	writeIconFile(t, filepath.Join(iconsDir, "Custom", "scalable", "apps", "editor.svg"), "svg")
+	writeIconFile(t, filepath.Join(iconsDir, "Custom", "48x48", "apps", "fixed.png"), "png")
+	writeIconFile(t, filepath.Join(iconsDir, "Parent", "32x32", "apps", "inherited.png"), "png")
+	writeIconFile(t, filepath.Join(iconsDir, "hicolor", "16x16", "apps", "fallback.png"), "png")
+	writeIconFile(t, filepath.Join(iconsDir, "hicolor", "128x128", "apps", "fallback.png"), "png")
+	writeIconFile(t, filepath.Join(pixmapsDir, "legacy.xpm"), "xpm")
+
+	resolver := NewIconResolver([]string{iconsDir, pixmapsDir}, func() string { return "Custom" })
+
+	cases := []struct {
+		name string
+		icon string
+		size int
+		want string
+	}{
+		{name: "exact fixed size", icon: "editor", size: 48, want: filepath.Join(iconsDir, "Custom", "48x48", "apps", "editor.png")},
+		{name: "scalable covers size", icon: "editor", size: 128, want: filepath.Join(iconsDir, "Custom", "scalable", "apps", "editor.svg")},
+		{name: "closest size", icon: "fixed", size: 128, want: filepath.Join(iconsDir, "Custom", "48x48", "apps", "fixed.png")},
+		{name: "inherited theme", icon: "inherited", size: 128, want: filepath.Join(iconsDir, "Parent", "32x32", "apps", "inherited.png")},
+		{name: "hicolor fallback", icon: "fallback", size: 128, want: filepath.Join(iconsDir, "hicolor", "128x128", "apps", "fallback.png")},
+		{name: "pixmaps fallback", icon: "legacy", size: 128, want: filepath.Join(pixmapsDir, "legacy.xpm")},
+		{name: "extension is ignored", icon: "legacy.xpm", size: 128, want: filepath.Join(pixmapsDir, "legacy.xpm")},
+	}
+	for _, tc := range cases {
+		got, err := resolver.LookupIcon(tc.icon, tc.size)
+		if err != nil {
+			t.Fatalf("%s: LookupIcon(%q) returned error: %v", tc.name, tc.icon, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: LookupIcon(%q) = %q, want %q", tc.name, tc.icon, got, tc.want)
+		}
+	}
+
+	if _, err := resolver.LookupIcon("missing", 128); err == nil {
+		t.Fatalf("LookupIcon(missing) expected error")
+	}
+}
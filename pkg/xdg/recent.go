@@ -0,0 +1,119 @@
+package xdg
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// RecentlyUsedFileName is the name of the desktop bookmark file GTK and KDE record recent documents in
+const RecentlyUsedFileName = "recently-used.xbel"
+
+// RecentFile is a document recorded in recently-used.xbel
+type RecentFile struct {
+	URI      string
+	MimeType string
+	// Application is the application that registered the document last, Exec is its command with %f or %u
+	Application string
+	Exec        string
+	// VisitedAt is the latest of the times the document was added, modified or visited
+	VisitedAt time.Time
+}
+
+type xbelDocument struct {
+	Bookmarks []xbelBookmark `xml:"bookmark"`
+}
+
+type xbelBookmark struct {
+	Href     string `xml:"href,attr"`
+	Added    string `xml:"added,attr"`
+	Modified string `xml:"modified,attr"`
+	Visited  string `xml:"visited,attr"`
+	MimeType struct {
+		Type string `xml:"type,attr"`
+	} `xml:"info>metadata>mime-type"`
+	Applications []xbelApplication `xml:"info>metadata>applications>application"`
+}
+
+type xbelApplication struct {
+	Name     string `xml:"name,attr"`
+	Exec     string `xml:"exec,attr"`
+	Modified string `xml:"modified,attr"`
+}
+
+// RecentlyUsedPath returns the recently-used.xbel of the user
+func RecentlyUsedPath() string {
+	return filepath.Join(DataHome(), RecentlyUsedFileName)
+}
+
+// ParseRecentlyUsed reads the bookmarks of a desktop bookmark file
+func ParseRecentlyUsed(r io.Reader) ([]RecentFile, error) {
+	var document xbelDocument
+	if err := xml.NewDecoder(r).Decode(&document); err != nil {
+		return nil, fmt.Errorf("failed to parse recently used file: %w", err)
+	}
+	files := make([]RecentFile, 0, len(document.Bookmarks))
+	for _, bookmark := range document.Bookmarks {
+		if bookmark.Href == "" {
+			continue
+		}
+		file := RecentFile{
+			URI:       bookmark.Href,
+			MimeType:  bookmark.MimeType.Type,
+			VisitedAt: latestTime(bookmark.Added, bookmark.Modified, bookmark.Visited),
+		}
+		var applicationAt time.Time
+		for _, application := range bookmark.Applications {
+			modified := latestTime(application.Modified)
+			if file.Application == "" || modified.After(applicationAt) {
+				file.Application = application.Name
+				file.Exec = strings.Trim(application.Exec, "'")
+				applicationAt = modified
+			}
+		}
+		if applicationAt.After(file.VisitedAt) {
+			file.VisitedAt = applicationAt
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// ParseRecentlyUsedFile reads the bookmarks of the desktop bookmark file at path
+func ParseRecentlyUsedFile(path string) ([]RecentFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseRecentlyUsed(f)
+}
+
+// LocalPath returns the file path of a file:// URI, ok is false for other schemes
+func (f RecentFile) LocalPath() (string, bool) {
+	parsed, err := url.Parse(f.URI)
+	if err != nil || parsed.Scheme != "file" || parsed.Path == "" {
+		return "", false
+	}
+	if parsed.Host != "" && parsed.Host != "localhost" {
+		return "", false
+	}
+	return filepath.FromSlash(parsed.Path), true
+}
+
+// latestTime returns the latest of the RFC 3339 timestamps that parse, GTK writes the Unix epoch for unset times
+func latestTime(values ...string) time.Time {
+	var latest time.Time
+	for _, value := range values {
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		if err == nil && parsed.After(latest) {
+			latest = parsed
+		}
+	}
+	return latest
+}
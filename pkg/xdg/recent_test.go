@@ -0,0 +1,55 @@
+package xdg
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleRecentlyUsed = `<?xml version="1.0" encoding="UTF-8"?>
+<xbel version="1.0"
+      xmlns:bookmark="http://www.freedesktop.org/standards/desktop-bookmarks"
+      xmlns:mime="http://www.freedesktop.org/standards/shared-mime-info"
+>
+  <bookmark href="file:///home/user/Documents/My%20Report.odt" added="2024-03-01T09:00:00.000000Z" modified="2024-03-02T10:00:00.000000Z" visited="1970-01-01T00:00:00Z">
+    <info>
+      <metadata owner="http://freedesktop.org">
+        <mime:mime-type type="application/vnd.oasis.opendocument.text"/>
+        <bookmark:applications>
+          <bookmark:application name="Files" exec="&apos;nautilus %u&apos;" modified="2024-03-01T09:00:00Z" count="1"/>
+          <bookmark:application name="LibreOffice" exec="&apos;soffice %u&apos;" modified="2024-03-05T08:30:00Z" count="3"/>
+        </bookmark:applications>
+      </metadata>
+    </info>
+  </bookmark>
+  <bookmark href="https://example.com/page" added="2024-03-01T09:00:00Z" modified="2024-03-01T09:00:00Z" visited="2024-03-01T09:00:00Z"/>
+</xbel>
+`
+
+func TestParseRecentlyUsed(t *testing.T) {
+	files, err := ParseRecentlyUsed(strings.NewReader(sampleRecentlyUsed))
+	if err != nil {
+		t.Fatalf("expected recently used file to parse: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 bookmarks, got %d", len(files))
+	}
+
+	document := files[0]
+	if document.MimeType != "application/vnd.oasis.opendocument.text" {
+		t.Fatalf("unexpected mime type %q", document.MimeType)
+	}
+	if document.Application != "LibreOffice" || document.Exec != "soffice %u" {
+		t.Fatalf("expected the latest application, got %q %q", document.Application, document.Exec)
+	}
+	if !document.VisitedAt.Equal(time.Date(2024, 3, 5, 8, 30, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected visit time %v", document.VisitedAt)
+	}
+	if path, ok := document.LocalPath(); !ok || path != filepath.FromSlash("/home/user/Documents/My Report.odt") {
+		t.Fatalf("unexpected local path %q", path)
+	}
+	if _, ok := files[1].LocalPath(); ok {
+		t.Fatal("expected a web bookmark to have no local path")
+	}
+}
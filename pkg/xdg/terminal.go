@@ -0,0 +1,63 @@
+package xdg
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// terminalExecFlags maps known terminal emulators to the flag that precedes the command to run
+var terminalExecFlags = map[string]string{
+	"x-terminal-emulator": "-e",
+	"gnome-terminal":      "--",
+	"kgx":                 "--",
+	"ptyxis":              "--",
+	"konsole":             "-e",
+	"xfce4-terminal":      "-x",
+	"mate-terminal":       "-x",
+	"tilix":               "-e",
+	"alacritty":           "-e",
+	"kitty":               "",
+	"foot":                "",
+	"wezterm":             "start --",
+	"xterm":               "-e",
+}
+
+// terminalPreference is the lookup order used when $TERMINAL is not set
+var terminalPreference = []string{
+	"x-terminal-emulator", "gnome-terminal", "kgx", "ptyxis", "konsole", "xfce4-terminal",
+	"mate-terminal", "tilix", "alacritty", "kitty", "foot", "wezterm", "xterm",
+}
+
+// TerminalCommand wraps args so they run inside a terminal emulator, $TERMINAL takes precedence over the known emulators
+func TerminalCommand(args []string) ([]string, error) {
+	if terminal := strings.TrimSpace(os.Getenv("TERMINAL")); terminal != "" {
+		terminalArgs, err := SplitExec(terminal)
+		if err == nil {
+			if _, err := exec.LookPath(terminalArgs[0]); err == nil {
+				return wrapInTerminal(terminalArgs, args), nil
+			}
+		}
+	}
+	for _, name := range terminalPreference {
+		if _, err := exec.LookPath(name); err == nil {
+			return wrapInTerminal([]string{name}, args), nil
+		}
+	}
+	return nil, fmt.Errorf("no terminal emulator found")
+}
+
+func wrapInTerminal(terminalArgs []string, args []string) []string {
+	command := append([]string{}, terminalArgs...)
+	// only add the exec flag when $TERMINAL did not already carry one
+	if len(terminalArgs) == 1 {
+		if flag := terminalExecFlags[filepath.Base(terminalArgs[0])]; flag != "" {
+			command = append(command, strings.Fields(flag)...)
+		} else if _, known := terminalExecFlags[filepath.Base(terminalArgs[0])]; !known {
+			command = append(command, "-e")
+		}
+	}
+	return append(command, args...)
+}